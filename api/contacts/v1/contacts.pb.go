@@ -0,0 +1,1267 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: api/contacts/v1/contacts.proto
+
+package contactsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Contact é um contato do tenant
+type Contact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,6,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contact) Reset() {
+	*x = Contact{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contact) ProtoMessage() {}
+
+func (x *Contact) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contact.ProtoReflect.Descriptor instead.
+func (*Contact) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Contact) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Contact) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Contact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Contact) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Contact) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Contact) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *Contact) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Contact) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateContactRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Obrigatório
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Obrigatório; deve ser um email válido e único no tenant
+	Email         string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         string `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	CategoryId    string `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateContactRequest) Reset() {
+	*x = CreateContactRequest{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateContactRequest) ProtoMessage() {}
+
+func (x *CreateContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateContactRequest.ProtoReflect.Descriptor instead.
+func (*CreateContactRequest) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateContactRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CreateContactRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+type CreateContactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contact       *Contact               `protobuf:"bytes,1,opt,name=contact,proto3" json:"contact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateContactResponse) Reset() {
+	*x = CreateContactResponse{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateContactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateContactResponse) ProtoMessage() {}
+
+func (x *CreateContactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateContactResponse.ProtoReflect.Descriptor instead.
+func (*CreateContactResponse) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateContactResponse) GetContact() *Contact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+type GetContactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContactRequest) Reset() {
+	*x = GetContactRequest{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContactRequest) ProtoMessage() {}
+
+func (x *GetContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContactRequest.ProtoReflect.Descriptor instead.
+func (*GetContactRequest) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetContactRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetContactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contact       *Contact               `protobuf:"bytes,1,opt,name=contact,proto3" json:"contact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContactResponse) Reset() {
+	*x = GetContactResponse{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContactResponse) ProtoMessage() {}
+
+func (x *GetContactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContactResponse.ProtoReflect.Descriptor instead.
+func (*GetContactResponse) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetContactResponse) GetContact() *Contact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+// UpdateContactRequest substitui todos os campos do contato, como o PUT da API REST
+type UpdateContactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,5,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateContactRequest) Reset() {
+	*x = UpdateContactRequest{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateContactRequest) ProtoMessage() {}
+
+func (x *UpdateContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateContactRequest.ProtoReflect.Descriptor instead.
+func (*UpdateContactRequest) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateContactRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *UpdateContactRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+type UpdateContactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contact       *Contact               `protobuf:"bytes,1,opt,name=contact,proto3" json:"contact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateContactResponse) Reset() {
+	*x = UpdateContactResponse{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateContactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateContactResponse) ProtoMessage() {}
+
+func (x *UpdateContactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateContactResponse.ProtoReflect.Descriptor instead.
+func (*UpdateContactResponse) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateContactResponse) GetContact() *Contact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+type DeleteContactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteContactRequest) Reset() {
+	*x = DeleteContactRequest{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteContactRequest) ProtoMessage() {}
+
+func (x *DeleteContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteContactRequest.ProtoReflect.Descriptor instead.
+func (*DeleteContactRequest) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteContactRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteContactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteContactResponse) Reset() {
+	*x = DeleteContactResponse{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteContactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteContactResponse) ProtoMessage() {}
+
+func (x *DeleteContactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteContactResponse.ProtoReflect.Descriptor instead.
+func (*DeleteContactResponse) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{8}
+}
+
+type ListContactsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContactsRequest) Reset() {
+	*x = ListContactsRequest{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContactsRequest) ProtoMessage() {}
+
+func (x *ListContactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContactsRequest.ProtoReflect.Descriptor instead.
+func (*ListContactsRequest) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{9}
+}
+
+type ListContactsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contact       *Contact               `protobuf:"bytes,1,opt,name=contact,proto3" json:"contact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContactsResponse) Reset() {
+	*x = ListContactsResponse{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContactsResponse) ProtoMessage() {}
+
+func (x *ListContactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContactsResponse.ProtoReflect.Descriptor instead.
+func (*ListContactsResponse) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListContactsResponse) GetContact() *Contact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+// ExportContactsRequest pede as alterações desde sync_token; sem token, todos
+// os contatos. O token é o mesmo de GET /contacts/sync.
+type ExportContactsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SyncToken     string                 `protobuf:"bytes,1,opt,name=sync_token,json=syncToken,proto3" json:"sync_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportContactsRequest) Reset() {
+	*x = ExportContactsRequest{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportContactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportContactsRequest) ProtoMessage() {}
+
+func (x *ExportContactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportContactsRequest.ProtoReflect.Descriptor instead.
+func (*ExportContactsRequest) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExportContactsRequest) GetSyncToken() string {
+	if x != nil {
+		return x.SyncToken
+	}
+	return ""
+}
+
+// ExportContactsResponse é um contato criado ou atualizado, um contato
+// removido ou, na última mensagem do stream, o token da próxima exportação
+type ExportContactsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Change:
+	//
+	//	*ExportContactsResponse_Contact
+	//	*ExportContactsResponse_Deleted
+	//	*ExportContactsResponse_SyncToken
+	Change        isExportContactsResponse_Change `protobuf_oneof:"change"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportContactsResponse) Reset() {
+	*x = ExportContactsResponse{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportContactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportContactsResponse) ProtoMessage() {}
+
+func (x *ExportContactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportContactsResponse.ProtoReflect.Descriptor instead.
+func (*ExportContactsResponse) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExportContactsResponse) GetChange() isExportContactsResponse_Change {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+func (x *ExportContactsResponse) GetContact() *Contact {
+	if x != nil {
+		if x, ok := x.Change.(*ExportContactsResponse_Contact); ok {
+			return x.Contact
+		}
+	}
+	return nil
+}
+
+func (x *ExportContactsResponse) GetDeleted() *DeletedContact {
+	if x != nil {
+		if x, ok := x.Change.(*ExportContactsResponse_Deleted); ok {
+			return x.Deleted
+		}
+	}
+	return nil
+}
+
+func (x *ExportContactsResponse) GetSyncToken() string {
+	if x != nil {
+		if x, ok := x.Change.(*ExportContactsResponse_SyncToken); ok {
+			return x.SyncToken
+		}
+	}
+	return ""
+}
+
+type isExportContactsResponse_Change interface {
+	isExportContactsResponse_Change()
+}
+
+type ExportContactsResponse_Contact struct {
+	Contact *Contact `protobuf:"bytes,1,opt,name=contact,proto3,oneof"`
+}
+
+type ExportContactsResponse_Deleted struct {
+	Deleted *DeletedContact `protobuf:"bytes,2,opt,name=deleted,proto3,oneof"`
+}
+
+type ExportContactsResponse_SyncToken struct {
+	SyncToken string `protobuf:"bytes,3,opt,name=sync_token,json=syncToken,proto3,oneof"`
+}
+
+func (*ExportContactsResponse_Contact) isExportContactsResponse_Change() {}
+
+func (*ExportContactsResponse_Deleted) isExportContactsResponse_Change() {}
+
+func (*ExportContactsResponse_SyncToken) isExportContactsResponse_Change() {}
+
+type DeletedContact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletedContact) Reset() {
+	*x = DeletedContact{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletedContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletedContact) ProtoMessage() {}
+
+func (x *DeletedContact) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletedContact.ProtoReflect.Descriptor instead.
+func (*DeletedContact) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeletedContact) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeletedContact) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+// BatchContactsRequest é uma operação do lote; request_id é devolvido na
+// resposta correspondente
+type BatchContactsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Types that are valid to be assigned to Operation:
+	//
+	//	*BatchContactsRequest_Create
+	//	*BatchContactsRequest_Update
+	//	*BatchContactsRequest_Delete
+	Operation     isBatchContactsRequest_Operation `protobuf_oneof:"operation"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchContactsRequest) Reset() {
+	*x = BatchContactsRequest{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchContactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchContactsRequest) ProtoMessage() {}
+
+func (x *BatchContactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchContactsRequest.ProtoReflect.Descriptor instead.
+func (*BatchContactsRequest) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BatchContactsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *BatchContactsRequest) GetOperation() isBatchContactsRequest_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *BatchContactsRequest) GetCreate() *CreateContactRequest {
+	if x != nil {
+		if x, ok := x.Operation.(*BatchContactsRequest_Create); ok {
+			return x.Create
+		}
+	}
+	return nil
+}
+
+func (x *BatchContactsRequest) GetUpdate() *UpdateContactRequest {
+	if x != nil {
+		if x, ok := x.Operation.(*BatchContactsRequest_Update); ok {
+			return x.Update
+		}
+	}
+	return nil
+}
+
+func (x *BatchContactsRequest) GetDelete() *DeleteContactRequest {
+	if x != nil {
+		if x, ok := x.Operation.(*BatchContactsRequest_Delete); ok {
+			return x.Delete
+		}
+	}
+	return nil
+}
+
+type isBatchContactsRequest_Operation interface {
+	isBatchContactsRequest_Operation()
+}
+
+type BatchContactsRequest_Create struct {
+	Create *CreateContactRequest `protobuf:"bytes,2,opt,name=create,proto3,oneof"`
+}
+
+type BatchContactsRequest_Update struct {
+	Update *UpdateContactRequest `protobuf:"bytes,3,opt,name=update,proto3,oneof"`
+}
+
+type BatchContactsRequest_Delete struct {
+	Delete *DeleteContactRequest `protobuf:"bytes,4,opt,name=delete,proto3,oneof"`
+}
+
+func (*BatchContactsRequest_Create) isBatchContactsRequest_Operation() {}
+
+func (*BatchContactsRequest_Update) isBatchContactsRequest_Operation() {}
+
+func (*BatchContactsRequest_Delete) isBatchContactsRequest_Operation() {}
+
+// BatchContactsResponse é o resultado de uma operação do lote. A falha de uma
+// operação não encerra o stream.
+type BatchContactsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*BatchContactsResponse_Contact
+	//	*BatchContactsResponse_Deleted
+	//	*BatchContactsResponse_Error
+	Result        isBatchContactsResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchContactsResponse) Reset() {
+	*x = BatchContactsResponse{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchContactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchContactsResponse) ProtoMessage() {}
+
+func (x *BatchContactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchContactsResponse.ProtoReflect.Descriptor instead.
+func (*BatchContactsResponse) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BatchContactsResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *BatchContactsResponse) GetResult() isBatchContactsResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *BatchContactsResponse) GetContact() *Contact {
+	if x != nil {
+		if x, ok := x.Result.(*BatchContactsResponse_Contact); ok {
+			return x.Contact
+		}
+	}
+	return nil
+}
+
+func (x *BatchContactsResponse) GetDeleted() *DeleteContactResponse {
+	if x != nil {
+		if x, ok := x.Result.(*BatchContactsResponse_Deleted); ok {
+			return x.Deleted
+		}
+	}
+	return nil
+}
+
+func (x *BatchContactsResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*BatchContactsResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isBatchContactsResponse_Result interface {
+	isBatchContactsResponse_Result()
+}
+
+type BatchContactsResponse_Contact struct {
+	Contact *Contact `protobuf:"bytes,2,opt,name=contact,proto3,oneof"`
+}
+
+type BatchContactsResponse_Deleted struct {
+	Deleted *DeleteContactResponse `protobuf:"bytes,3,opt,name=deleted,proto3,oneof"`
+}
+
+type BatchContactsResponse_Error struct {
+	Error *Error `protobuf:"bytes,4,opt,name=error,proto3,oneof"`
+}
+
+func (*BatchContactsResponse_Contact) isBatchContactsResponse_Result() {}
+
+func (*BatchContactsResponse_Deleted) isBatchContactsResponse_Result() {}
+
+func (*BatchContactsResponse_Error) isBatchContactsResponse_Result() {}
+
+// Error é a falha de uma operação do lote, com o código de status gRPC
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contacts_v1_contacts_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_api_contacts_v1_contacts_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_api_contacts_v1_contacts_proto protoreflect.FileDescriptor
+
+const file_api_contacts_v1_contacts_proto_rawDesc = "" +
+	"\n" +
+	"\x1eapi/contacts/v1/contacts.proto\x12\vcontacts.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8d\x02\n" +
+	"\aContact\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x05 \x01(\tR\x05phone\x12\x1f\n" +
+	"\vcategory_id\x18\x06 \x01(\tR\n" +
+	"categoryId\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"w\n" +
+	"\x14CreateContactRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x1f\n" +
+	"\vcategory_id\x18\x04 \x01(\tR\n" +
+	"categoryId\"G\n" +
+	"\x15CreateContactResponse\x12.\n" +
+	"\acontact\x18\x01 \x01(\v2\x14.contacts.v1.ContactR\acontact\"#\n" +
+	"\x11GetContactRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"D\n" +
+	"\x12GetContactResponse\x12.\n" +
+	"\acontact\x18\x01 \x01(\v2\x14.contacts.v1.ContactR\acontact\"\x87\x01\n" +
+	"\x14UpdateContactRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x1f\n" +
+	"\vcategory_id\x18\x05 \x01(\tR\n" +
+	"categoryId\"G\n" +
+	"\x15UpdateContactResponse\x12.\n" +
+	"\acontact\x18\x01 \x01(\v2\x14.contacts.v1.ContactR\acontact\"&\n" +
+	"\x14DeleteContactRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x15DeleteContactResponse\"\x15\n" +
+	"\x13ListContactsRequest\"F\n" +
+	"\x14ListContactsResponse\x12.\n" +
+	"\acontact\x18\x01 \x01(\v2\x14.contacts.v1.ContactR\acontact\"6\n" +
+	"\x15ExportContactsRequest\x12\x1d\n" +
+	"\n" +
+	"sync_token\x18\x01 \x01(\tR\tsyncToken\"\xae\x01\n" +
+	"\x16ExportContactsResponse\x120\n" +
+	"\acontact\x18\x01 \x01(\v2\x14.contacts.v1.ContactH\x00R\acontact\x127\n" +
+	"\adeleted\x18\x02 \x01(\v2\x1b.contacts.v1.DeletedContactH\x00R\adeleted\x12\x1f\n" +
+	"\n" +
+	"sync_token\x18\x03 \x01(\tH\x00R\tsyncTokenB\b\n" +
+	"\x06change\"[\n" +
+	"\x0eDeletedContact\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"deleted_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\xf9\x01\n" +
+	"\x14BatchContactsRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12;\n" +
+	"\x06create\x18\x02 \x01(\v2!.contacts.v1.CreateContactRequestH\x00R\x06create\x12;\n" +
+	"\x06update\x18\x03 \x01(\v2!.contacts.v1.UpdateContactRequestH\x00R\x06update\x12;\n" +
+	"\x06delete\x18\x04 \x01(\v2!.contacts.v1.DeleteContactRequestH\x00R\x06deleteB\v\n" +
+	"\toperation\"\xde\x01\n" +
+	"\x15BatchContactsResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x120\n" +
+	"\acontact\x18\x02 \x01(\v2\x14.contacts.v1.ContactH\x00R\acontact\x12>\n" +
+	"\adeleted\x18\x03 \x01(\v2\".contacts.v1.DeleteContactResponseH\x00R\adeleted\x12*\n" +
+	"\x05error\x18\x04 \x01(\v2\x12.contacts.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"5\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage2\xf8\x04\n" +
+	"\x0fContactsService\x12V\n" +
+	"\rCreateContact\x12!.contacts.v1.CreateContactRequest\x1a\".contacts.v1.CreateContactResponse\x12M\n" +
+	"\n" +
+	"GetContact\x12\x1e.contacts.v1.GetContactRequest\x1a\x1f.contacts.v1.GetContactResponse\x12V\n" +
+	"\rUpdateContact\x12!.contacts.v1.UpdateContactRequest\x1a\".contacts.v1.UpdateContactResponse\x12V\n" +
+	"\rDeleteContact\x12!.contacts.v1.DeleteContactRequest\x1a\".contacts.v1.DeleteContactResponse\x12U\n" +
+	"\fListContacts\x12 .contacts.v1.ListContactsRequest\x1a!.contacts.v1.ListContactsResponse0\x01\x12[\n" +
+	"\x0eExportContacts\x12\".contacts.v1.ExportContactsRequest\x1a#.contacts.v1.ExportContactsResponse0\x01\x12Z\n" +
+	"\rBatchContacts\x12!.contacts.v1.BatchContactsRequest\x1a\".contacts.v1.BatchContactsResponse(\x010\x01BBZ@github.com/Felipe8297/go-contacts-api/api/contacts/v1;contactsv1b\x06proto3"
+
+var (
+	file_api_contacts_v1_contacts_proto_rawDescOnce sync.Once
+	file_api_contacts_v1_contacts_proto_rawDescData []byte
+)
+
+func file_api_contacts_v1_contacts_proto_rawDescGZIP() []byte {
+	file_api_contacts_v1_contacts_proto_rawDescOnce.Do(func() {
+		file_api_contacts_v1_contacts_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_contacts_v1_contacts_proto_rawDesc), len(file_api_contacts_v1_contacts_proto_rawDesc)))
+	})
+	return file_api_contacts_v1_contacts_proto_rawDescData
+}
+
+var file_api_contacts_v1_contacts_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_api_contacts_v1_contacts_proto_goTypes = []any{
+	(*Contact)(nil),                // 0: contacts.v1.Contact
+	(*CreateContactRequest)(nil),   // 1: contacts.v1.CreateContactRequest
+	(*CreateContactResponse)(nil),  // 2: contacts.v1.CreateContactResponse
+	(*GetContactRequest)(nil),      // 3: contacts.v1.GetContactRequest
+	(*GetContactResponse)(nil),     // 4: contacts.v1.GetContactResponse
+	(*UpdateContactRequest)(nil),   // 5: contacts.v1.UpdateContactRequest
+	(*UpdateContactResponse)(nil),  // 6: contacts.v1.UpdateContactResponse
+	(*DeleteContactRequest)(nil),   // 7: contacts.v1.DeleteContactRequest
+	(*DeleteContactResponse)(nil),  // 8: contacts.v1.DeleteContactResponse
+	(*ListContactsRequest)(nil),    // 9: contacts.v1.ListContactsRequest
+	(*ListContactsResponse)(nil),   // 10: contacts.v1.ListContactsResponse
+	(*ExportContactsRequest)(nil),  // 11: contacts.v1.ExportContactsRequest
+	(*ExportContactsResponse)(nil), // 12: contacts.v1.ExportContactsResponse
+	(*DeletedContact)(nil),         // 13: contacts.v1.DeletedContact
+	(*BatchContactsRequest)(nil),   // 14: contacts.v1.BatchContactsRequest
+	(*BatchContactsResponse)(nil),  // 15: contacts.v1.BatchContactsResponse
+	(*Error)(nil),                  // 16: contacts.v1.Error
+	(*timestamppb.Timestamp)(nil),  // 17: google.protobuf.Timestamp
+}
+var file_api_contacts_v1_contacts_proto_depIdxs = []int32{
+	17, // 0: contacts.v1.Contact.created_at:type_name -> google.protobuf.Timestamp
+	17, // 1: contacts.v1.Contact.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: contacts.v1.CreateContactResponse.contact:type_name -> contacts.v1.Contact
+	0,  // 3: contacts.v1.GetContactResponse.contact:type_name -> contacts.v1.Contact
+	0,  // 4: contacts.v1.UpdateContactResponse.contact:type_name -> contacts.v1.Contact
+	0,  // 5: contacts.v1.ListContactsResponse.contact:type_name -> contacts.v1.Contact
+	0,  // 6: contacts.v1.ExportContactsResponse.contact:type_name -> contacts.v1.Contact
+	13, // 7: contacts.v1.ExportContactsResponse.deleted:type_name -> contacts.v1.DeletedContact
+	17, // 8: contacts.v1.DeletedContact.deleted_at:type_name -> google.protobuf.Timestamp
+	1,  // 9: contacts.v1.BatchContactsRequest.create:type_name -> contacts.v1.CreateContactRequest
+	5,  // 10: contacts.v1.BatchContactsRequest.update:type_name -> contacts.v1.UpdateContactRequest
+	7,  // 11: contacts.v1.BatchContactsRequest.delete:type_name -> contacts.v1.DeleteContactRequest
+	0,  // 12: contacts.v1.BatchContactsResponse.contact:type_name -> contacts.v1.Contact
+	8,  // 13: contacts.v1.BatchContactsResponse.deleted:type_name -> contacts.v1.DeleteContactResponse
+	16, // 14: contacts.v1.BatchContactsResponse.error:type_name -> contacts.v1.Error
+	1,  // 15: contacts.v1.ContactsService.CreateContact:input_type -> contacts.v1.CreateContactRequest
+	3,  // 16: contacts.v1.ContactsService.GetContact:input_type -> contacts.v1.GetContactRequest
+	5,  // 17: contacts.v1.ContactsService.UpdateContact:input_type -> contacts.v1.UpdateContactRequest
+	7,  // 18: contacts.v1.ContactsService.DeleteContact:input_type -> contacts.v1.DeleteContactRequest
+	9,  // 19: contacts.v1.ContactsService.ListContacts:input_type -> contacts.v1.ListContactsRequest
+	11, // 20: contacts.v1.ContactsService.ExportContacts:input_type -> contacts.v1.ExportContactsRequest
+	14, // 21: contacts.v1.ContactsService.BatchContacts:input_type -> contacts.v1.BatchContactsRequest
+	2,  // 22: contacts.v1.ContactsService.CreateContact:output_type -> contacts.v1.CreateContactResponse
+	4,  // 23: contacts.v1.ContactsService.GetContact:output_type -> contacts.v1.GetContactResponse
+	6,  // 24: contacts.v1.ContactsService.UpdateContact:output_type -> contacts.v1.UpdateContactResponse
+	8,  // 25: contacts.v1.ContactsService.DeleteContact:output_type -> contacts.v1.DeleteContactResponse
+	10, // 26: contacts.v1.ContactsService.ListContacts:output_type -> contacts.v1.ListContactsResponse
+	12, // 27: contacts.v1.ContactsService.ExportContacts:output_type -> contacts.v1.ExportContactsResponse
+	15, // 28: contacts.v1.ContactsService.BatchContacts:output_type -> contacts.v1.BatchContactsResponse
+	22, // [22:29] is the sub-list for method output_type
+	15, // [15:22] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_api_contacts_v1_contacts_proto_init() }
+func file_api_contacts_v1_contacts_proto_init() {
+	if File_api_contacts_v1_contacts_proto != nil {
+		return
+	}
+	file_api_contacts_v1_contacts_proto_msgTypes[12].OneofWrappers = []any{
+		(*ExportContactsResponse_Contact)(nil),
+		(*ExportContactsResponse_Deleted)(nil),
+		(*ExportContactsResponse_SyncToken)(nil),
+	}
+	file_api_contacts_v1_contacts_proto_msgTypes[14].OneofWrappers = []any{
+		(*BatchContactsRequest_Create)(nil),
+		(*BatchContactsRequest_Update)(nil),
+		(*BatchContactsRequest_Delete)(nil),
+	}
+	file_api_contacts_v1_contacts_proto_msgTypes[15].OneofWrappers = []any{
+		(*BatchContactsResponse_Contact)(nil),
+		(*BatchContactsResponse_Deleted)(nil),
+		(*BatchContactsResponse_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_contacts_v1_contacts_proto_rawDesc), len(file_api_contacts_v1_contacts_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_contacts_v1_contacts_proto_goTypes,
+		DependencyIndexes: file_api_contacts_v1_contacts_proto_depIdxs,
+		MessageInfos:      file_api_contacts_v1_contacts_proto_msgTypes,
+	}.Build()
+	File_api_contacts_v1_contacts_proto = out.File
+	file_api_contacts_v1_contacts_proto_goTypes = nil
+	file_api_contacts_v1_contacts_proto_depIdxs = nil
+}
@@ -0,0 +1,366 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api/contacts/v1/contacts.proto
+
+package contactsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ContactsService_CreateContact_FullMethodName  = "/contacts.v1.ContactsService/CreateContact"
+	ContactsService_GetContact_FullMethodName     = "/contacts.v1.ContactsService/GetContact"
+	ContactsService_UpdateContact_FullMethodName  = "/contacts.v1.ContactsService/UpdateContact"
+	ContactsService_DeleteContact_FullMethodName  = "/contacts.v1.ContactsService/DeleteContact"
+	ContactsService_ListContacts_FullMethodName   = "/contacts.v1.ContactsService/ListContacts"
+	ContactsService_ExportContacts_FullMethodName = "/contacts.v1.ContactsService/ExportContacts"
+	ContactsService_BatchContacts_FullMethodName  = "/contacts.v1.ContactsService/BatchContacts"
+)
+
+// ContactsServiceClient is the client API for ContactsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ContactsService expõe as mesmas operações da API REST de contatos. O tenant
+// é informado no metadata x-tenant-id.
+type ContactsServiceClient interface {
+	CreateContact(ctx context.Context, in *CreateContactRequest, opts ...grpc.CallOption) (*CreateContactResponse, error)
+	GetContact(ctx context.Context, in *GetContactRequest, opts ...grpc.CallOption) (*GetContactResponse, error)
+	UpdateContact(ctx context.Context, in *UpdateContactRequest, opts ...grpc.CallOption) (*UpdateContactResponse, error)
+	DeleteContact(ctx context.Context, in *DeleteContactRequest, opts ...grpc.CallOption) (*DeleteContactResponse, error)
+	// ListContacts envia os contatos do tenant, um por mensagem
+	ListContacts(ctx context.Context, in *ListContactsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListContactsResponse], error)
+	// ExportContacts envia as alterações desde o token em páginas, seguidas do
+	// token da próxima exportação
+	ExportContacts(ctx context.Context, in *ExportContactsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportContactsResponse], error)
+	// BatchContacts executa as operações na ordem em que chegam e responde
+	// cada uma no mesmo stream
+	BatchContacts(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BatchContactsRequest, BatchContactsResponse], error)
+}
+
+type contactsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewContactsServiceClient(cc grpc.ClientConnInterface) ContactsServiceClient {
+	return &contactsServiceClient{cc}
+}
+
+func (c *contactsServiceClient) CreateContact(ctx context.Context, in *CreateContactRequest, opts ...grpc.CallOption) (*CreateContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateContactResponse)
+	err := c.cc.Invoke(ctx, ContactsService_CreateContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsServiceClient) GetContact(ctx context.Context, in *GetContactRequest, opts ...grpc.CallOption) (*GetContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetContactResponse)
+	err := c.cc.Invoke(ctx, ContactsService_GetContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsServiceClient) UpdateContact(ctx context.Context, in *UpdateContactRequest, opts ...grpc.CallOption) (*UpdateContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateContactResponse)
+	err := c.cc.Invoke(ctx, ContactsService_UpdateContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsServiceClient) DeleteContact(ctx context.Context, in *DeleteContactRequest, opts ...grpc.CallOption) (*DeleteContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteContactResponse)
+	err := c.cc.Invoke(ctx, ContactsService_DeleteContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsServiceClient) ListContacts(ctx context.Context, in *ListContactsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListContactsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ContactsService_ServiceDesc.Streams[0], ContactsService_ListContacts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListContactsRequest, ListContactsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContactsService_ListContactsClient = grpc.ServerStreamingClient[ListContactsResponse]
+
+func (c *contactsServiceClient) ExportContacts(ctx context.Context, in *ExportContactsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportContactsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ContactsService_ServiceDesc.Streams[1], ContactsService_ExportContacts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportContactsRequest, ExportContactsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContactsService_ExportContactsClient = grpc.ServerStreamingClient[ExportContactsResponse]
+
+func (c *contactsServiceClient) BatchContacts(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BatchContactsRequest, BatchContactsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ContactsService_ServiceDesc.Streams[2], ContactsService_BatchContacts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchContactsRequest, BatchContactsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContactsService_BatchContactsClient = grpc.BidiStreamingClient[BatchContactsRequest, BatchContactsResponse]
+
+// ContactsServiceServer is the server API for ContactsService service.
+// All implementations must embed UnimplementedContactsServiceServer
+// for forward compatibility.
+//
+// ContactsService expõe as mesmas operações da API REST de contatos. O tenant
+// é informado no metadata x-tenant-id.
+type ContactsServiceServer interface {
+	CreateContact(context.Context, *CreateContactRequest) (*CreateContactResponse, error)
+	GetContact(context.Context, *GetContactRequest) (*GetContactResponse, error)
+	UpdateContact(context.Context, *UpdateContactRequest) (*UpdateContactResponse, error)
+	DeleteContact(context.Context, *DeleteContactRequest) (*DeleteContactResponse, error)
+	// ListContacts envia os contatos do tenant, um por mensagem
+	ListContacts(*ListContactsRequest, grpc.ServerStreamingServer[ListContactsResponse]) error
+	// ExportContacts envia as alterações desde o token em páginas, seguidas do
+	// token da próxima exportação
+	ExportContacts(*ExportContactsRequest, grpc.ServerStreamingServer[ExportContactsResponse]) error
+	// BatchContacts executa as operações na ordem em que chegam e responde
+	// cada uma no mesmo stream
+	BatchContacts(grpc.BidiStreamingServer[BatchContactsRequest, BatchContactsResponse]) error
+	mustEmbedUnimplementedContactsServiceServer()
+}
+
+// UnimplementedContactsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedContactsServiceServer struct{}
+
+func (UnimplementedContactsServiceServer) CreateContact(context.Context, *CreateContactRequest) (*CreateContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateContact not implemented")
+}
+func (UnimplementedContactsServiceServer) GetContact(context.Context, *GetContactRequest) (*GetContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContact not implemented")
+}
+func (UnimplementedContactsServiceServer) UpdateContact(context.Context, *UpdateContactRequest) (*UpdateContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateContact not implemented")
+}
+func (UnimplementedContactsServiceServer) DeleteContact(context.Context, *DeleteContactRequest) (*DeleteContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteContact not implemented")
+}
+func (UnimplementedContactsServiceServer) ListContacts(*ListContactsRequest, grpc.ServerStreamingServer[ListContactsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListContacts not implemented")
+}
+func (UnimplementedContactsServiceServer) ExportContacts(*ExportContactsRequest, grpc.ServerStreamingServer[ExportContactsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportContacts not implemented")
+}
+func (UnimplementedContactsServiceServer) BatchContacts(grpc.BidiStreamingServer[BatchContactsRequest, BatchContactsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method BatchContacts not implemented")
+}
+func (UnimplementedContactsServiceServer) mustEmbedUnimplementedContactsServiceServer() {}
+func (UnimplementedContactsServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeContactsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ContactsServiceServer will
+// result in compilation errors.
+type UnsafeContactsServiceServer interface {
+	mustEmbedUnimplementedContactsServiceServer()
+}
+
+func RegisterContactsServiceServer(s grpc.ServiceRegistrar, srv ContactsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedContactsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ContactsService_ServiceDesc, srv)
+}
+
+func _ContactsService_CreateContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServiceServer).CreateContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContactsService_CreateContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServiceServer).CreateContact(ctx, req.(*CreateContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContactsService_GetContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServiceServer).GetContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContactsService_GetContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServiceServer).GetContact(ctx, req.(*GetContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContactsService_UpdateContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServiceServer).UpdateContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContactsService_UpdateContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServiceServer).UpdateContact(ctx, req.(*UpdateContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContactsService_DeleteContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServiceServer).DeleteContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContactsService_DeleteContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServiceServer).DeleteContact(ctx, req.(*DeleteContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContactsService_ListContacts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListContactsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContactsServiceServer).ListContacts(m, &grpc.GenericServerStream[ListContactsRequest, ListContactsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContactsService_ListContactsServer = grpc.ServerStreamingServer[ListContactsResponse]
+
+func _ContactsService_ExportContacts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportContactsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContactsServiceServer).ExportContacts(m, &grpc.GenericServerStream[ExportContactsRequest, ExportContactsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContactsService_ExportContactsServer = grpc.ServerStreamingServer[ExportContactsResponse]
+
+func _ContactsService_BatchContacts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ContactsServiceServer).BatchContacts(&grpc.GenericServerStream[BatchContactsRequest, BatchContactsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ContactsService_BatchContactsServer = grpc.BidiStreamingServer[BatchContactsRequest, BatchContactsResponse]
+
+// ContactsService_ServiceDesc is the grpc.ServiceDesc for ContactsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ContactsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "contacts.v1.ContactsService",
+	HandlerType: (*ContactsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateContact",
+			Handler:    _ContactsService_CreateContact_Handler,
+		},
+		{
+			MethodName: "GetContact",
+			Handler:    _ContactsService_GetContact_Handler,
+		},
+		{
+			MethodName: "UpdateContact",
+			Handler:    _ContactsService_UpdateContact_Handler,
+		},
+		{
+			MethodName: "DeleteContact",
+			Handler:    _ContactsService_DeleteContact_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListContacts",
+			Handler:       _ContactsService_ListContacts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportContacts",
+			Handler:       _ContactsService_ExportContacts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BatchContacts",
+			Handler:       _ContactsService_BatchContacts_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "api/contacts/v1/contacts.proto",
+}
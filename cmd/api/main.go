@@ -1,60 +1,458 @@
 package main
 
 import (
-	"log"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
+	"time"
 
 	_ "github.com/Felipe8297/go-contacts-api/docs"
+	"github.com/Felipe8297/go-contacts-api/internal/carddav"
 	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/grpcapi"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/apierror"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/auth"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/cache"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/config"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/consistency"
 	"github.com/Felipe8297/go-contacts-api/internal/pkg/db"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/health"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/idempotency"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/logger"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/metrics"
 	"github.com/Felipe8297/go-contacts-api/internal/pkg/middleware"
 	"github.com/Felipe8297/go-contacts-api/internal/pkg/migrations"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/outbox"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/ratelimit"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/server"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tracing"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/worker"
+	"github.com/Felipe8297/go-contacts-api/internal/stream"
+	"github.com/Felipe8297/go-contacts-api/internal/webhooks"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func main() {
-	if err := godotenv.Load(); err != nil {
-		log.Println("Arquivo .env não encontrado, usando variáveis de ambiente do sistema")
+	envErr := godotenv.Load()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("Erro ao carregar configuração", err)
+	}
+
+	log := logger.New(cfg.Log.Level, cfg.Log.Format, os.Stdout)
+	slog.SetDefault(log)
+
+	if envErr != nil {
+		slog.Info("Arquivo .env não encontrado, usando variáveis de ambiente do sistema")
 	}
+	slog.Info("Configuração efetiva", "config", cfg.String())
 
-	database, err := db.InitDB()
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
 	if err != nil {
-		log.Fatalf("Erro ao conectar ao banco de dados: %v", err)
+		fatal("Erro ao configurar tracing", err)
 	}
-	defer database.Close()
 
-	log.Println("Executando migrations pendentes...")
-	err = migrations.RunMigrations(database)
+	// Um sinal de término durante as tentativas de conexão encerra a espera
+	connectCtx, stopConnect := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	cluster, err := db.ConnectCluster(connectCtx, cfg.Database)
+	stopConnect()
 	if err != nil {
-		log.Fatalf("Erro ao executar migrações: %v", err)
+		fatal("Erro ao conectar ao banco de dados", err)
+	}
+	defer func() {
+		cluster.Close()
+		slog.Info("Conexão com o banco de dados fechada")
+	}()
+
+	database := cluster.Primary
+
+	if err := startupMigrations(database, cfg.Database.Driver, cfg.Migrations); err != nil {
+		cluster.Close()
+		fatal("Erro nas migrations", err)
 	}
 
 	gin.SetMode(gin.ReleaseMode)
+	if cfg.Log.Level == "debug" {
+		gin.SetMode(gin.DebugMode)
+	}
 
 	router := gin.New()
 
-	router.Use(gin.Logger())
-	router.Use(gin.Recovery())
+	// O span da requisição precisa envolver os demais middlewares para que as
+	// métricas HTTP recebam o trace como exemplar
+	router.Use(otelgin.Middleware(cfg.Tracing.ServiceName))
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.AccessLogMiddleware(log))
+	router.Use(gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		slog.ErrorContext(c.Request.Context(), "Panic ao processar requisição",
+			"panic", recovered, "stack", string(debug.Stack()))
+		apierror.Abort(c, http.StatusInternalServerError, "Erro interno do servidor")
+	}))
 	router.Use(middleware.MetricsMiddleware())
 
-	router.SetTrustedProxies([]string{"127.0.0.1"})
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		cluster.Close()
+		fatal("Erro ao configurar proxies confiáveis", err)
+	}
+
+	if err := metrics.RegisterDBStats(database, "contactsdb"); err != nil {
+		cluster.Close()
+		fatal("Erro ao registrar métricas do pool de conexões", err)
+	}
+	for i, replica := range cluster.Replicas() {
+		if err := metrics.RegisterDBStats(replica, fmt.Sprintf("contactsdb_replica_%d", i)); err != nil {
+			cluster.Close()
+			fatal("Erro ao registrar métricas do pool de conexões", err)
+		}
+	}
+
+	// As listagens usam as réplicas, se configuradas; as demais operações, o primário
+	storageRepo, dbSystem := contacts.NewPostgresRepositoryWithOptions(database, contacts.PostgresOptions{
+		EnforceRLS: cfg.Tenant.EnforceRLS,
+		Reader:     cluster.Reader,
+		Outbox:     cfg.Features.Events,
+	}), "postgresql"
+	if cfg.Database.Driver == "sqlite" {
+		storageRepo, dbSystem = contacts.NewSQLiteRepositoryWithOptions(database, contacts.SQLiteOptions{Outbox: cfg.Features.Events}), "sqlite"
+	}
+
+	// O prazo é aplicado dentro da instrumentação para que as métricas registrem
+	// as operações canceladas ou expiradas com o tempo gasto até o cancelamento
+	contactsRepo := contacts.NewTracingRepository(
+		contacts.NewInstrumentedRepository(
+			contacts.NewTimeoutRepository(
+				storageRepo,
+				contacts.Timeouts{Default: cfg.Database.QueryTimeout, Operations: cfg.Database.OperationTimeouts},
+			),
+		),
+		dbSystem,
+	)
+
+	// O cache fica por fora dos demais decoradores: acertos não geram spans
+	// nem métricas de banco
+	if cfg.Features.Cache {
+		contactsRepo = contacts.NewCachingRepository(contactsRepo, cache.NewLRU(cfg.Cache.Size), cfg.Cache.TTL)
+	}
 
-	contactsRepo := contacts.NewPostgresRepository(database)
-	contactsService := contacts.NewService(contactsRepo)
+	contactsService := contacts.NewTracingService(contacts.NewService(contactsRepo))
 	contactsHandler := contacts.NewHandler(contactsService)
 
-	contactsHandler.RegisterRoutes(router)
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	workers := worker.NewGroup()
+
+	// Remoções mais antigas que a retenção não são mais sincronizadas
+	workers.Add(worker.Task{
+		Name:     "sync-tombstone-purge",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			_, err := contacts.PurgeTombstones(ctx, database, cfg.Sync.TombstoneRetention)
+			return err
+		},
+	})
+
+	// Rotas de negócio exigem a resolução do tenant e, conforme as features
+	// habilitadas, estão sujeitas ao rate limit e honram o cabeçalho Idempotency-Key
+	var business gin.HandlersChain
+
+	if len(cluster.Replicas()) > 0 {
+		business = append(business, consistency.Middleware(cfg.Database.ReplicaStickyWindow))
+
+		workers.Add(worker.Task{
+			Name:     "replica-health",
+			Interval: cfg.Database.ReplicaHealthInterval,
+			Run: func(ctx context.Context) error {
+				return cluster.CheckReplicas(ctx, cfg.Database.ConnectTimeout)
+			},
+		})
+	}
+
+	var webhooksRepo webhooks.Repository
+	if cfg.Features.Webhooks {
+		webhooksRepo = webhooks.NewSQLRepository(database)
+
+		// O lease cobre o prazo de cada requisição com folga para gravar o resultado
+		dispatcher := webhooks.NewDispatcher(webhooksRepo, webhooks.NewHTTPClient(cfg.Webhooks.Timeout, cfg.Webhooks.AllowPrivateNetworks), webhooks.DispatcherOptions{
+			BatchSize:   cfg.Webhooks.BatchSize,
+			Lease:       cfg.Webhooks.Timeout + time.Minute,
+			Timeout:     cfg.Webhooks.Timeout,
+			MaxAttempts: cfg.Webhooks.MaxAttempts,
+			Backoff:     cfg.Webhooks.RetryBackoff,
+			MaxBackoff:  cfg.Webhooks.RetryMaxBackoff,
+		})
+
+		workers.Add(worker.Task{
+			Name:     "webhook-dispatcher",
+			Interval: cfg.Webhooks.PollInterval,
+			Run:      dispatcher.Run,
+		})
+		workers.Add(worker.Task{
+			Name:     "webhook-purge",
+			Interval: time.Hour,
+			Run: func(ctx context.Context) error {
+				_, err := webhooksRepo.Purge(ctx, cfg.Webhooks.Retention)
+				return err
+			},
+		})
+	}
+
+	var streamBroker *stream.Broker
+	var streamSink outbox.Sink
+	if cfg.Features.Stream {
+		streamBroker = stream.NewBroker(cfg.Stream.BufferSize)
+		streamSink = stream.NewLocalSink(streamBroker)
+
+		// Com o PostgreSQL o relay de qualquer réplica avisa todas por NOTIFY
+		if cfg.Database.Driver == "postgres" {
+			streamSink = stream.NewNotifySink(database)
+			listener := stream.NewListener(cfg.Database.DSN(), stream.NewSQLLog(database), streamBroker)
+
+			workers.Add(worker.Task{
+				Name:     "stream-listener",
+				Interval: time.Second,
+				Run:      listener.Run,
+			})
+		}
+	}
+
+	if cfg.Features.Events {
+		sinks := eventSinks(cfg.Events)
+		if webhooksRepo != nil {
+			sinks = append(sinks, webhooks.NewSink(webhooksRepo))
+		}
+		if streamSink != nil {
+			sinks = append(sinks, streamSink)
+		}
+
+		outboxStore := outbox.NewStore(database)
+		relay := outbox.NewRelay(outboxStore, sinks, outbox.RelayOptions{
+			BatchSize:  cfg.Events.BatchSize,
+			Lease:      time.Minute,
+			Backoff:    cfg.Events.RetryBackoff,
+			MaxBackoff: cfg.Events.RetryMaxBackoff,
+		})
+
+		workers.Add(worker.Task{
+			Name:     "outbox-relay",
+			Interval: cfg.Events.PollInterval,
+			Run:      relay.Run,
+		})
+		workers.Add(worker.Task{
+			Name:     "outbox-purge",
+			Interval: time.Hour,
+			Run: func(ctx context.Context) error {
+				_, err := outboxStore.Purge(ctx, cfg.Events.Retention)
+				return err
+			},
+		})
+	}
+
+	if cfg.Features.RateLimit {
+		// Em produção com várias réplicas use o store postgres para compartilhar o estado
+		var rateLimitStore ratelimit.Store = ratelimit.NewMemoryStore()
+		if cfg.RateLimit.Store == "postgres" {
+			postgresStore := ratelimit.NewPostgresStore(database)
+			rateLimitStore = postgresStore
+
+			workers.Add(worker.Task{
+				Name:     "rate-limit-purge",
+				Interval: 10 * time.Minute,
+				Run: func(ctx context.Context) error {
+					_, err := postgresStore.Purge(ctx, time.Hour)
+					return err
+				},
+			})
+		}
+
+		rateLimitConfig := ratelimit.DefaultConfig()
+		rateLimitConfig.Limit = ratelimit.Limit{Rate: cfg.RateLimit.Rate, Burst: cfg.RateLimit.Burst}
+		business = append(business, ratelimit.Middleware(rateLimitStore, rateLimitConfig))
+	}
+
+	// O store de idempotência existe apenas no PostgreSQL
+	idempotencyEnabled := cfg.Features.Idempotency && cfg.Database.Driver == "postgres"
+	if cfg.Features.Idempotency && !idempotencyEnabled {
+		slog.Warn("Idempotência desabilitada: o driver configurado não oferece um store", "driver", cfg.Database.Driver)
+	}
+
+	if idempotencyEnabled {
+		idempotencyStore := idempotency.NewPostgresStore(database, cfg.Idempotency.TTL, time.Minute)
+		business = append(business, idempotency.Middleware(idempotencyStore))
+
+		workers.Add(worker.Task{
+			Name:     "idempotency-purge",
+			Interval: 10 * time.Minute,
+			Run: func(ctx context.Context) error {
+				_, err := idempotencyStore.Purge(ctx)
+				return err
+			},
+		})
+	}
+
+	api := router.Group("", append(gin.HandlersChain{tenant.Middleware(cfg.Tenant.DefaultID)}, business...)...)
+
+	// Nas rotas autenticadas o tenant vem da chave de API, e não do cabeçalho
+	// X-Tenant-ID, que qualquer cliente poderia forjar
+	apiKeys := make([]auth.Key, 0, len(cfg.Auth.APIKeys))
+	for _, key := range cfg.Auth.APIKeys {
+		apiKeys = append(apiKeys, auth.Key{Tenant: key.Tenant, Secret: key.Key})
+	}
+	authenticated := router.Group("", append(gin.HandlersChain{auth.Middleware(apiKeys), tenant.Middleware(cfg.Tenant.DefaultID)}, business...)...)
+
+	contactsHandler.RegisterRoutes(api)
+	if streamBroker != nil {
+		stream.NewHandler(streamBroker, stream.NewSQLLog(database), stream.HandlerOptions{
+			HeartbeatInterval: cfg.Stream.HeartbeatInterval,
+			WriteTimeout:      cfg.Server.WriteTimeout,
+		}).RegisterRoutes(api)
+	}
+	if cfg.Features.CardDAV {
+		carddav.NewHandler(contactsService, carddav.NewSQLStore(database)).RegisterRoutes(authenticated)
+		carddav.RegisterWellKnown(router)
+	}
+	if webhooksRepo != nil {
+		webhooks.NewHandler(webhooks.NewServiceWithOptions(webhooksRepo, webhooks.ServiceOptions{
+			AllowPrivateNetworks: cfg.Webhooks.AllowPrivateNetworks,
+		})).RegisterRoutes(authenticated)
+	}
+
+	checker := health.NewChecker(cfg.Server.HealthCheckTimeout)
+	checker.Add("database", database.PingContext)
+	checker.Add("migrations", func(ctx context.Context) error {
+		pending, err := migrations.PendingMigrations(ctx, database, cfg.Database.Driver)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("migrations pendentes: %v", pending)
+		}
+		return nil
+	})
+	checker.Add("workers", func(ctx context.Context) error {
+		if !workers.Running() {
+			return fmt.Errorf("workers parados")
+		}
+		return nil
+	})
+	checker.RegisterRoutes(router)
+
+	if cfg.Features.Swagger {
+		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
 
 	// Endpoint para métricas do Prometheus
-	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	if cfg.Features.Metrics {
+		// O formato OpenMetrics é necessário para expor os exemplares com trace_id
+		metricsHandler := promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		})
+		router.GET("/metrics", gin.WrapH(promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer, metricsHandler)))
+	}
+
+	// SIGTERM (redeploy do container) ou SIGINT iniciam o desligamento gracioso
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Os workers só param depois que as requisições em andamento terminarem
+	workers.Start(context.Background())
+
+	// O pool de conexões é fechado pelo defer apenas após o servidor e os workers
+	srv := server.New(cfg.Server, router)
+
+	// As conexões do stream não terminam sozinhas; encerrá-las no início do
+	// desligamento evita que segurem o servidor até o fim do prazo
+	if streamBroker != nil {
+		srv.RegisterOnShutdown(streamBroker.Close)
+	}
+	hooks := server.Hooks{
+		Draining: []func(){checker.SetShuttingDown},
+		Stopped:  []server.ShutdownHook{workers.Stop, shutdownTracing},
+	}
+
+	// O servidor gRPC segue o mesmo desligamento: o health check passa a
+	// NOT_SERVING com a readiness e as chamadas em andamento terminam antes
+	// dos workers
+	if cfg.Features.GRPC {
+		grpcServer := grpcapi.New(contactsService, grpcapi.Options{
+			DefaultTenant: cfg.Tenant.DefaultID,
+			Reflection:    cfg.GRPC.Reflection,
+			Logger:        log,
+		})
+
+		grpcListener, err := net.Listen("tcp", cfg.GRPC.Addr)
+		if err != nil {
+			cluster.Close()
+			fatal("Erro ao abrir porta do servidor gRPC", err)
+		}
+		slog.Info("Servidor gRPC iniciado", "addr", grpcListener.Addr().String())
+
+		go func() {
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				slog.Error("Servidor gRPC finalizado com erro", "error", err)
+			}
+		}()
+
+		hooks.Draining = append(hooks.Draining, grpcServer.Drain)
+		hooks.Stopped = append([]server.ShutdownHook{grpcServer.Stop}, hooks.Stopped...)
+	}
+
+	if err := server.ListenAndRun(ctx, srv, cfg.Server, hooks); err != nil {
+		cluster.Close()
+		fatal("Erro ao executar o servidor", err)
+	}
+}
+
+// fatal registra o erro e encerra o processo. Defers não são executados.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// eventSinks cria os destinos dos eventos de contatos configurados
+func eventSinks(cfg config.EventsConfig) []outbox.Sink {
+	var sinks []outbox.Sink
+	for _, name := range cfg.Sinks {
+		switch name {
+		case "log":
+			sinks = append(sinks, outbox.NewLogSink())
+		}
+	}
+	return sinks
+}
+
+// startupMigrations aplica ou verifica as migrations conforme o modo
+// configurado. Com verify ou skip, as migrations ficam a cargo do cmd/migrate.
+func startupMigrations(database *sql.DB, driver string, cfg config.MigrationsConfig) error {
+	if cfg.Mode == "skip" {
+		slog.Info("Verificação das migrations desabilitada")
+		return nil
+	}
 
-	port := "0.0.0.0:8080"
-	log.Printf("Servidor iniciado na porta %s", port)
-	if err := router.Run(port); err != nil {
-		log.Fatalf("Erro ao iniciar o servidor: %v", err)
+	migrator, err := migrations.New(database, migrations.Options{AllowDrift: cfg.AllowDrift, Driver: driver})
+	if err != nil {
+		return err
 	}
+
+	if cfg.Mode == "verify" {
+		slog.Info("Verificando migrations")
+		return migrator.Verify(context.Background())
+	}
+
+	slog.Info("Executando migrations pendentes")
+	_, err = migrator.Up(context.Background())
+	return err
 }
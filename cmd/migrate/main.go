@@ -1,29 +1,368 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
 
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/config"
 	"github.com/Felipe8297/go-contacts-api/internal/pkg/db"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/logger"
 	"github.com/Felipe8297/go-contacts-api/internal/pkg/migrations"
 	"github.com/joho/godotenv"
 )
 
+// Códigos de saída do comando
+const (
+	exitOK      = 0
+	exitError   = 1
+	exitUsage   = 2
+	exitPending = 3 // status: há migrations pendentes
+)
+
+const usage = `Uso: migrate [-dir DIR] [-allow-drift] <comando> [argumentos]
+
+Comandos:
+  up            aplica todas as migrations pendentes
+  down [N]      reverte as últimas N migrations aplicadas (padrão: 1)
+  goto V        aplica ou reverte migrations até a versão V (0 reverte todas)
+  status        lista as migrations e se foram aplicadas
+  create NOME   cria os arquivos NNN-nome.up.sql e NNN-nome.down.sql em -dir
+  force V       registra a versão V como atual sem executar scripts
+
+Opções:
+  -dir DIR      diretório das migrations usado por create (as do SQLite
+                ficam em internal/pkg/migrations/sqlite); a versão criada
+                segue também as migrations registradas em Go
+  -allow-drift  não falha quando uma migration aplicada foi alterada no código
+
+Códigos de saída:
+  0  sucesso
+  1  erro ao executar o comando
+  2  uso incorreto
+  3  status encontrou migrations pendentes
+`
+
+// errUsage indica argumentos inválidos
+var errUsage = errors.New("uso incorreto")
+
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
 func main() {
-	if err := godotenv.Load(); err != nil {
-		log.Println("Arquivo .env não encontrado, usando variáveis de ambiente do sistema")
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dir := flags.String("dir", "internal/pkg/migrations", "diretório onde o comando create grava os arquivos")
+	allowDrift := flags.Bool("allow-drift", false, "opera mesmo com migrations aplicadas alteradas no código")
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	command, commandArgs := flags.Arg(0), flags.Args()[1:]
+
+	switch command {
+	case "create":
+		return exitCode(create(*dir, commandArgs))
+	case "up", "down", "goto", "status", "force":
+	default:
+		fmt.Fprintf(os.Stderr, "Comando desconhecido: %s\n\n%s", command, usage)
+		return exitUsage
+	}
+
+	envErr := godotenv.Load()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erro ao carregar configuração: %v\n", err)
+		return exitError
+	}
+
+	// A saída padrão fica reservada para o resultado do comando
+	slog.SetDefault(logger.New(cfg.Log.Level, cfg.Log.Format, os.Stderr))
+
+	if envErr != nil {
+		slog.Debug("Arquivo .env não encontrado, usando variáveis de ambiente do sistema")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	database, err := db.Connect(ctx, cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erro ao conectar ao banco de dados: %v\n", err)
+		return exitError
+	}
+	defer database.Close()
+
+	migrator, err := migrations.New(database, migrations.Options{
+		AllowDrift: *allowDrift || cfg.Migrations.AllowDrift,
+		Driver:     cfg.Database.Driver,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erro ao carregar migrations: %v\n", err)
+		return exitError
+	}
+
+	switch command {
+	case "up":
+		return exitCode(up(ctx, migrator, commandArgs))
+	case "down":
+		return exitCode(down(ctx, migrator, commandArgs))
+	case "goto":
+		return exitCode(gotoVersion(ctx, migrator, commandArgs))
+	case "status":
+		return status(ctx, migrator, commandArgs)
+	default: // force
+		return exitCode(force(ctx, migrator, commandArgs))
+	}
+}
+
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "%v\n\n%s", err, usage)
+		return exitUsage
+	default:
+		fmt.Fprintf(os.Stderr, "Erro: %v\n", err)
+		return exitError
+	}
+}
+
+func up(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: up não aceita argumentos", errUsage)
+	}
+
+	done, err := migrator.Up(ctx)
+	printDone("Aplicada", done)
+	if err != nil {
+		return err
+	}
+
+	if len(done) == 0 {
+		fmt.Println("Nenhuma migration pendente")
+	}
+	return nil
+}
+
+func down(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+	n := 1
+	switch len(args) {
+	case 0:
+	case 1:
+		parsed, err := strconv.Atoi(args[0])
+		if err != nil || parsed <= 0 {
+			return fmt.Errorf("%w: N deve ser um número inteiro positivo", errUsage)
+		}
+		n = parsed
+	default:
+		return fmt.Errorf("%w: down aceita apenas N", errUsage)
+	}
+
+	done, err := migrator.Down(ctx, n)
+	printDone("Revertida", done)
+	if err != nil {
+		return err
+	}
+
+	if len(done) == 0 {
+		fmt.Println("Nenhuma migration aplicada")
+	}
+	return nil
+}
+
+func gotoVersion(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+	version, err := versionArg("goto", args)
+	if err != nil {
+		return err
 	}
 
-	db, err := db.InitDB()
+	done, err := migrator.Goto(ctx, version)
+	for _, migration := range done {
+		// Goto reverte as versões posteriores antes de aplicar as anteriores
+		if migration.Version > version {
+			fmt.Printf("Revertida %s\n", migration)
+		} else {
+			fmt.Printf("Aplicada %s\n", migration)
+		}
+	}
 	if err != nil {
-		log.Fatalf("Erro ao conectar ao banco de dados: %v", err)
+		return err
+	}
+
+	if len(done) == 0 {
+		fmt.Printf("Banco já está na versão %d\n", version)
+	}
+	return nil
+}
+
+func force(ctx context.Context, migrator *migrations.Migrator, args []string) error {
+	version, err := versionArg("force", args)
+	if err != nil {
+		return err
+	}
+
+	if err := migrator.Force(ctx, version); err != nil {
+		return err
+	}
+
+	fmt.Printf("Versão %d registrada como atual\n", version)
+	return nil
+}
+
+func status(ctx context.Context, migrator *migrations.Migrator, args []string) int {
+	if len(args) != 0 {
+		return exitCode(fmt.Errorf("%w: status não aceita argumentos", errUsage))
 	}
-	defer db.Close()
 
-	log.Println("Iniciando a execução das migrations...")
-	err = migrations.RunMigrations(db)
+	statuses, err := migrator.Status(ctx)
 	if err != nil {
-		log.Fatalf("Erro ao executar migrations: %v", err)
+		return exitCode(err)
 	}
 
-	log.Println("Migrações concluídas com sucesso!")
+	pending := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSÃO\tNOME\tESTADO\tAPLICADA EM\tAPLICADA POR\tDURAÇÃO")
+	for _, s := range statuses {
+		state, appliedAt, appliedBy, duration := "pendente", "-", "-", "-"
+		switch {
+		case s.Dirty:
+			state = "incompleta"
+		case s.Missing:
+			state = "ausente no código"
+		case s.Drift:
+			state = "alterada"
+		case s.Applied:
+			state = "aplicada"
+		}
+		if s.Applied {
+			appliedAt = s.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			if s.AppliedBy != "" {
+				appliedBy = s.AppliedBy
+			}
+			if s.Duration > 0 {
+				duration = s.Duration.String()
+			}
+		} else {
+			pending++
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\t%s\t%s\n", s.Migration.Version, s.Migration.Name, state, appliedAt, appliedBy, duration)
+	}
+	w.Flush()
+
+	if pending > 0 {
+		fmt.Printf("\n%d migration(s) pendente(s)\n", pending)
+		return exitPending
+	}
+	return exitOK
+}
+
+// create grava os arquivos de uma nova migration com a próxima versão livre
+func create(dir string, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: create exige o nome da migration", errUsage)
+	}
+
+	name := strings.ToLower(strings.NewReplacer(" ", "_", "-", "_").Replace(strings.TrimSpace(args[0])))
+	if !migrationNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: o nome deve conter apenas letras, números e _", errUsage)
+	}
+
+	last, err := lastVersion(dir)
+	if err != nil {
+		return err
+	}
+
+	base := fmt.Sprintf("%03d-%s", last+1, name)
+	for _, direction := range []string{migrations.DirectionUp, migrations.DirectionDown} {
+		path := filepath.Join(dir, base+"."+direction+".sql")
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return fmt.Errorf("erro ao criar %s: %v", path, err)
+		}
+		// Arquivos vazios são rejeitados ao carregar as migrations
+		fmt.Fprintf(file, "-- %s: %s\n", base, direction)
+		file.Close()
+		fmt.Printf("Criado %s\n", path)
+	}
+
+	fmt.Println("As migrations são embutidas no binário; recompile para incluí-las")
+	return nil
+}
+
+// lastVersion retorna a maior versão entre os arquivos de dir e as migrations
+// embutidas do mesmo driver, que incluem as escritas em Go e registradas com
+// migrations.Register, sem arquivo em dir
+func lastVersion(dir string) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao listar %s: %v", dir, err)
+	}
+
+	var last int64
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "-")
+		if !ok || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		if version, err := strconv.ParseInt(prefix, 10, 64); err == nil && version > last {
+			last = version
+		}
+	}
+
+	driver := migrations.DriverPostgres
+	if filepath.Base(filepath.Clean(dir)) == migrations.DriverSQLite {
+		driver = migrations.DriverSQLite
+	}
+	embedded, err := migrations.Embedded(driver)
+	if err != nil {
+		return 0, err
+	}
+	for _, migration := range embedded {
+		last = max(last, migration.Version)
+	}
+
+	return last, nil
+}
+
+func versionArg(command string, args []string) (int64, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("%w: %s exige a versão", errUsage, command)
+	}
+
+	version, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("%w: a versão deve ser um número inteiro não negativo", errUsage)
+	}
+
+	return version, nil
+}
+
+func printDone(verb string, done []migrations.Migration) {
+	for _, migration := range done {
+		fmt.Printf("%s %s\n", verb, migration)
+	}
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/migrations"
+)
+
+func TestCreateVersion(t *testing.T) {
+	noop := func(context.Context, migrations.Executor) error { return nil }
+	// Migration em Go sem arquivo no diretório, à frente das embutidas
+	migrations.Register(migrations.GoMigration{Version: 900, Name: "go_only", Up: noop, Down: noop})
+
+	tests := []struct {
+		name   string
+		subdir string
+		files  []string
+		want   string
+	}{
+		{name: "migration registrada em Go", want: "901-add_notes"},
+		{name: "arquivo à frente das embutidas", files: []string{"950-future.up.sql"}, want: "951-add_notes"},
+		{name: "SQLite ignora as migrations em Go", subdir: migrations.DriverSQLite, want: "007-add_notes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), tt.subdir)
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			for _, file := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, file), []byte("SELECT 1;"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := create(dir, []string{"add notes"}); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			for _, direction := range []string{migrations.DirectionUp, migrations.DirectionDown} {
+				if _, err := os.Stat(filepath.Join(dir, tt.want+"."+direction+".sql")); err != nil {
+					t.Fatalf("arquivo %s não criado: %v", tt.want, err)
+				}
+			}
+		})
+	}
+}
@@ -28,6 +28,14 @@ const docTemplate = `{
                     "contacts"
                 ],
                 "summary": "Listar todos os contatos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant do contato (padrão: default)",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -38,6 +46,555 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "429": {
+                        "description": "Limite de requisições excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da operação excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cria um novo contato com as informações fornecidas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "Criar um novo contato",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant do contato (padrão: default)",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave para repetir a requisição com segurança",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dados do contato",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contacts.CreateContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.Contact"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email já cadastrado no tenant ou requisição idempotente em andamento",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key reutilizada com outro payload",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da operação excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/contacts/events": {
+            "get": {
+                "description": "Server-Sent Events com os eventos contact.created, contact.updated e contact.deleted do tenant. O campo id de cada evento pode ser enviado em Last-Event-ID para retomar o stream sem perdas enquanto o evento estiver retido; a retomada pode repetir eventos já recebidos, que o cliente descarta pelo id; fora da retenção, o stream começa com o evento reset e o cliente deve recarregar os contatos. Comentários de heartbeat são enviados periodicamente, e o cliente que não acompanha os eventos é desconectado e deve reconectar com Last-Event-ID.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "Stream de alterações de contatos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant dos contatos (padrão: default)",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do último evento recebido",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternativa ao cabeçalho Last-Event-ID na primeira conexão",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IDs de categoria, separados por vírgula; o evento passa se a categoria atual ou a anterior do contato estiver na lista",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream de eventos",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Filtro ou Last-Event-ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/contacts/sync": {
+            "get": {
+                "description": "Retorna os contatos criados ou atualizados e os removidos desde o token informado, em ordem de alteração, e o token da próxima sincronização. Sem token, retorna todos os contatos. Com has_more, repita a chamada com o novo token. Um token expirado retorna 410 e exige nova sincronização sem token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "Sincronizar contatos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant do contato (padrão: default)",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token retornado pela sincronização anterior",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Máximo de alterações por página (padrão: 500, máximo: 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.SyncResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Token ou limite inválido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Token expirado; sincronize novamente sem token",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da operação excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/contacts/{id}": {
+            "get": {
+                "description": "Retorna um contato específico com base no ID fornecido",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "Buscar contato por ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant do contato (padrão: default)",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do contato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.Contact"
+                        }
+                    },
+                    "404": {
+                        "description": "Contato não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da operação excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Atualiza os dados de um contato existente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "Atualizar contato",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant do contato (padrão: default)",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave para repetir a requisição com segurança",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do contato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados atualizados do contato",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contacts.UpdateContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.Contact"
+                        }
+                    },
+                    "400": {
+                        "description": "Erro de validação dos dados",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Contato não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email já cadastrado no tenant ou requisição idempotente em andamento",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key reutilizada com outro payload",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da operação excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove um contato existente da base de dados",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "Excluir contato",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant do contato (padrão: default)",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave para repetir a requisição com segurança",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do contato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Contato removido com sucesso"
+                    },
+                    "404": {
+                        "description": "Contato não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Requisição idempotente em andamento",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key reutilizada com outro payload",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Tempo limite da operação excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Indica que o processo está ativo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Verifica as dependências (banco de dados, migrations, workers) e retorna o estado de cada uma",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Alguma dependência não está pronta ou a aplicação está sendo desligada",
+                        "schema": {
+                            "$ref": "#/definitions/health.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Retorna as assinaturas do tenant, sem os segredos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Listar assinaturas de webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003cchave de API\u003e; o tenant da assinatura é o da chave",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhooks.Subscription"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Credenciais ausentes ou inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Erro interno do servidor",
                         "schema": {
@@ -47,7 +604,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Cria um novo contato com as informações fornecidas",
+                "description": "Registra uma URL para receber os eventos de contatos dos tipos informados. O segredo usado na assinatura HMAC-SHA256 das entregas é retornado apenas nesta resposta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -55,17 +612,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "webhooks"
                 ],
-                "summary": "Criar um novo contato",
+                "summary": "Criar assinatura de webhook",
                 "parameters": [
                     {
-                        "description": "Dados do contato",
+                        "type": "string",
+                        "description": "Bearer \u003cchave de API\u003e; o tenant da assinatura é o da chave",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados da assinatura",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/contacts.CreateContactRequest"
+                            "$ref": "#/definitions/webhooks.CreateSubscriptionRequest"
                         }
                     }
                 ],
@@ -73,11 +637,23 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/contacts.Contact"
+                            "$ref": "#/definitions/webhooks.Subscription"
                         }
                     },
                     "400": {
-                        "description": "Erro de validação dos dados",
+                        "description": "URL ou tipo de evento inválido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Credenciais ausentes ou inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
                         "schema": {
                             "$ref": "#/definitions/contacts.ErrorResponse"
                         }
@@ -91,23 +667,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/contacts/{id}": {
+        "/webhooks/{id}": {
             "get": {
-                "description": "Retorna um contato específico com base no ID fornecido",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retorna uma assinatura do tenant, sem o segredo",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "webhooks"
                 ],
-                "summary": "Buscar contato por ID",
+                "summary": "Buscar assinatura de webhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do contato",
+                        "description": "Bearer \u003cchave de API\u003e; o tenant da assinatura é o da chave",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da assinatura",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -117,62 +697,152 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/contacts.Contact"
+                            "$ref": "#/definitions/webhooks.Subscription"
+                        }
+                    },
+                    "401": {
+                        "description": "Credenciais ausentes ou inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Contato não encontrado",
+                        "description": "Assinatura não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
                         "schema": {
                             "$ref": "#/definitions/contacts.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Atualiza os dados de um contato existente",
-                "consumes": [
+            "delete": {
+                "description": "Remove a assinatura e o seu log de entregas",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Excluir assinatura de webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003cchave de API\u003e; o tenant da assinatura é o da chave",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da assinatura",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Assinatura removida com sucesso"
+                    },
+                    "401": {
+                        "description": "Credenciais ausentes ou inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Assinatura não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Retorna as 100 entregas mais recentes da assinatura, com o estado, as tentativas e o último erro",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "webhooks"
                 ],
-                "summary": "Atualizar contato",
+                "summary": "Log de entregas",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do contato",
+                        "description": "Bearer \u003cchave de API\u003e; o tenant da assinatura é o da chave",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da assinatura",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Dados atualizados do contato",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/contacts.UpdateContactRequest"
-                        }
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Filtra pelo estado",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/contacts.Contact"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhooks.Delivery"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Erro de validação dos dados",
+                        "description": "Estado inválido",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Credenciais ausentes ou inválidas",
                         "schema": {
                             "$ref": "#/definitions/contacts.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Contato não encontrado",
+                        "description": "Assinatura não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
                         "schema": {
                             "$ref": "#/definitions/contacts.ErrorResponse"
                         }
@@ -184,34 +854,62 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Remove um contato existente da base de dados",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/webhooks/{id}/deliveries/{deliveryId}/replay": {
+            "post": {
+                "description": "Volta a entrega para pendente, com as tentativas zeradas, para que seja reenviada assim que possível",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contacts"
+                    "webhooks"
                 ],
-                "summary": "Excluir contato",
+                "summary": "Reenviar entrega",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do contato",
+                        "description": "Bearer \u003cchave de API\u003e; o tenant da assinatura é o da chave",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da assinatura",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID da entrega",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Contato removido com sucesso"
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.Delivery"
+                        }
+                    },
+                    "401": {
+                        "description": "Credenciais ausentes ou inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
                     },
                     "404": {
-                        "description": "Contato não encontrado",
+                        "description": "Assinatura ou entrega não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/contacts.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Limite de requisições excedido",
                         "schema": {
                             "$ref": "#/definitions/contacts.ErrorResponse"
                         }
@@ -261,6 +959,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "11999998888"
                 },
+                "tenant_id": {
+                    "description": "Tenant dono do contato",
+                    "type": "string",
+                    "example": "acme"
+                },
                 "updated_at": {
                     "description": "Data de atualização",
                     "type": "string",
@@ -298,6 +1001,22 @@ const docTemplate = `{
                 }
             }
         },
+        "contacts.DeletedContact": {
+            "description": "Contato removido desde a última sincronização",
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "Data da remoção",
+                    "type": "string",
+                    "example": "2023-01-01T12:00:00Z"
+                },
+                "id": {
+                    "description": "ID do contato removido",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
         "contacts.ErrorResponse": {
             "description": "Estrutura padrão para respostas de erro",
             "type": "object",
@@ -306,6 +1025,41 @@ const docTemplate = `{
                     "description": "Mensagem de erro",
                     "type": "string",
                     "example": "Mensagem de erro"
+                },
+                "request_id": {
+                    "description": "ID da requisição (X-Request-ID)",
+                    "type": "string",
+                    "example": "4bf92f3577b34da6a3ce929d0e0e4736"
+                }
+            }
+        },
+        "contacts.SyncResult": {
+            "description": "Alterações dos contatos desde o token informado",
+            "type": "object",
+            "properties": {
+                "contacts": {
+                    "description": "Contatos criados ou atualizados, no estado atual",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contacts.Contact"
+                    }
+                },
+                "deleted": {
+                    "description": "Contatos removidos",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contacts.DeletedContact"
+                    }
+                },
+                "has_more": {
+                    "description": "Há mais alterações; repita a chamada com o novo token",
+                    "type": "boolean",
+                    "example": false
+                },
+                "token": {
+                    "description": "Token para a próxima sincronização",
+                    "type": "string",
+                    "example": "ZGVmYXVsdDo0Mg"
                 }
             }
         },
@@ -334,6 +1088,177 @@ const docTemplate = `{
                     "example": "11999997777"
                 }
             }
+        },
+        "health.CheckResult": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "error": {
+                    "type": "string",
+                    "example": "context deadline exceeded"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "health.Response": {
+            "description": "Estado da aplicação e de cada dependência",
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/health.CheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "webhooks.CreateSubscriptionRequest": {
+            "description": "Dados para criação de uma assinatura de webhook",
+            "type": "object",
+            "required": [
+                "event_types",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "description": "contact.created, contact.updated e/ou contact.deleted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "contact.created",
+                        "contact.updated"
+                    ]
+                },
+                "url": {
+                    "description": "URL que recebe os eventos",
+                    "type": "string",
+                    "example": "https://crm.example.com/hooks/contacts"
+                }
+            }
+        },
+        "webhooks.Delivery": {
+            "description": "Entrega de um evento a uma assinatura",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Tentativas que falharam",
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string",
+                    "example": "2023-01-01T12:00:00Z"
+                },
+                "delivered_at": {
+                    "description": "Data da entrega",
+                    "type": "string",
+                    "example": "2023-01-01T12:00:01Z"
+                },
+                "event_id": {
+                    "description": "ID do evento",
+                    "type": "string",
+                    "example": "979dd9c2-d8a1-4009-a794-72dfdc476a23"
+                },
+                "event_type": {
+                    "description": "Tipo do evento",
+                    "type": "string",
+                    "example": "contact.created"
+                },
+                "id": {
+                    "description": "ID da entrega, enviado em X-Webhook-ID",
+                    "type": "string",
+                    "example": "0b6e7f7a-3c2d-4e1f-8a9b-5c6d7e8f9a0b"
+                },
+                "last_error": {
+                    "description": "Erro da última tentativa",
+                    "type": "string",
+                    "example": "status 503"
+                },
+                "last_status_code": {
+                    "description": "Status HTTP da última resposta",
+                    "type": "integer",
+                    "example": 503
+                },
+                "next_attempt_at": {
+                    "description": "Próxima tentativa, se pendente",
+                    "type": "string",
+                    "example": "2023-01-01T12:00:30Z"
+                },
+                "payload": {
+                    "description": "Corpo enviado",
+                    "type": "object"
+                },
+                "status": {
+                    "description": "pending, delivered ou dead",
+                    "type": "string",
+                    "example": "pending"
+                },
+                "subscription_id": {
+                    "description": "ID da assinatura",
+                    "type": "string",
+                    "example": "7d9f2c1e-5b7a-4c1e-9f3a-2b8d6e4c1a90"
+                },
+                "tenant_id": {
+                    "description": "Tenant dono da assinatura",
+                    "type": "string",
+                    "example": "acme"
+                }
+            }
+        },
+        "webhooks.Subscription": {
+            "description": "Assinatura de webhook",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string",
+                    "example": "2023-01-01T12:00:00Z"
+                },
+                "event_types": {
+                    "description": "Tipos de evento entregues",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "contact.created",
+                        "contact.updated"
+                    ]
+                },
+                "id": {
+                    "description": "ID da assinatura",
+                    "type": "string",
+                    "example": "7d9f2c1e-5b7a-4c1e-9f3a-2b8d6e4c1a90"
+                },
+                "secret": {
+                    "description": "Segredo da assinatura HMAC, exibido apenas na criação",
+                    "type": "string",
+                    "example": "whsec_3f1c9a0b7e2d4c6f8a1b3d5e7f9a2c4e"
+                },
+                "tenant_id": {
+                    "description": "Tenant dono da assinatura",
+                    "type": "string",
+                    "example": "acme"
+                },
+                "url": {
+                    "description": "URL que recebe os eventos",
+                    "type": "string",
+                    "example": "https://crm.example.com/hooks/contacts"
+                }
+            }
         }
     }
 }`
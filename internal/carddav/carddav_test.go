@@ -0,0 +1,338 @@
+package carddav_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Felipe8297/go-contacts-api/internal/carddav"
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/contacts/contactstest"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/dbtest"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+const addressBook = "/carddav/addressbooks/contacts/"
+
+func TestDiscovery(t *testing.T) {
+	env := newTestEnv(t)
+
+	rec := env.do(t, http.MethodOptions, "/carddav/", "", nil)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get("DAV"), "addressbook") {
+		t.Fatalf("OPTIONS = %d, DAV %q", rec.Code, rec.Header().Get("DAV"))
+	}
+
+	rec = env.do(t, "PROPFIND", "/.well-known/carddav", "", nil)
+	if rec.Code != http.StatusMovedPermanently || rec.Header().Get("Location") != "/carddav/" {
+		t.Fatalf("well-known = %d, Location %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	principal := env.propfind(t, "/carddav/principal/", "0", `<d:propfind xmlns:d="DAV:" xmlns:card="urn:ietf:params:xml:ns:carddav"><d:prop><card:addressbook-home-set/><d:owner/></d:prop></d:propfind>`)
+	if len(principal.Responses) != 1 || !strings.Contains(principal.Responses[0].found(), "/carddav/addressbooks/") {
+		t.Fatalf("principal = %+v", principal)
+	}
+	if len(principal.Responses[0].Propstats) != 2 || !strings.Contains(principal.Responses[0].Propstats[1].Status, "404") {
+		t.Fatalf("propriedade ausente não retornou 404: %+v", principal.Responses[0].Propstats)
+	}
+
+	home := env.propfind(t, "/carddav/addressbooks/", "1", "")
+	if len(home.Responses) != 2 || home.Responses[1].Href != addressBook || !strings.Contains(home.Responses[1].found(), "addressbook") {
+		t.Fatalf("home = %+v", home)
+	}
+}
+
+func TestObjects(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	// Nome com mais de 75 octetos para exercitar a dobra de linhas
+	longName := "Ana Maria " + strings.Repeat("Souza ", 12) + "Ção"
+	vcard := "BEGIN:VCARD\r\nVERSION:3.0\r\nUID:cliente-1\r\nN:Souza;Ana;;;\r\nFN:" + longName + "\r\nitem1.EMAIL;TYPE=INTERNET:ana@example.com\r\nTEL;TYPE=CELL:11999998888\r\nEND:VCARD\r\n"
+
+	rec := env.put(t, "abc.vcf", vcard, "If-None-Match", "*")
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("PUT = %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := env.put(t, "abc.vcf", vcard, "If-None-Match", "*"); rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("PUT repetido = %d, esperado 412", rec.Code)
+	}
+
+	rec = env.do(t, http.MethodGet, addressBook+"abc.vcf", "", nil)
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "UID:cliente-1") || !strings.Contains(body, "EMAIL;TYPE=INTERNET:ana@example.com") {
+		t.Fatalf("GET = %d %s", rec.Code, body)
+	}
+	for _, line := range strings.Split(body, "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("linha sem dobra: %q", line)
+		}
+	}
+	tag := rec.Header().Get("ETag")
+
+	all, err := env.contacts.GetAllContacts(ctx, tenant.DefaultID)
+	if err != nil || len(all) != 1 || all[0].Name != longName || all[0].Phone != "11999998888" {
+		t.Fatalf("contatos = %+v, %v", all, err)
+	}
+
+	if rec := env.do(t, http.MethodGet, addressBook+"abc.vcf", "", nil, "If-None-Match", tag); rec.Code != http.StatusNotModified {
+		t.Fatalf("GET condicional = %d, esperado 304", rec.Code)
+	}
+
+	updated := strings.Replace(vcard, "FN:"+longName, "FN:Ana Souza", 1)
+	if rec := env.put(t, "abc.vcf", updated, "If-Match", `"outra"`); rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("PUT com ETag antiga = %d, esperado 412", rec.Code)
+	}
+	if rec := env.put(t, "abc.vcf", updated, "If-Match", tag); rec.Code != http.StatusNoContent {
+		t.Fatalf("PUT = %d %s", rec.Code, rec.Body.String())
+	}
+	if contact, _ := env.contacts.GetContactByID(ctx, tenant.DefaultID, all[0].ID); contact.Name != "Ana Souza" {
+		t.Fatalf("nome = %q", contact.Name)
+	}
+
+	// O contato criado pela API REST é exposto como <id>.vcf
+	bia, err := env.contacts.CreateNewContact(ctx, tenant.DefaultID, "Bia", "bia@example.com", "", "")
+	if err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+	if rec := env.do(t, http.MethodGet, addressBook+bia.ID+".vcf", "", nil); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "UID:"+bia.ID) {
+		t.Fatalf("GET = %d %s", rec.Code, rec.Body.String())
+	}
+
+	// As validações da API REST se aplicam
+	if rec := env.put(t, "novo.vcf", strings.Replace(vcard, "ana@example.com", "nao-e-email", 1)); rec.Code != http.StatusBadRequest {
+		t.Fatalf("PUT com email inválido = %d, esperado 400", rec.Code)
+	}
+	if rec := env.put(t, "novo.vcf", strings.Replace(vcard, "UID:cliente-1", "UID:cliente-2", 1)); rec.Code != http.StatusConflict {
+		t.Fatalf("PUT com email repetido = %d, esperado 409", rec.Code)
+	}
+	if rec := env.put(t, "novo.vcf", "BEGIN:VCARD\r\nFN:Sem versão\r\nEND:VCARD\r\n"); rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "valid-address-data") {
+		t.Fatalf("PUT com vCard inválido = %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := env.do(t, http.MethodDelete, addressBook+"abc.vcf", "", nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE = %d", rec.Code)
+	}
+	if rec := env.do(t, http.MethodGet, addressBook+"abc.vcf", "", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("GET após DELETE = %d, esperado 404", rec.Code)
+	}
+
+	// O nome liberado pode ser usado por um novo contato
+	if rec := env.put(t, "abc.vcf", vcard, "If-None-Match", "*"); rec.Code != http.StatusCreated {
+		t.Fatalf("PUT após DELETE = %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestReports(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	ana, _ := env.contacts.CreateNewContact(ctx, tenant.DefaultID, "Ana", "ana@example.com", "", "")
+	bia, _ := env.contacts.CreateNewContact(ctx, tenant.DefaultID, "Bia", "bia@example.com", "", "")
+
+	listing := env.propfind(t, addressBook, "1", "")
+	if len(listing.Responses) != 3 {
+		t.Fatalf("PROPFIND com Depth 1 = %d respostas, esperado 3", len(listing.Responses))
+	}
+
+	multiget := env.report(t, `<card:addressbook-multiget xmlns:d="DAV:" xmlns:card="urn:ietf:params:xml:ns:carddav">
+		<d:prop><d:getetag/><card:address-data/></d:prop>
+		<d:href>`+addressBook+ana.ID+`.vcf</d:href>
+		<d:href>`+addressBook+`inexistente.vcf</d:href>
+	</card:addressbook-multiget>`)
+	if len(multiget.Responses) != 2 || !strings.Contains(multiget.Responses[0].found(), "FN:Ana") || !strings.Contains(multiget.Responses[1].Status, "404") {
+		t.Fatalf("multiget = %+v", multiget)
+	}
+
+	query := env.report(t, `<card:addressbook-query xmlns:d="DAV:" xmlns:card="urn:ietf:params:xml:ns:carddav">
+		<d:prop><d:getetag/></d:prop>
+		<card:filter><card:prop-filter name="EMAIL"><card:text-match match-type="starts-with">BIA@</card:text-match></card:prop-filter></card:filter>
+	</card:addressbook-query>`)
+	if len(query.Responses) != 1 || query.Responses[0].Href != addressBook+bia.ID+".vcf" {
+		t.Fatalf("query = %+v", query)
+	}
+
+	rec := env.do(t, "REPORT", addressBook, `<card:addressbook-query xmlns:card="urn:ietf:params:xml:ns:carddav"><card:filter><card:prop-filter name="FN"><card:text-match collation="i;desconhecida">a</card:text-match></card:prop-filter></card:filter></card:addressbook-query>`, nil)
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "supported-collation") {
+		t.Fatalf("query com collation desconhecida = %d %s", rec.Code, rec.Body.String())
+	}
+
+	initial := env.sync(t, "")
+	if len(initial.Responses) != 2 || initial.SyncToken == "" {
+		t.Fatalf("sync-collection inicial = %+v", initial)
+	}
+
+	if _, err := env.contacts.UpdateContact(ctx, tenant.DefaultID, ana.ID, "Ana Souza", ana.Email, "", ""); err != nil {
+		t.Fatalf("UpdateContact: %v", err)
+	}
+	if err := env.contacts.DeleteContact(ctx, tenant.DefaultID, bia.ID); err != nil {
+		t.Fatalf("DeleteContact: %v", err)
+	}
+
+	delta := env.sync(t, initial.SyncToken)
+	if len(delta.Responses) != 2 || !strings.Contains(delta.Responses[0].found(), "Ana Souza") ||
+		delta.Responses[1].Href != addressBook+bia.ID+".vcf" || !strings.Contains(delta.Responses[1].Status, "404") {
+		t.Fatalf("sync-collection = %+v", delta)
+	}
+
+	rec = env.do(t, "REPORT", addressBook, syncBody("urn:outro:token"), nil)
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "valid-sync-token") {
+		t.Fatalf("sync-collection com token inválido = %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSyncCollectionFromLegacyRows(t *testing.T) {
+	db, legacy := contactstest.OpenSQLiteWithLegacyContacts(t, tenant.DefaultID, 5)
+	env := newTestEnvWithDB(t, db)
+
+	// Com páginas menores que o número de contatos anteriores à sequência de
+	// alterações, o cliente repete o relatório até receber todos
+	var hrefs []string
+	token := ""
+	for page := 0; ; page++ {
+		if page > len(legacy) {
+			t.Fatal("sincronização não terminou")
+		}
+
+		m := env.report(t, `<d:sync-collection xmlns:d="DAV:">
+			<d:sync-token>`+token+`</d:sync-token><d:sync-level>1</d:sync-level>
+			<d:limit><d:nresults>2</d:nresults></d:limit>
+			<d:prop><d:getetag/></d:prop>
+		</d:sync-collection>`)
+		token = m.SyncToken
+
+		truncated := false
+		for _, r := range m.Responses {
+			if r.Href == addressBook && strings.Contains(r.Status, "507") {
+				truncated = true
+				continue
+			}
+			hrefs = append(hrefs, r.Href)
+		}
+		if !truncated {
+			break
+		}
+	}
+
+	if len(hrefs) != len(legacy) {
+		t.Fatalf("sincronizados %d contatos, esperado %d", len(hrefs), len(legacy))
+	}
+	for i, contact := range legacy {
+		if hrefs[i] != addressBook+contact.ID+".vcf" {
+			t.Fatalf("contato %d = %s, esperado %s", i, hrefs[i], contact.ID)
+		}
+	}
+}
+
+type testEnv struct {
+	contacts contacts.Service
+	router   *gin.Engine
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	return newTestEnvWithDB(t, dbtest.OpenSQLite(t))
+}
+
+func newTestEnvWithDB(t *testing.T, db *sql.DB) *testEnv {
+	t.Helper()
+
+	service := contacts.NewService(contacts.NewSQLiteRepository(db))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	carddav.RegisterWellKnown(router)
+	carddav.NewHandler(service, carddav.NewSQLStore(db)).RegisterRoutes(router.Group("", tenant.Middleware(tenant.DefaultID)))
+
+	return &testEnv{contacts: service, router: router}
+}
+
+// do executa a requisição; headers são pares nome, valor
+func (e *testEnv) do(t *testing.T, method, target, body string, contentType *string, headers ...string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != nil {
+		req.Header.Set("Content-Type", *contentType)
+	} else if body != "" {
+		req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	rec := httptest.NewRecorder()
+	e.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func (e *testEnv) put(t *testing.T, name, vcard string, headers ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	contentType := "text/vcard; charset=utf-8"
+	return e.do(t, http.MethodPut, addressBook+name, vcard, &contentType, headers...)
+}
+
+func (e *testEnv) propfind(t *testing.T, target, depth, body string) *multistatus {
+	t.Helper()
+	return decodeMultistatus(t, e.do(t, "PROPFIND", target, body, nil, "Depth", depth))
+}
+
+func (e *testEnv) report(t *testing.T, body string) *multistatus {
+	t.Helper()
+	return decodeMultistatus(t, e.do(t, "REPORT", addressBook, body, nil, "Depth", "1"))
+}
+
+func (e *testEnv) sync(t *testing.T, token string) *multistatus {
+	t.Helper()
+	return e.report(t, syncBody(token))
+}
+
+func syncBody(token string) string {
+	return `<d:sync-collection xmlns:d="DAV:" xmlns:card="urn:ietf:params:xml:ns:carddav">
+		<d:sync-token>` + token + `</d:sync-token><d:sync-level>1</d:sync-level>
+		<d:prop><d:getetag/><card:address-data/></d:prop>
+	</d:sync-collection>`
+}
+
+type multistatus struct {
+	Responses []response `xml:"DAV: response"`
+	SyncToken string     `xml:"DAV: sync-token"`
+}
+
+type response struct {
+	Href      string `xml:"DAV: href"`
+	Status    string `xml:"DAV: status"`
+	Propstats []struct {
+		Status string `xml:"DAV: status"`
+		Prop   struct {
+			Inner string `xml:",innerxml"`
+		} `xml:"DAV: prop"`
+	} `xml:"DAV: propstat"`
+}
+
+// found retorna o XML das propriedades encontradas (status 200)
+func (r response) found() string {
+	for _, propstat := range r.Propstats {
+		if strings.Contains(propstat.Status, "200") {
+			return propstat.Prop.Inner
+		}
+	}
+	return ""
+}
+
+func decodeMultistatus(t *testing.T, rec *httptest.ResponseRecorder) *multistatus {
+	t.Helper()
+
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("status = %d, esperado 207 (corpo: %s)", rec.Code, rec.Body.String())
+	}
+	m := &multistatus{}
+	if err := xml.Unmarshal(rec.Body.Bytes(), m); err != nil {
+		t.Fatalf("multistatus inválido %q: %v", rec.Body.String(), err)
+	}
+	return m
+}
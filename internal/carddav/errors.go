@@ -0,0 +1,12 @@
+package carddav
+
+import "errors"
+
+var (
+	// ErrNotFound indica que não há objeto registrado com o nome informado
+	ErrNotFound = errors.New("objeto não encontrado")
+
+	// ErrNameTaken indica que o nome do recurso passou a apontar para outro
+	// contato durante a gravação
+	ErrNameTaken = errors.New("nome de recurso já utilizado")
+)
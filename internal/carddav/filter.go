@@ -0,0 +1,130 @@
+package carddav
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// filterRequest é o filtro do relatório addressbook-query (RFC 6352, seção 10.5)
+type filterRequest struct {
+	Test        string       `xml:"test,attr"`
+	PropFilters []propFilter `xml:"urn:ietf:params:xml:ns:carddav prop-filter"`
+}
+
+type propFilter struct {
+	Name         string       `xml:"name,attr"`
+	Test         string       `xml:"test,attr"`
+	IsNotDefined *struct{}    `xml:"urn:ietf:params:xml:ns:carddav is-not-defined"`
+	TextMatches  []textMatch  `xml:"urn:ietf:params:xml:ns:carddav text-match"`
+	ParamFilters []anyElement `xml:"urn:ietf:params:xml:ns:carddav param-filter"`
+}
+
+type textMatch struct {
+	Collation string `xml:"collation,attr"`
+	MatchType string `xml:"match-type,attr"`
+	Negate    string `xml:"negate-condition,attr"`
+	Text      string `xml:",chardata"`
+}
+
+// validate retorna a pré-condição violada por um filtro que o servidor não
+// sabe avaliar, ou um nome vazio se o filtro é aceito
+func (f *filterRequest) validate() xml.Name {
+	for _, pf := range f.PropFilters {
+		// Os parâmetros (TYPE, PREF) não são guardados
+		if len(pf.ParamFilters) > 0 {
+			return xml.Name{Space: nsCardDAV, Local: "supported-filter"}
+		}
+		for _, tm := range pf.TextMatches {
+			switch tm.Collation {
+			case "", "i;unicode-casemap", "i;ascii-casemap", "i;octet":
+			default:
+				return xml.Name{Space: nsCardDAV, Local: "supported-collation"}
+			}
+			switch tm.MatchType {
+			case "", "equals", "contains", "starts-with", "ends-with":
+			default:
+				return xml.Name{Space: nsCardDAV, Local: "supported-filter"}
+			}
+		}
+	}
+	return xml.Name{}
+}
+
+// matches avalia o filtro sobre os valores de cada propriedade do vCard
+func (f *filterRequest) matches(values func(name string) []string) bool {
+	if f == nil || len(f.PropFilters) == 0 {
+		return true
+	}
+	return evaluate(f.Test, len(f.PropFilters), func(i int) bool {
+		return f.PropFilters[i].matches(values(strings.ToUpper(f.PropFilters[i].Name)))
+	})
+}
+
+func (pf propFilter) matches(values []string) bool {
+	if pf.IsNotDefined != nil {
+		return len(values) == 0
+	}
+	if len(pf.TextMatches) == 0 {
+		return len(values) > 0
+	}
+	return evaluate(pf.Test, len(pf.TextMatches), func(i int) bool {
+		return pf.TextMatches[i].matches(values)
+	})
+}
+
+// matches indica se algum valor da propriedade satisfaz o critério
+func (tm textMatch) matches(values []string) bool {
+	needle := fold(tm.Collation, tm.Text)
+
+	matched := false
+	for _, value := range values {
+		value = fold(tm.Collation, value)
+
+		switch tm.MatchType {
+		case "equals":
+			matched = value == needle
+		case "starts-with":
+			matched = strings.HasPrefix(value, needle)
+		case "ends-with":
+			matched = strings.HasSuffix(value, needle)
+		default:
+			matched = strings.Contains(value, needle)
+		}
+		if matched {
+			break
+		}
+	}
+
+	if tm.Negate == "yes" {
+		return !matched
+	}
+	return matched
+}
+
+// evaluate combina n testes com anyof (o padrão) ou allof
+func evaluate(test string, n int, match func(i int) bool) bool {
+	all := test == "allof"
+	for i := range n {
+		if match(i) != all {
+			return !all
+		}
+	}
+	return all
+}
+
+// fold normaliza o texto conforme a collation; i;octet compara os bytes
+func fold(collation, value string) string {
+	switch collation {
+	case "i;octet":
+		return value
+	case "i;ascii-casemap":
+		return strings.Map(func(r rune) rune {
+			if 'A' <= r && r <= 'Z' {
+				return r + 'a' - 'A'
+			}
+			return r
+		}, value)
+	default:
+		return strings.ToLower(value)
+	}
+}
@@ -0,0 +1,688 @@
+// Package carddav expõe os contatos de cada tenant como um catálogo de
+// endereços CardDAV (RFC 6352), para sincronização direta por celulares e
+// clientes como o Thunderbird. As alterações passam por contacts.Service, com
+// as mesmas validações e eventos da API REST.
+package carddav
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/apierror"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+const (
+	basePath        = "/carddav/"
+	principalPath   = basePath + "principal/"
+	homePath        = basePath + "addressbooks/"
+	addressBookPath = homePath + "contacts/"
+
+	// syncTokenPrefix torna o token de sincronização de contatos uma URI,
+	// como exige o relatório sync-collection (RFC 6578)
+	syncTokenPrefix = "urn:go-contacts-api:sync:"
+
+	// maxResourceSize limita o corpo das requisições e o tamanho de um vCard
+	maxResourceSize = 1 << 20
+)
+
+// methods são os métodos aceitos em qualquer recurso do CardDAV
+var methods = []string{http.MethodOptions, "PROPFIND", "REPORT", http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete}
+
+type Handler struct {
+	service contacts.Service
+	store   Store
+}
+
+func NewHandler(service contacts.Service, store Store) *Handler {
+	return &Handler{service: service, store: store}
+}
+
+// RegisterRoutes registra o CardDAV em /carddav/. O tenant é resolvido pelo
+// middleware do grupo, como nas demais rotas.
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	for _, method := range methods {
+		router.Handle(method, basePath+"*path", h.serve)
+	}
+}
+
+// RegisterWellKnown registra a descoberta do serviço (RFC 6764) usada pelos
+// clientes a partir apenas do host
+func RegisterWellKnown(router gin.IRouter) {
+	redirect := func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, basePath)
+	}
+	router.GET("/.well-known/carddav", redirect)
+	router.Handle("PROPFIND", "/.well-known/carddav", redirect)
+}
+
+// resourceKind identifica o recurso apontado pela URL
+type resourceKind int
+
+const (
+	kindRoot resourceKind = iota
+	kindPrincipal
+	kindHome
+	kindAddressBook
+	kindObject
+)
+
+func parsePath(path string) (kind resourceKind, name string, ok bool) {
+	switch strings.TrimSuffix(path, "/") {
+	case "":
+		return kindRoot, "", true
+	case "/principal":
+		return kindPrincipal, "", true
+	case "/addressbooks":
+		return kindHome, "", true
+	case "/addressbooks/contacts":
+		return kindAddressBook, "", true
+	}
+
+	name, found := strings.CutPrefix(path, "/addressbooks/contacts/")
+	if !found || name == "" || strings.Contains(name, "/") {
+		return 0, "", false
+	}
+	return kindObject, name, true
+}
+
+func (h *Handler) serve(c *gin.Context) {
+	kind, name, ok := parsePath(c.Param("path"))
+	if !ok {
+		apierror.Respond(c, http.StatusNotFound, "Recurso não encontrado")
+		return
+	}
+
+	switch c.Request.Method {
+	case http.MethodOptions:
+		h.options(c)
+	case "PROPFIND":
+		h.propfind(c, kind, name)
+	case "REPORT":
+		if kind != kindAddressBook {
+			h.methodNotAllowed(c)
+			return
+		}
+		h.report(c)
+	case http.MethodGet, http.MethodHead:
+		if kind != kindObject {
+			h.methodNotAllowed(c)
+			return
+		}
+		h.get(c, name)
+	case http.MethodPut:
+		if kind != kindObject {
+			h.methodNotAllowed(c)
+			return
+		}
+		h.put(c, name)
+	case http.MethodDelete:
+		if kind != kindObject {
+			h.methodNotAllowed(c)
+			return
+		}
+		h.delete(c, name)
+	}
+}
+
+func (h *Handler) options(c *gin.Context) {
+	c.Header("DAV", "1, 3, addressbook")
+	c.Header("Allow", strings.Join(methods, ", "))
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) methodNotAllowed(c *gin.Context) {
+	c.Header("Allow", strings.Join(methods, ", "))
+	apierror.Respond(c, http.StatusMethodNotAllowed, "Método não suportado neste recurso")
+}
+
+// entry é um contato exposto no catálogo, com o recurso e o vCard servidos
+type entry struct {
+	contact *contacts.Contact
+	href    string
+	uid     string
+	vcard   []byte
+}
+
+func newEntry(contact *contacts.Contact, object *Object) *entry {
+	name, uid := contact.ID+".vcf", contact.ID
+	if object != nil {
+		name, uid = object.Name, object.UID
+	}
+	return &entry{contact: contact, href: addressBookPath + url.PathEscape(name), uid: uid, vcard: encodeVCard(contact, uid)}
+}
+
+func (e *entry) etag() string {
+	return etag(e.vcard)
+}
+
+func (e *entry) props() []property {
+	return []property{
+		prop(nsDAV, "resourcetype", ""),
+		prop(nsDAV, "getetag", text(e.etag())),
+		prop(nsDAV, "getcontenttype", vCardContentType),
+		prop(nsDAV, "getcontentlength", strconv.Itoa(len(e.vcard))),
+		prop(nsDAV, "getlastmodified", e.contact.UpdatedAt.UTC().Format(http.TimeFormat)),
+		{name: xml.Name{Space: nsCardDAV, Local: "address-data"}, value: text(string(e.vcard)), hidden: true},
+	}
+}
+
+// hrefFor retorna o recurso de um contato a partir dos objetos do tenant
+func hrefFor(contactID string, objects map[string]*Object) string {
+	if object, ok := objects[contactID]; ok {
+		return addressBookPath + url.PathEscape(object.Name)
+	}
+	return addressBookPath + url.PathEscape(contactID+".vcf")
+}
+
+// collectionProps são as propriedades das coleções fixas do serviço
+func collectionProps(kind resourceKind) []property {
+	principal := prop(nsDAV, "current-user-principal", hrefValue(principalPath))
+
+	switch kind {
+	case kindPrincipal:
+		return []property{
+			prop(nsDAV, "resourcetype", "<d:collection/><d:principal/>"),
+			prop(nsDAV, "displayname", "Contatos"),
+			principal,
+			prop(nsDAV, "principal-URL", hrefValue(principalPath)),
+			prop(nsCardDAV, "addressbook-home-set", hrefValue(homePath)),
+		}
+	case kindHome:
+		return []property{
+			prop(nsDAV, "resourcetype", "<d:collection/>"),
+			prop(nsDAV, "displayname", "Catálogos de endereços"),
+			principal,
+		}
+	case kindAddressBook:
+		reports := ""
+		for _, report := range []string{"<card:addressbook-query/>", "<card:addressbook-multiget/>", "<d:sync-collection/>"} {
+			reports += "<d:supported-report><d:report>" + report + "</d:report></d:supported-report>"
+		}
+		return []property{
+			prop(nsDAV, "resourcetype", "<d:collection/><card:addressbook/>"),
+			prop(nsDAV, "displayname", "Contatos"),
+			prop(nsCardDAV, "addressbook-description", "Contatos do tenant"),
+			prop(nsCardDAV, "supported-address-data", `<card:address-data-type content-type="text/vcard" version="3.0"/>`),
+			prop(nsCardDAV, "max-resource-size", strconv.Itoa(maxResourceSize)),
+			prop(nsDAV, "supported-report-set", reports),
+			prop(nsDAV, "current-user-privilege-set", "<d:privilege><d:read/></d:privilege><d:privilege><d:write/></d:privilege>"),
+			principal,
+		}
+	default:
+		return []property{
+			prop(nsDAV, "resourcetype", "<d:collection/>"),
+			principal,
+		}
+	}
+}
+
+func (h *Handler) propfind(c *gin.Context, kind resourceKind, name string) {
+	body, ok := readBody(c)
+	if !ok {
+		return
+	}
+
+	req := &propfindRequest{}
+	if isXMLBody(body) {
+		if err := xml.Unmarshal(body, req); err != nil {
+			apierror.Respond(c, http.StatusBadRequest, "Corpo PROPFIND inválido: "+err.Error())
+			return
+		}
+	}
+	sel := newSelection(req.AllProp, req.PropName, req.Prop)
+
+	// Depth infinity é tratado como 1: não há coleções aninhadas além das fixas
+	children := c.GetHeader("Depth") != "0"
+	ctx := c.Request.Context()
+	tenantID := tenant.FromContext(c)
+
+	m := newMultistatus()
+	switch kind {
+	case kindObject:
+		e, _, err := h.find(ctx, tenantID, name)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		m.response(e.href, e.props(), sel)
+	case kindAddressBook:
+		m.response(addressBookPath, collectionProps(kind), sel)
+		if children {
+			entries, err := h.list(ctx, tenantID)
+			if err != nil {
+				respondError(c, err)
+				return
+			}
+			for _, e := range entries {
+				m.response(e.href, e.props(), sel)
+			}
+		}
+	case kindHome:
+		m.response(homePath, collectionProps(kind), sel)
+		if children {
+			m.response(addressBookPath, collectionProps(kindAddressBook), sel)
+		}
+	case kindPrincipal:
+		m.response(principalPath, collectionProps(kind), sel)
+	case kindRoot:
+		m.response(basePath, collectionProps(kind), sel)
+		if children {
+			m.response(principalPath, collectionProps(kindPrincipal), sel)
+			m.response(homePath, collectionProps(kindHome), sel)
+		}
+	}
+
+	writeMultistatus(c, m)
+}
+
+func (h *Handler) report(c *gin.Context) {
+	body, ok := readBody(c)
+	if !ok {
+		return
+	}
+
+	req := &reportRequest{}
+	if err := xml.Unmarshal(body, req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, "Corpo REPORT inválido: "+err.Error())
+		return
+	}
+	sel := newSelection(req.AllProp, nil, req.Prop)
+
+	switch req.XMLName {
+	case xml.Name{Space: nsCardDAV, Local: "addressbook-multiget"}:
+		h.multiget(c, req, sel)
+	case xml.Name{Space: nsCardDAV, Local: "addressbook-query"}:
+		h.query(c, req, sel)
+	case xml.Name{Space: nsDAV, Local: "sync-collection"}:
+		h.syncCollection(c, req, sel)
+	default:
+		preconditionFailed(c, http.StatusForbidden, xml.Name{Space: nsDAV, Local: "supported-report"})
+	}
+}
+
+func (h *Handler) multiget(c *gin.Context, req *reportRequest, sel selection) {
+	ctx := c.Request.Context()
+	tenantID := tenant.FromContext(c)
+
+	m := newMultistatus()
+	for _, href := range req.Hrefs {
+		name, ok := objectName(href)
+		if !ok {
+			m.status(href, http.StatusNotFound)
+			continue
+		}
+
+		e, _, err := h.find(ctx, tenantID, name)
+		if errors.Is(err, contacts.ErrNotFound) {
+			m.status(href, http.StatusNotFound)
+			continue
+		}
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		m.response(e.href, e.props(), sel)
+	}
+
+	writeMultistatus(c, m)
+}
+
+func (h *Handler) query(c *gin.Context, req *reportRequest, sel selection) {
+	if req.Filter != nil {
+		if condition := req.Filter.validate(); condition.Local != "" {
+			preconditionFailed(c, http.StatusForbidden, condition)
+			return
+		}
+	}
+
+	entries, err := h.list(c.Request.Context(), tenant.FromContext(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	limit := req.CardLimit.results()
+	m := newMultistatus()
+	matched := 0
+	for _, e := range entries {
+		if !req.Filter.matches(e.values) {
+			continue
+		}
+		if limit > 0 && matched == limit {
+			// Resultado truncado pelo limite pedido (RFC 6352, seção 8.6.1)
+			m.status(addressBookPath, http.StatusInsufficientStorage)
+			break
+		}
+		m.response(e.href, e.props(), sel)
+		matched++
+	}
+
+	writeMultistatus(c, m)
+}
+
+// values retorna os valores de uma propriedade do vCard servido, para os filtros
+func (e *entry) values(name string) []string {
+	var value string
+	switch name {
+	case "FN", "N":
+		value = e.contact.Name
+	case "EMAIL":
+		value = e.contact.Email
+	case "TEL":
+		value = e.contact.Phone
+	case "UID":
+		value = e.uid
+	case categoryProperty:
+		value = e.contact.CategoryID
+	}
+
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+// syncCollection entrega as alterações desde o token pela sincronização
+// incremental de contacts.Service
+func (h *Handler) syncCollection(c *gin.Context, req *reportRequest, sel selection) {
+	ctx := c.Request.Context()
+	tenantID := tenant.FromContext(c)
+
+	token := ""
+	if req.SyncToken != "" {
+		var ok bool
+		if token, ok = strings.CutPrefix(strings.TrimSpace(req.SyncToken), syncTokenPrefix); !ok {
+			preconditionFailed(c, http.StatusForbidden, xml.Name{Space: nsDAV, Local: "valid-sync-token"})
+			return
+		}
+	}
+
+	limit := min(req.DAVLimit.results(), contacts.MaxSyncLimit)
+	result, err := h.service.SyncContacts(ctx, tenantID, token, limit)
+	if errors.Is(err, contacts.ErrInvalidSyncToken) || errors.Is(err, contacts.ErrSyncTokenExpired) {
+		// O cliente descarta o token e sincroniza do zero (RFC 6578, seção 3.2)
+		preconditionFailed(c, http.StatusForbidden, xml.Name{Space: nsDAV, Local: "valid-sync-token"})
+		return
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	objects, err := h.store.FindAll(ctx, tenantID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	m := newMultistatus()
+	for _, contact := range result.Contacts {
+		e := newEntry(contact, objects[contact.ID])
+		m.response(e.href, e.props(), sel)
+	}
+	for _, deleted := range result.Deleted {
+		m.status(hrefFor(deleted.ID, objects), http.StatusNotFound)
+	}
+	if result.HasMore {
+		// O cliente repete o relatório com o novo token (RFC 6578, seção 3.6)
+		m.status(addressBookPath, http.StatusInsufficientStorage)
+	}
+	m.syncToken(syncTokenPrefix + result.Token)
+
+	writeMultistatus(c, m)
+}
+
+func (h *Handler) get(c *gin.Context, name string) {
+	e, _, err := h.find(c.Request.Context(), tenant.FromContext(c), name)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	tag := e.etag()
+	c.Header("ETag", tag)
+	c.Header("Last-Modified", e.contact.UpdatedAt.UTC().Format(http.TimeFormat))
+	if matchesETag(c.GetHeader("If-None-Match"), tag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, vCardContentType, e.vcard)
+}
+
+func (h *Handler) put(c *gin.Context, name string) {
+	ctx := c.Request.Context()
+	tenantID := tenant.FromContext(c)
+
+	switch c.ContentType() {
+	case "text/vcard", "text/x-vcard":
+	default:
+		preconditionFailed(c, http.StatusForbidden, xml.Name{Space: nsCardDAV, Local: "supported-address-data"})
+		return
+	}
+
+	body, ok := readBody(c)
+	if !ok {
+		return
+	}
+	parsed, err := parseVCard(body)
+	if err != nil {
+		slog.InfoContext(ctx, "vCard rejeitado", "error", err)
+		preconditionFailed(c, http.StatusForbidden, xml.Name{Space: nsCardDAV, Local: "valid-address-data"})
+		return
+	}
+
+	current, object, err := h.find(ctx, tenantID, name)
+	if err != nil && !errors.Is(err, contacts.ErrNotFound) {
+		respondError(c, err)
+		return
+	}
+
+	// Pré-condições de gravação (RFC 4918, seção 10.4); a comparação não é
+	// atômica com a gravação, que não aceita condições no repositório
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" && (current == nil || !matchesETag(ifMatch, current.etag())) {
+		apierror.Respond(c, http.StatusPreconditionFailed, "O contato foi alterado ou não existe")
+		return
+	}
+	if current != nil && c.GetHeader("If-None-Match") == "*" {
+		apierror.Respond(c, http.StatusPreconditionFailed, "O contato já existe")
+		return
+	}
+
+	// O servidor guarda apenas parte do vCard; sem ETag na resposta, o
+	// cliente lê de novo a versão armazenada (RFC 6352, seção 6.3.2.3)
+	if current != nil {
+		categoryID := current.contact.CategoryID
+		if parsed.HasCategory {
+			categoryID = parsed.CategoryID
+		}
+		req := &contacts.UpdateContactRequest{Name: parsed.Name, Email: parsed.Email, Phone: parsed.Phone, CategoryID: categoryID}
+		if err := binding.Validator.ValidateStruct(req); err != nil {
+			apierror.Respond(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, err := h.service.UpdateContact(ctx, tenantID, current.contact.ID, req.Name, req.Email, req.Phone, req.CategoryID); err != nil {
+			respondError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	req := &contacts.CreateContactRequest{Name: parsed.Name, Email: parsed.Email, Phone: parsed.Phone, CategoryID: parsed.CategoryID}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	created, err := h.service.CreateNewContact(ctx, tenantID, req.Name, req.Email, req.Phone, req.CategoryID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if err := h.register(ctx, tenantID, name, parsed.UID, created.ID, object); err != nil {
+		// Outra requisição gravou o mesmo recurso; o contato criado é desfeito
+		if err := h.service.DeleteContact(context.WithoutCancel(ctx), tenantID, created.ID); err != nil {
+			slog.ErrorContext(ctx, "Erro ao desfazer contato criado pelo CardDAV", "contact_id", created.ID, "error", err)
+		}
+		if errors.Is(err, ErrNameTaken) {
+			apierror.Respond(c, http.StatusPreconditionFailed, "O contato já existe")
+			return
+		}
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Location", addressBookPath+url.PathEscape(name))
+	c.Status(http.StatusCreated)
+}
+
+// register associa o nome e o UID escolhidos pelo cliente ao contato criado.
+// stale é o objeto anterior com o mesmo nome, cujo contato foi removido.
+func (h *Handler) register(ctx context.Context, tenantID, name, uid, contactID string, stale *Object) error {
+	if uid == "" {
+		uid = contactID
+	}
+	object := &Object{TenantID: tenantID, Name: name, ContactID: contactID, UID: uid}
+
+	if stale != nil {
+		return h.store.Replace(ctx, object, stale.ContactID)
+	}
+	return h.store.Create(ctx, object)
+}
+
+func (h *Handler) delete(c *gin.Context, name string) {
+	ctx := c.Request.Context()
+	tenantID := tenant.FromContext(c)
+
+	current, _, err := h.find(ctx, tenantID, name)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" && !matchesETag(ifMatch, current.etag()) {
+		apierror.Respond(c, http.StatusPreconditionFailed, "O contato foi alterado")
+		return
+	}
+
+	// O objeto é mantido para que a sincronização informe a remoção no mesmo recurso
+	if err := h.service.DeleteContact(ctx, tenantID, current.contact.ID); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// find carrega o contato do recurso name: o registrado pelo cliente ou, sem
+// registro, o contato cujo ID forma o nome <id>.vcf. O objeto registrado é
+// retornado mesmo quando o contato já foi removido.
+func (h *Handler) find(ctx context.Context, tenantID, name string) (*entry, *Object, error) {
+	object, err := h.store.FindByName(ctx, tenantID, name)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, nil, err
+	}
+
+	contactID := ""
+	if object != nil {
+		contactID = object.ContactID
+	} else if id, ok := strings.CutSuffix(name, ".vcf"); ok && uuid.Validate(id) == nil {
+		contactID = id
+	} else {
+		return nil, nil, contacts.ErrNotFound
+	}
+
+	contact, err := h.service.GetContactByID(ctx, tenantID, contactID)
+	if err != nil {
+		return nil, object, err
+	}
+	return newEntry(contact, object), object, nil
+}
+
+// list carrega todos os contatos do tenant com os respectivos recursos
+func (h *Handler) list(ctx context.Context, tenantID string) ([]*entry, error) {
+	all, err := h.service.GetAllContacts(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := h.store.FindAll(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*entry, 0, len(all))
+	for _, contact := range all {
+		entries = append(entries, newEntry(contact, objects[contact.ID]))
+	}
+	return entries, nil
+}
+
+// objectName extrai o nome do recurso de um href do catálogo, absoluto ou não
+func objectName(href string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return "", false
+	}
+	kind, name, ok := parsePath(strings.TrimPrefix(u.Path, strings.TrimSuffix(basePath, "/")))
+	return name, ok && kind == kindObject
+}
+
+// matchesETag compara a ETag com um cabeçalho If-Match ou If-None-Match, que
+// pode listar várias ETags ou conter *
+func matchesETag(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// readBody lê o corpo da requisição até maxResourceSize
+func readBody(c *gin.Context) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxResourceSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			preconditionFailed(c, http.StatusRequestEntityTooLarge, xml.Name{Space: nsCardDAV, Local: "max-resource-size"})
+			return nil, false
+		}
+		apierror.Respond(c, http.StatusBadRequest, "Erro ao ler o corpo da requisição")
+		return nil, false
+	}
+	return body, true
+}
+
+func writeMultistatus(c *gin.Context, m *multistatus) {
+	c.Data(http.StatusMultiStatus, "application/xml; charset=utf-8", m.bytes())
+}
+
+// preconditionFailed responde com o elemento DAV:error da pré-condição violada
+func preconditionFailed(c *gin.Context, status int, condition xml.Name) {
+	c.Data(status, "application/xml; charset=utf-8", errorBody(condition))
+}
+
+// respondError converte erros de domínio no status HTTP correspondente
+func respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, contacts.ErrNotFound):
+		apierror.Respond(c, http.StatusNotFound, "Contato não encontrado")
+	case errors.Is(err, contacts.ErrEmailAlreadyExists):
+		apierror.Respond(c, http.StatusConflict, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		apierror.Respond(c, http.StatusGatewayTimeout, "Tempo limite da operação excedido")
+	default:
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+	}
+}
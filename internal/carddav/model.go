@@ -0,0 +1,10 @@
+package carddav
+
+// Object associa o nome do recurso e o UID escolhidos por um cliente CardDAV
+// a um contato. Contatos sem Object são expostos como <id>.vcf, com o ID como UID.
+type Object struct {
+	TenantID  string
+	Name      string
+	ContactID string
+	UID       string
+}
@@ -0,0 +1,118 @@
+package carddav
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type Store interface {
+	FindByName(ctx context.Context, tenantID, name string) (*Object, error)
+
+	// FindAll retorna os objetos do tenant indexados pelo ID do contato
+	FindAll(ctx context.Context, tenantID string) (map[string]*Object, error)
+
+	// Create registra o objeto; retorna ErrNameTaken se o nome já estiver em uso
+	Create(ctx context.Context, object *Object) error
+
+	// Replace aponta o nome para outro contato, desde que ainda aponte para
+	// previousContactID; caso contrário retorna ErrNameTaken
+	Replace(ctx context.Context, object *Object, previousContactID string) error
+}
+
+// SQLStore guarda os objetos no PostgreSQL ou no SQLite
+type SQLStore struct {
+	db *sql.DB
+}
+
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+func (s *SQLStore) FindByName(ctx context.Context, tenantID, name string) (*Object, error) {
+	query := `
+		SELECT tenant_id, name, contact_id, uid
+		FROM carddav_objects
+		WHERE tenant_id = $1 AND name = $2
+	`
+
+	object := &Object{}
+	err := s.db.QueryRowContext(ctx, query, tenantID, name).Scan(&object.TenantID, &object.Name, &object.ContactID, &object.UID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar objeto CardDAV: %v", err)
+	}
+
+	return object, nil
+}
+
+func (s *SQLStore) FindAll(ctx context.Context, tenantID string) (map[string]*Object, error) {
+	query := `
+		SELECT tenant_id, name, contact_id, uid
+		FROM carddav_objects
+		WHERE tenant_id = $1
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar objetos CardDAV: %v", err)
+	}
+	defer rows.Close()
+
+	objects := map[string]*Object{}
+	for rows.Next() {
+		object := &Object{}
+		if err := rows.Scan(&object.TenantID, &object.Name, &object.ContactID, &object.UID); err != nil {
+			return nil, fmt.Errorf("erro ao ler objeto CardDAV: %v", err)
+		}
+		objects[object.ContactID] = object
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao listar objetos CardDAV: %v", err)
+	}
+
+	return objects, nil
+}
+
+func (s *SQLStore) Create(ctx context.Context, object *Object) error {
+	query := `
+		INSERT INTO carddav_objects (tenant_id, name, contact_id, uid)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, name) DO NOTHING
+	`
+
+	result, err := s.db.ExecContext(ctx, query, object.TenantID, object.Name, object.ContactID, object.UID)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar objeto CardDAV: %v", err)
+	}
+	return checkWritten(result)
+}
+
+func (s *SQLStore) Replace(ctx context.Context, object *Object, previousContactID string) error {
+	query := `
+		UPDATE carddav_objects
+		SET contact_id = $3, uid = $4
+		WHERE tenant_id = $1 AND name = $2 AND contact_id = $5
+	`
+
+	result, err := s.db.ExecContext(ctx, query, object.TenantID, object.Name, object.ContactID, object.UID, previousContactID)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar objeto CardDAV: %v", err)
+	}
+	return checkWritten(result)
+}
+
+func checkWritten(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao registrar objeto CardDAV: %v", err)
+	}
+	if rows == 0 {
+		return ErrNameTaken
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package carddav
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+)
+
+const (
+	// vCardContentType é o formato dos contatos servidos e aceitos
+	vCardContentType = "text/vcard; charset=utf-8"
+
+	// categoryProperty guarda a categoria do contato, que é um ID e não o
+	// nome exibido em CATEGORIES
+	categoryProperty = "X-CATEGORY-ID"
+
+	// maxLineLength é o limite de octetos por linha antes da dobra (RFC 6350)
+	maxLineLength = 75
+)
+
+// card são os campos de um vCard que o cadastro de contatos guarda. Os demais
+// (endereços, fotos, aniversários) são descartados na gravação.
+type card struct {
+	UID        string
+	Name       string
+	Email      string
+	Phone      string
+	CategoryID string
+
+	// HasCategory distingue a categoria ausente da categoria vazia
+	HasCategory bool
+}
+
+// encodeVCard gera o vCard 3.0 do contato, aceito pelos clientes de CardDAV
+// mais comuns. A saída é determinística: a ETag é o hash do vCard.
+func encodeVCard(contact *contacts.Contact, uid string) []byte {
+	var buf bytes.Buffer
+	line := func(value string) {
+		writeFolded(&buf, value)
+	}
+
+	line("BEGIN:VCARD")
+	line("VERSION:3.0")
+	line("PRODID:-//go-contacts-api//CardDAV//PT")
+	line("UID:" + escapeText(uid))
+	line("FN:" + escapeText(contact.Name))
+	line("N:;" + escapeText(contact.Name) + ";;;")
+	if contact.Email != "" {
+		line("EMAIL;TYPE=INTERNET:" + escapeText(contact.Email))
+	}
+	if contact.Phone != "" {
+		line("TEL:" + escapeText(contact.Phone))
+	}
+	if contact.CategoryID != "" {
+		line(categoryProperty + ":" + escapeText(contact.CategoryID))
+	}
+	line("REV:" + contact.UpdatedAt.UTC().Format("20060102T150405Z"))
+	line("END:VCARD")
+
+	return buf.Bytes()
+}
+
+// etag identifica a versão do vCard servido
+func etag(vcard []byte) string {
+	sum := sha256.Sum256(vcard)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// writeFolded escreve a linha terminada em CRLF, dobrada em linhas de até
+// maxLineLength octetos sem partir caracteres UTF-8
+func writeFolded(buf *bytes.Buffer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// A linha de continuação começa com o espaço da dobra
+		limit = maxLineLength - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+// parseVCard lê um vCard 3.0 ou 4.0 com um único contato
+func parseVCard(data []byte) (*card, error) {
+	lines := unfold(string(data))
+	if len(lines) < 2 || !strings.EqualFold(lines[0], "BEGIN:VCARD") || !strings.EqualFold(lines[len(lines)-1], "END:VCARD") {
+		return nil, errors.New("o corpo deve conter um único vCard")
+	}
+
+	c := &card{}
+	var version, structuredName string
+	for _, line := range lines[1 : len(lines)-1] {
+		name, value, ok := splitProperty(line)
+		if !ok {
+			return nil, fmt.Errorf("linha inválida: %q", line)
+		}
+
+		switch name {
+		case "BEGIN":
+			return nil, errors.New("o corpo deve conter um único vCard")
+		case "VERSION":
+			version = value
+		case "UID":
+			c.UID = unescapeText(value)
+		case "FN":
+			c.Name = unescapeText(value)
+		case "N":
+			structuredName = value
+		case "EMAIL":
+			if c.Email == "" {
+				c.Email = unescapeText(value)
+			}
+		case "TEL":
+			if c.Phone == "" {
+				c.Phone = strings.TrimPrefix(unescapeText(value), "tel:")
+			}
+		case categoryProperty:
+			c.CategoryID = unescapeText(value)
+			c.HasCategory = true
+		}
+	}
+
+	if version != "3.0" && version != "4.0" {
+		return nil, fmt.Errorf("versão de vCard não suportada: %q", version)
+	}
+	if c.Name == "" {
+		c.Name = formattedName(structuredName)
+	}
+	return c, nil
+}
+
+// unfold junta as linhas dobradas e descarta as linhas vazias
+func unfold(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+
+	var lines []string
+	for _, line := range strings.Split(data, "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// splitProperty separa o nome e o valor de uma linha de conteúdo, ignorando
+// os parâmetros. O nome volta em maiúsculas e sem o prefixo de grupo (item1.EMAIL).
+func splitProperty(line string) (name, value string, ok bool) {
+	quoted := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ':' && !quoted:
+			name, _, _ = strings.Cut(line[:i], ";")
+			if dot := strings.LastIndex(name, "."); dot >= 0 {
+				name = name[dot+1:]
+			}
+			return strings.ToUpper(name), line[i+1:], name != ""
+		}
+	}
+	return "", "", false
+}
+
+// formattedName monta o nome a partir do N: prefixo, nome, nomes adicionais,
+// sobrenome e sufixo
+func formattedName(value string) string {
+	parts := splitComponents(value)
+	for len(parts) < 5 {
+		parts = append(parts, "")
+	}
+
+	var words []string
+	for _, part := range []string{parts[3], parts[1], parts[2], parts[0], parts[4]} {
+		if part = strings.TrimSpace(unescapeText(part)); part != "" {
+			words = append(words, part)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// splitComponents separa os componentes de um valor estruturado pelos ';' não escapados
+func splitComponents(value string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ';':
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+var (
+	textEscaper   = strings.NewReplacer(`\`, `\\`, "\n", `\n`, ",", `\,`, ";", `\;`)
+	textUnescaper = strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";")
+)
+
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+func unescapeText(value string) string {
+	return textUnescaper.Replace(value)
+}
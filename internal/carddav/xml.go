@@ -0,0 +1,238 @@
+package carddav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	nsDAV     = "DAV:"
+	nsCardDAV = "urn:ietf:params:xml:ns:carddav"
+)
+
+// prefixes são os prefixos declarados na raiz das respostas
+var prefixes = map[string]string{
+	nsDAV:     "d",
+	nsCardDAV: "card",
+}
+
+// anyElement captura o nome de um elemento qualquer
+type anyElement struct {
+	XMLName xml.Name
+}
+
+type propRequest struct {
+	Names []anyElement `xml:",any"`
+}
+
+type propfindRequest struct {
+	XMLName  xml.Name     `xml:"DAV: propfind"`
+	AllProp  *struct{}    `xml:"DAV: allprop"`
+	PropName *struct{}    `xml:"DAV: propname"`
+	Prop     *propRequest `xml:"DAV: prop"`
+}
+
+type limitRequest struct {
+	DAVResults  int `xml:"DAV: nresults"`
+	CardResults int `xml:"urn:ietf:params:xml:ns:carddav nresults"`
+}
+
+func (l *limitRequest) results() int {
+	if l == nil {
+		return 0
+	}
+	return max(l.DAVResults, l.CardResults)
+}
+
+// reportRequest reúne os elementos dos relatórios addressbook-query,
+// addressbook-multiget e sync-collection; XMLName identifica o relatório
+type reportRequest struct {
+	XMLName   xml.Name
+	AllProp   *struct{}      `xml:"DAV: allprop"`
+	Prop      *propRequest   `xml:"DAV: prop"`
+	Hrefs     []string       `xml:"DAV: href"`
+	Filter    *filterRequest `xml:"urn:ietf:params:xml:ns:carddav filter"`
+	CardLimit *limitRequest  `xml:"urn:ietf:params:xml:ns:carddav limit"`
+	DAVLimit  *limitRequest  `xml:"DAV: limit"`
+	SyncToken string         `xml:"DAV: sync-token"`
+	SyncLevel string         `xml:"DAV: sync-level"`
+}
+
+// selection são as propriedades pedidas: todas (allprop), apenas os nomes
+// (propname) ou uma lista
+type selection struct {
+	all   bool
+	names bool
+	props []xml.Name
+}
+
+func newSelection(allProp, propName *struct{}, prop *propRequest) selection {
+	switch {
+	case propName != nil:
+		return selection{names: true}
+	case prop != nil:
+		var s selection
+		for _, element := range prop.Names {
+			s.props = append(s.props, element.XMLName)
+		}
+		return s
+	default:
+		// Sem corpo ou com allprop
+		return selection{all: true}
+	}
+}
+
+// property é uma propriedade de um recurso; value é o conteúdo já em XML
+type property struct {
+	name  xml.Name
+	value string
+
+	// hidden exclui a propriedade de allprop, como address-data
+	hidden bool
+}
+
+func prop(space, local, value string) property {
+	return property{name: xml.Name{Space: space, Local: local}, value: value}
+}
+
+// text escapa um valor textual para uso como conteúdo de uma propriedade
+func text(value string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
+
+func hrefValue(href string) string {
+	return "<d:href>" + text(href) + "</d:href>"
+}
+
+// multistatus monta a resposta 207 Multi-Status
+type multistatus struct {
+	buf bytes.Buffer
+}
+
+func newMultistatus() *multistatus {
+	m := &multistatus{}
+	m.buf.WriteString(xml.Header)
+	m.buf.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:card="urn:ietf:params:xml:ns:carddav">`)
+	return m
+}
+
+// response escreve as propriedades selecionadas do recurso; as pedidas que o
+// recurso não tem voltam com 404
+func (m *multistatus) response(href string, props []property, sel selection) {
+	var found []property
+	var missing []xml.Name
+
+	switch {
+	case sel.all || sel.names:
+		for _, p := range props {
+			if sel.names {
+				found = append(found, property{name: p.name})
+			} else if !p.hidden {
+				found = append(found, p)
+			}
+		}
+	default:
+		for _, name := range sel.props {
+			p, ok := findProperty(props, name)
+			if !ok {
+				missing = append(missing, name)
+				continue
+			}
+			found = append(found, p)
+		}
+	}
+
+	m.buf.WriteString("<d:response>")
+	m.buf.WriteString(hrefValue(href))
+	if len(found) > 0 || len(missing) == 0 {
+		m.propstat(found, http.StatusOK)
+	}
+	if len(missing) > 0 {
+		var empty []property
+		for _, name := range missing {
+			empty = append(empty, property{name: name})
+		}
+		m.propstat(empty, http.StatusNotFound)
+	}
+	m.buf.WriteString("</d:response>")
+}
+
+func (m *multistatus) propstat(props []property, status int) {
+	m.buf.WriteString("<d:propstat><d:prop>")
+	for _, p := range props {
+		writeElement(&m.buf, p.name, p.value)
+	}
+	m.buf.WriteString("</d:prop>")
+	m.buf.WriteString("<d:status>" + statusLine(status) + "</d:status>")
+	m.buf.WriteString("</d:propstat>")
+}
+
+// status escreve uma resposta sem propriedades, como a de um contato removido
+func (m *multistatus) status(href string, status int) {
+	m.buf.WriteString("<d:response>")
+	m.buf.WriteString(hrefValue(href))
+	m.buf.WriteString("<d:status>" + statusLine(status) + "</d:status>")
+	m.buf.WriteString("</d:response>")
+}
+
+func (m *multistatus) syncToken(token string) {
+	m.buf.WriteString("<d:sync-token>" + text(token) + "</d:sync-token>")
+}
+
+func (m *multistatus) bytes() []byte {
+	m.buf.WriteString("</d:multistatus>")
+	return m.buf.Bytes()
+}
+
+func findProperty(props []property, name xml.Name) (property, bool) {
+	for _, p := range props {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return property{}, false
+}
+
+// writeElement escreve o elemento com o prefixo do namespace; namespaces sem
+// prefixo declarado, comuns nas propriedades pedidas e ausentes, são
+// declarados no próprio elemento
+func writeElement(buf *bytes.Buffer, name xml.Name, value string) {
+	tag := name.Local
+	declaration := ""
+	if prefix, ok := prefixes[name.Space]; ok {
+		tag = prefix + ":" + name.Local
+	} else if name.Space != "" {
+		tag = "x:" + name.Local
+		declaration = ` xmlns:x="` + text(name.Space) + `"`
+	}
+
+	if value == "" {
+		fmt.Fprintf(buf, "<%s%s/>", tag, declaration)
+		return
+	}
+	fmt.Fprintf(buf, "<%s%s>%s</%s>", tag, declaration, value, tag)
+}
+
+func statusLine(status int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", status, http.StatusText(status))
+}
+
+// errorBody é o corpo DAV:error que indica a pré-condição violada
+func errorBody(condition xml.Name) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<d:error xmlns:d="DAV:" xmlns:card="urn:ietf:params:xml:ns:carddav">`)
+	writeElement(&buf, condition, "")
+	buf.WriteString("</d:error>")
+	return buf.Bytes()
+}
+
+// isXMLBody indica se o corpo foi enviado; corpo vazio equivale a allprop
+func isXMLBody(body []byte) bool {
+	return strings.TrimSpace(string(body)) != ""
+}
@@ -0,0 +1,153 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/cache"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/metrics"
+	"golang.org/x/sync/singleflight"
+)
+
+const cacheName = "contacts"
+
+// cachingRepository atende FindByID a partir do cache e invalida a entrada
+// em Update e Delete. Buscas concorrentes pelo mesmo contato ausente do cache
+// compartilham uma única consulta ao repositório decorado.
+type cachingRepository struct {
+	next  Repository
+	cache cache.Cache
+	ttl   time.Duration
+	group singleflight.Group
+
+	// generation muda a cada invalidação; uma busca iniciada antes dela não
+	// grava no cache o valor que pode ter ficado desatualizado. mu impede que
+	// a invalidação ocorra entre a conferência e a gravação.
+	mu         sync.Mutex
+	generation atomic.Uint64
+}
+
+// NewCachingRepository decora next com um cache de leitura. Falhas do cache
+// são registradas no log e a operação segue direto para next.
+func NewCachingRepository(next Repository, c cache.Cache, ttl time.Duration) Repository {
+	return &cachingRepository{next: next, cache: c, ttl: ttl}
+}
+
+func cacheKey(tenantID, id string) string {
+	return "contacts:" + tenantID + ":" + id
+}
+
+func (r *cachingRepository) Create(ctx context.Context, contact *Contact) error {
+	return r.next.Create(ctx, contact)
+}
+
+func (r *cachingRepository) FindAll(ctx context.Context, tenantID string) ([]*Contact, error) {
+	return r.next.FindAll(ctx, tenantID)
+}
+
+func (r *cachingRepository) FindByID(ctx context.Context, tenantID, id string) (*Contact, error) {
+	key := cacheKey(tenantID, id)
+
+	if contact, ok := r.get(ctx, key); ok {
+		metrics.CacheRequestsTotal.WithLabelValues(cacheName, metrics.CacheHit).Inc()
+		return contact, nil
+	}
+	metrics.CacheRequestsTotal.WithLabelValues(cacheName, metrics.CacheMiss).Inc()
+
+	result := r.group.DoChan(key, func() (any, error) {
+		// A consulta é compartilhada e não deve ser interrompida quando a
+		// requisição que a iniciou é cancelada; o prazo de cada operação
+		// continua sendo aplicado pelo repositório decorado
+		ctx := context.WithoutCancel(ctx)
+		generation := r.generation.Load()
+
+		contact, err := r.next.FindByID(ctx, tenantID, id)
+		if err != nil {
+			return nil, err
+		}
+
+		r.mu.Lock()
+		if r.generation.Load() == generation {
+			r.set(ctx, key, contact)
+		}
+		r.mu.Unlock()
+		return contact, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		// Cada chamador recebe a própria cópia do contato compartilhado
+		contact := *res.Val.(*Contact)
+		return &contact, nil
+	}
+}
+
+// FindChanges não passa pelo cache: a sincronização precisa das alterações
+// confirmadas no banco
+func (r *cachingRepository) FindChanges(ctx context.Context, tenantID string, after int64, limit int) (*ChangeSet, error) {
+	return r.next.FindChanges(ctx, tenantID, after, limit)
+}
+
+func (r *cachingRepository) Update(ctx context.Context, contact *Contact) error {
+	err := r.next.Update(ctx, contact)
+	r.invalidate(ctx, cacheKey(contact.TenantID, contact.ID))
+	return err
+}
+
+func (r *cachingRepository) Delete(ctx context.Context, tenantID, id string) error {
+	err := r.next.Delete(ctx, tenantID, id)
+	r.invalidate(ctx, cacheKey(tenantID, id))
+	return err
+}
+
+func (r *cachingRepository) get(ctx context.Context, key string) (*Contact, bool) {
+	value, ok, err := r.cache.Get(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, "Erro ao consultar cache", "cache", cacheName, "error", err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+
+	contact := &Contact{}
+	if err := json.Unmarshal(value, contact); err != nil {
+		slog.WarnContext(ctx, "Entrada inválida no cache", "cache", cacheName, "error", err)
+		return nil, false
+	}
+	return contact, true
+}
+
+func (r *cachingRepository) set(ctx context.Context, key string, contact *Contact) {
+	value, err := json.Marshal(contact)
+	if err != nil {
+		slog.WarnContext(ctx, "Erro ao serializar contato para o cache", "cache", cacheName, "error", err)
+		return
+	}
+	if err := r.cache.Set(ctx, key, value, r.ttl); err != nil {
+		slog.WarnContext(ctx, "Erro ao gravar no cache", "cache", cacheName, "error", err)
+	}
+}
+
+// invalidate remove a entrada mesmo quando a operação falhou, já que o erro
+// pode ter ocorrido depois de o banco aplicar a alteração. Buscas posteriores
+// não aproveitam uma consulta iniciada antes da invalidação.
+func (r *cachingRepository) invalidate(ctx context.Context, key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation.Add(1)
+	r.group.Forget(key)
+	if err := r.cache.Delete(context.WithoutCancel(ctx), key); err != nil {
+		slog.ErrorContext(ctx, "Erro ao invalidar cache; a entrada expira pelo TTL", "cache", cacheName, "key", key, "error", err)
+	}
+}
@@ -0,0 +1,168 @@
+package contacts_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/contacts/contactstest"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/cache"
+)
+
+// countingRepository conta as buscas por ID que chegam ao repositório e pode
+// segurá-las até release ser fechado
+type countingRepository struct {
+	contacts.Repository
+	finds   atomic.Int64
+	release chan struct{}
+}
+
+func (r *countingRepository) FindByID(ctx context.Context, tenantID, id string) (*contacts.Contact, error) {
+	r.finds.Add(1)
+	if r.release != nil {
+		<-r.release
+	}
+	return r.Repository.FindByID(ctx, tenantID, id)
+}
+
+// failingCache simula um cache compartilhado indisponível
+type failingCache struct{}
+
+func (failingCache) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("cache indisponível")
+}
+
+func (failingCache) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("cache indisponível")
+}
+
+func (failingCache) Delete(context.Context, string) error {
+	return errors.New("cache indisponível")
+}
+
+func TestCachingRepositoryConformance(t *testing.T) {
+	contactstest.RunRepositoryTests(t, func(t *testing.T) contacts.Repository {
+		return contacts.NewCachingRepository(contacts.NewMemoryRepository(), cache.NewLRU(100), time.Minute)
+	})
+}
+
+func TestCachingRepositoryServesHits(t *testing.T) {
+	ctx := context.Background()
+	backend := &countingRepository{Repository: contacts.NewMemoryRepository()}
+	repo := contacts.NewCachingRepository(backend, cache.NewLRU(100), time.Minute)
+
+	contact := contactstest.NewContact("acme", "joao@example.com")
+	if err := repo.Create(ctx, contact); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		found, err := repo.FindByID(ctx, "acme", contact.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		found.Name = "alterado pelo chamador"
+	}
+	if got := backend.finds.Load(); got != 1 {
+		t.Fatalf("%d buscas chegaram ao repositório, esperado 1", got)
+	}
+
+	// O contato retornado é uma cópia; alterá-la não afeta o cache
+	found, _ := repo.FindByID(ctx, "acme", contact.ID)
+	if found.Name != contact.Name {
+		t.Fatalf("nome em cache = %q, esperado %q", found.Name, contact.Name)
+	}
+
+	// Outro tenant não compartilha a entrada
+	if _, err := repo.FindByID(ctx, "globex", contact.ID); !errors.Is(err, contacts.ErrNotFound) {
+		t.Fatalf("erro = %v, esperado %v", err, contacts.ErrNotFound)
+	}
+}
+
+func TestCachingRepositoryInvalidates(t *testing.T) {
+	ctx := context.Background()
+	repo := contacts.NewCachingRepository(contacts.NewMemoryRepository(), cache.NewLRU(100), time.Minute)
+
+	contact := contactstest.NewContact("acme", "joao@example.com")
+	if err := repo.Create(ctx, contact); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	repo.FindByID(ctx, "acme", contact.ID)
+
+	contact.Name = "João Souza"
+	if err := repo.Update(ctx, contact); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	found, err := repo.FindByID(ctx, "acme", contact.ID)
+	if err != nil || found.Name != "João Souza" {
+		t.Fatalf("FindByID após Update = %+v, %v", found, err)
+	}
+
+	if err := repo.Delete(ctx, "acme", contact.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, "acme", contact.ID); !errors.Is(err, contacts.ErrNotFound) {
+		t.Fatalf("erro após Delete = %v, esperado %v", err, contacts.ErrNotFound)
+	}
+}
+
+func TestCachingRepositoryCoalescesMisses(t *testing.T) {
+	ctx := context.Background()
+	backend := &countingRepository{Repository: contacts.NewMemoryRepository(), release: make(chan struct{})}
+	repo := contacts.NewCachingRepository(backend, cache.NewLRU(100), time.Minute)
+
+	contact := contactstest.NewContact("acme", "joao@example.com")
+	if err := repo.Create(ctx, contact); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	const callers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.FindByID(ctx, "acme", contact.ID)
+			errs <- err
+		}()
+	}
+
+	// Aguarda a primeira busca chegar ao repositório antes de liberá-la
+	for backend.finds.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(backend.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+	}
+	if got := backend.finds.Load(); got != 1 {
+		t.Fatalf("%d buscas chegaram ao repositório, esperado 1", got)
+	}
+}
+
+func TestCachingRepositoryToleratesCacheFailures(t *testing.T) {
+	ctx := context.Background()
+	repo := contacts.NewCachingRepository(contacts.NewMemoryRepository(), failingCache{}, time.Minute)
+
+	contact := contactstest.NewContact("acme", "joao@example.com")
+	if err := repo.Create(ctx, contact); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, "acme", contact.ID); err != nil {
+		t.Fatalf("FindByID com cache indisponível: %v", err)
+	}
+	if err := repo.Delete(ctx, "acme", contact.ID); err != nil {
+		t.Fatalf("Delete com cache indisponível: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package contactstest
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/dbtest"
+	"github.com/google/uuid"
+)
+
+// sqliteVersionBeforeChangeSeq é a última migration do SQLite anterior à
+// sequência de alterações usada na sincronização incremental
+const sqliteVersionBeforeChangeSeq = 4
+
+// OpenSQLiteWithLegacyContacts cria um banco SQLite com n contatos do tenant
+// gravados antes da migration que adiciona change_seq e então aplica as
+// migrations restantes. Os contatos são retornados na ordem de criação.
+func OpenSQLiteWithLegacyContacts(t *testing.T, tenantID string, n int) (*sql.DB, []*contacts.Contact) {
+	t.Helper()
+
+	db := dbtest.OpenSQLiteAt(t, sqliteVersionBeforeChangeSeq)
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO contacts (id, tenant_id, name, email, phone, category_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	created := time.Now().UTC().Truncate(time.Second).Add(-time.Duration(n) * time.Minute)
+	legacy := make([]*contacts.Contact, 0, n)
+	for i := range n {
+		contact := NewContact(tenantID, fmt.Sprintf("contato%d@example.com", i))
+		contact.ID = uuid.NewString()
+		contact.CreatedAt = created.Add(time.Duration(i) * time.Minute)
+		contact.UpdatedAt = contact.CreatedAt
+
+		if _, err := tx.Exec(query, contact.ID, contact.TenantID, contact.Name, contact.Email, contact.Phone, contact.CategoryID, contact.CreatedAt, contact.UpdatedAt); err != nil {
+			t.Fatalf("erro ao gravar contato anterior às migrations: %v", err)
+		}
+		legacy = append(legacy, contact)
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("erro ao gravar contatos anteriores às migrations: %v", err)
+	}
+
+	dbtest.MigrateSQLite(t, db)
+	return db, legacy
+}
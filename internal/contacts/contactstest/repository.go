@@ -0,0 +1,321 @@
+// Package contactstest reúne testes compartilhados pelas implementações de
+// contacts.Repository
+package contactstest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/google/uuid"
+)
+
+// RunRepositoryTests verifica que o repositório criado por newRepository
+// segue as regras esperadas de contacts.Repository. newRepository é chamado
+// por subteste e deve retornar um repositório vazio.
+func RunRepositoryTests(t *testing.T, newRepository func(t *testing.T) contacts.Repository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo contacts.Repository)
+	}{
+		{"CreateAndFindByID", testCreateAndFindByID},
+		{"CreateDuplicateEmail", testCreateDuplicateEmail},
+		{"FindByIDNotFound", testFindByIDNotFound},
+		{"FindAllByTenant", testFindAllByTenant},
+		{"Update", testUpdate},
+		{"UpdateNotFound", testUpdateNotFound},
+		{"UpdateDuplicateEmail", testUpdateDuplicateEmail},
+		{"Delete", testDelete},
+		{"ConcurrentCreate", testConcurrentCreate},
+		{"FindChanges", testFindChanges},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepository(t))
+		})
+	}
+}
+
+// NewContact retorna um contato válido, ainda sem ID, para o tenant informado
+func NewContact(tenantID, email string) *contacts.Contact {
+	// O PostgreSQL guarda os horários com precisão de microssegundos
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return &contacts.Contact{
+		TenantID:   tenantID,
+		Name:       "João Silva",
+		Email:      email,
+		Phone:      "11999998888",
+		CategoryID: uuid.NewString(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func create(t *testing.T, repo contacts.Repository, contact *contacts.Contact) *contacts.Contact {
+	t.Helper()
+	if err := repo.Create(context.Background(), contact); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return contact
+}
+
+func assertContact(t *testing.T, got, want *contacts.Contact) {
+	t.Helper()
+	if got.ID != want.ID || got.TenantID != want.TenantID || got.Name != want.Name ||
+		got.Email != want.Email || got.Phone != want.Phone || got.CategoryID != want.CategoryID {
+		t.Fatalf("contato = %+v, esperado %+v", got, want)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Fatalf("datas = %v/%v, esperado %v/%v", got.CreatedAt, got.UpdatedAt, want.CreatedAt, want.UpdatedAt)
+	}
+}
+
+func assertError(t *testing.T, err, want error) {
+	t.Helper()
+	if !errors.Is(err, want) {
+		t.Fatalf("erro = %v, esperado %v", err, want)
+	}
+}
+
+func testCreateAndFindByID(t *testing.T, repo contacts.Repository) {
+	contact := create(t, repo, NewContact("acme", "joao@example.com"))
+	if contact.ID == "" {
+		t.Fatal("Create não definiu o ID")
+	}
+
+	found, err := repo.FindByID(context.Background(), "acme", contact.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	assertContact(t, found, contact)
+}
+
+func testCreateDuplicateEmail(t *testing.T, repo contacts.Repository) {
+	create(t, repo, NewContact("acme", "joao@example.com"))
+
+	err := repo.Create(context.Background(), NewContact("acme", "joao@example.com"))
+	assertError(t, err, contacts.ErrEmailAlreadyExists)
+
+	// O email é único apenas dentro do tenant
+	create(t, repo, NewContact("globex", "joao@example.com"))
+}
+
+func testFindByIDNotFound(t *testing.T, repo contacts.Repository) {
+	contact := create(t, repo, NewContact("acme", "joao@example.com"))
+
+	_, err := repo.FindByID(context.Background(), "acme", uuid.NewString())
+	assertError(t, err, contacts.ErrNotFound)
+
+	_, err = repo.FindByID(context.Background(), "acme", "nao-e-um-uuid")
+	assertError(t, err, contacts.ErrNotFound)
+
+	_, err = repo.FindByID(context.Background(), "globex", contact.ID)
+	assertError(t, err, contacts.ErrNotFound)
+}
+
+func testFindAllByTenant(t *testing.T, repo contacts.Repository) {
+	ctx := context.Background()
+
+	empty, err := repo.FindAll(ctx, "acme")
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Fatalf("FindAll sem contatos = %#v, esperado lista vazia", empty)
+	}
+
+	first := create(t, repo, NewContact("acme", "joao@example.com"))
+	second := create(t, repo, NewContact("acme", "maria@example.com"))
+	create(t, repo, NewContact("globex", "jose@example.com"))
+
+	found, err := repo.FindAll(ctx, "acme")
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("FindAll retornou %d contatos, esperado 2", len(found))
+	}
+
+	byID := map[string]*contacts.Contact{}
+	for _, contact := range found {
+		byID[contact.ID] = contact
+	}
+	for _, want := range []*contacts.Contact{first, second} {
+		got, ok := byID[want.ID]
+		if !ok {
+			t.Fatalf("FindAll não retornou o contato %s", want.ID)
+		}
+		assertContact(t, got, want)
+	}
+}
+
+func testUpdate(t *testing.T, repo contacts.Repository) {
+	ctx := context.Background()
+	contact := create(t, repo, NewContact("acme", "joao@example.com"))
+
+	updated := *contact
+	updated.Name = "João Souza"
+	updated.Email = "joao.souza@example.com"
+	updated.Phone = "11888887777"
+	updated.CategoryID = uuid.NewString()
+	updated.UpdatedAt = contact.UpdatedAt.Add(time.Minute)
+
+	if err := repo.Update(ctx, &updated); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, "acme", contact.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	assertContact(t, found, &updated)
+
+	// Manter o próprio email não conflita com ele mesmo
+	if err := repo.Update(ctx, &updated); err != nil {
+		t.Fatalf("Update com o mesmo email: %v", err)
+	}
+}
+
+func testUpdateNotFound(t *testing.T, repo contacts.Repository) {
+	ctx := context.Background()
+
+	missing := NewContact("acme", "joao@example.com")
+	missing.ID = uuid.NewString()
+	assertError(t, repo.Update(ctx, missing), contacts.ErrNotFound)
+
+	contact := create(t, repo, NewContact("acme", "maria@example.com"))
+	otherTenant := *contact
+	otherTenant.TenantID = "globex"
+	otherTenant.Name = "Outro tenant"
+	assertError(t, repo.Update(ctx, &otherTenant), contacts.ErrNotFound)
+
+	found, err := repo.FindByID(ctx, "acme", contact.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	assertContact(t, found, contact)
+}
+
+func testUpdateDuplicateEmail(t *testing.T, repo contacts.Repository) {
+	create(t, repo, NewContact("acme", "joao@example.com"))
+	contact := create(t, repo, NewContact("acme", "maria@example.com"))
+
+	contact.Email = "joao@example.com"
+	assertError(t, repo.Update(context.Background(), contact), contacts.ErrEmailAlreadyExists)
+}
+
+func testDelete(t *testing.T, repo contacts.Repository) {
+	ctx := context.Background()
+	contact := create(t, repo, NewContact("acme", "joao@example.com"))
+
+	assertError(t, repo.Delete(ctx, "globex", contact.ID), contacts.ErrNotFound)
+
+	if err := repo.Delete(ctx, "acme", contact.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	_, err := repo.FindByID(ctx, "acme", contact.ID)
+	assertError(t, err, contacts.ErrNotFound)
+
+	assertError(t, repo.Delete(ctx, "acme", contact.ID), contacts.ErrNotFound)
+	assertError(t, repo.Delete(ctx, "acme", "nao-e-um-uuid"), contacts.ErrNotFound)
+
+	// O email fica livre após a exclusão
+	create(t, repo, NewContact("acme", "joao@example.com"))
+}
+
+func testConcurrentCreate(t *testing.T, repo contacts.Repository) {
+	const workers = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- repo.Create(context.Background(), NewContact("acme", "joao@example.com"))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, contacts.ErrEmailAlreadyExists):
+			t.Fatalf("Create concorrente: %v", err)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("%d contatos criados com o mesmo email, esperado 1", created)
+	}
+}
+
+func testFindChanges(t *testing.T, repo contacts.Repository) {
+	ctx := context.Background()
+
+	empty, err := repo.FindChanges(ctx, "acme", -1, 10)
+	if err != nil {
+		t.Fatalf("FindChanges sem alterações: %v", err)
+	}
+	if len(empty.Contacts) != 0 || len(empty.Deleted) != 0 || empty.HasMore {
+		t.Fatalf("FindChanges sem alterações = %+v", empty)
+	}
+
+	joao := create(t, repo, NewContact("acme", "joao@example.com"))
+	maria := create(t, repo, NewContact("acme", "maria@example.com"))
+	create(t, repo, NewContact("globex", "joao@example.com"))
+
+	full, err := repo.FindChanges(ctx, "acme", -1, 10)
+	if err != nil {
+		t.Fatalf("FindChanges completo: %v", err)
+	}
+	if len(full.Contacts) != 2 || full.Contacts[0].ID != joao.ID || full.Contacts[1].ID != maria.ID {
+		t.Fatalf("FindChanges completo = %+v", full.Contacts)
+	}
+
+	// Após o token, só entram o contato atualizado e o removido, na ordem das alterações
+	updated := *joao
+	updated.Name = "João Souza"
+	if err := repo.Update(ctx, &updated); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := repo.Delete(ctx, "acme", maria.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	page, err := repo.FindChanges(ctx, "acme", full.Seq, 1)
+	if err != nil {
+		t.Fatalf("FindChanges: %v", err)
+	}
+	if len(page.Contacts) != 1 || page.Contacts[0].Name != "João Souza" || len(page.Deleted) != 0 || !page.HasMore {
+		t.Fatalf("primeira página = %+v", page)
+	}
+
+	page, err = repo.FindChanges(ctx, "acme", page.Seq, 1)
+	if err != nil {
+		t.Fatalf("FindChanges: %v", err)
+	}
+	if len(page.Contacts) != 0 || len(page.Deleted) != 1 || page.Deleted[0].ID != maria.ID || page.HasMore {
+		t.Fatalf("segunda página = %+v", page)
+	}
+
+	// Sem novas alterações, o token permanece válido e a resposta vem vazia
+	last, err := repo.FindChanges(ctx, "acme", page.Seq, 1)
+	if err != nil {
+		t.Fatalf("FindChanges sem novas alterações: %v", err)
+	}
+	if len(last.Contacts) != 0 || len(last.Deleted) != 0 || last.Seq != page.Seq {
+		t.Fatalf("FindChanges sem novas alterações = %+v", last)
+	}
+
+	// Uma posição que o tenant ainda não alcançou não pode ser continuada
+	_, err = repo.FindChanges(ctx, "acme", page.Seq+100, 1)
+	assertError(t, err, contacts.ErrSyncTokenExpired)
+}
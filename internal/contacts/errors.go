@@ -0,0 +1,18 @@
+package contacts
+
+import "errors"
+
+var (
+	// ErrNotFound indica que o contato não existe para o tenant informado
+	ErrNotFound = errors.New("contato não encontrado")
+
+	// ErrEmailAlreadyExists indica que já existe um contato com o mesmo email no tenant
+	ErrEmailAlreadyExists = errors.New("já existe um contato com este email")
+
+	// ErrInvalidSyncToken indica um token de sincronização malformado ou de outro tenant
+	ErrInvalidSyncToken = errors.New("token de sincronização inválido")
+
+	// ErrSyncTokenExpired indica que as alterações desde o token não estão
+	// mais disponíveis e o cliente precisa de uma sincronização completa
+	ErrSyncTokenExpired = errors.New("token de sincronização expirado; sincronize novamente sem token")
+)
@@ -0,0 +1,60 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/outbox"
+	"github.com/google/uuid"
+)
+
+// Tipos dos eventos publicados a cada alteração de contato. Não há
+// contact.merged porque a API ainda não mescla contatos.
+const (
+	EventContactCreated = "contact.created"
+	EventContactUpdated = "contact.updated"
+	EventContactDeleted = "contact.deleted"
+)
+
+// Event é o payload gravado no outbox. Contact traz o estado após a
+// alteração e fica vazio em contact.deleted. CategoryID é a categoria do
+// contato após a alteração (em contact.deleted, a que ele tinha) e
+// PreviousCategoryID a anterior, apenas quando a atualização trocou a
+// categoria; assim quem acompanha uma categoria também vê o contato sair dela.
+type Event struct {
+	ID                 string    `json:"id"`
+	Type               string    `json:"type"`
+	TenantID           string    `json:"tenant_id"`
+	ContactID          string    `json:"contact_id"`
+	CategoryID         string    `json:"category_id,omitempty"`
+	PreviousCategoryID string    `json:"previous_category_id,omitempty"`
+	OccurredAt         time.Time `json:"occurred_at"`
+	Contact            *Contact  `json:"contact,omitempty"`
+}
+
+// recordEvent completa o ID e a data do evento e o grava no outbox usando q,
+// que deve ser a transação da alteração. Os eventos de um contato são
+// entregues em ordem pelo ID do contato.
+func recordEvent(ctx context.Context, q outbox.Executor, event Event) error {
+	event.ID = uuid.NewString()
+	event.OccurredAt = time.Now().UTC()
+	if event.PreviousCategoryID == event.CategoryID {
+		event.PreviousCategoryID = ""
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar evento %s: %v", event.Type, err)
+	}
+
+	return outbox.Enqueue(ctx, q, outbox.Message{
+		EventID:     event.ID,
+		Type:        event.Type,
+		TenantID:    event.TenantID,
+		AggregateID: event.ContactID,
+		Payload:     payload,
+		CreatedAt:   event.OccurredAt,
+	})
+}
@@ -0,0 +1,102 @@
+package contacts_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/dbtest"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/outbox"
+)
+
+func TestSQLiteRepositoryRecordsEvents(t *testing.T) {
+	db := dbtest.OpenSQLite(t)
+	testRecordsEvents(t, db, contacts.NewSQLiteRepositoryWithOptions(db, contacts.SQLiteOptions{Outbox: true}))
+}
+
+func TestPostgresRepositoryRecordsEvents(t *testing.T) {
+	db := openTestDatabase(t)
+	if _, err := db.Exec("TRUNCATE contacts, contact_tombstones, contact_change_counters, outbox_events"); err != nil {
+		t.Fatalf("erro ao limpar tabelas: %v", err)
+	}
+	testRecordsEvents(t, db, contacts.NewPostgresRepositoryWithOptions(db, contacts.PostgresOptions{Outbox: true}))
+}
+
+// testRecordsEvents confere que cada alteração grava um evento e que uma
+// alteração que falha não grava nenhum
+func testRecordsEvents(t *testing.T, db *sql.DB, repo contacts.Repository) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	const (
+		clients   = "0b8f7f5e-6f1a-4c1e-9a57-1d1f0f4c2a01"
+		suppliers = "0b8f7f5e-6f1a-4c1e-9a57-1d1f0f4c2a02"
+	)
+
+	contact := &contacts.Contact{TenantID: "acme", Name: "Ana", Email: "ana@example.com", CategoryID: clients, CreatedAt: now, UpdatedAt: now}
+	if err := repo.Create(ctx, contact); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	contact.Name = "Ana Souza"
+	contact.CategoryID = suppliers
+	if err := repo.Update(ctx, contact); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := repo.Delete(ctx, "acme", contact.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := repo.Delete(ctx, "acme", contact.ID); err != contacts.ErrNotFound {
+		t.Fatalf("Delete repetido = %v, esperado ErrNotFound", err)
+	}
+
+	store := outbox.NewStore(db)
+	want := []string{contacts.EventContactCreated, contacts.EventContactUpdated, contacts.EventContactDeleted}
+
+	// Categoria atual e anterior esperadas em cada evento
+	wantCategories := [][2]string{{clients, ""}, {suppliers, clients}, {suppliers, ""}}
+
+	// Cada entrega libera o próximo evento do mesmo contato
+	for i, eventType := range want {
+		messages, err := store.Claim(ctx, 10, time.Minute)
+		if err != nil {
+			t.Fatalf("Claim: %v", err)
+		}
+		if len(messages) != 1 {
+			t.Fatalf("evento %d: %d mensagens reservadas, esperado 1", i, len(messages))
+		}
+
+		message := messages[0]
+		if message.Type != eventType || message.AggregateID != contact.ID || message.TenantID != "acme" {
+			t.Fatalf("evento %d = %+v, esperado %s do contato %s", i, message, eventType, contact.ID)
+		}
+
+		var event contacts.Event
+		if err := json.Unmarshal(message.Payload, &event); err != nil {
+			t.Fatalf("payload inválido: %v", err)
+		}
+		if event.ID != message.EventID || event.ContactID != contact.ID {
+			t.Fatalf("payload = %+v", event)
+		}
+		if got := [2]string{event.CategoryID, event.PreviousCategoryID}; got != wantCategories[i] {
+			t.Fatalf("categorias de %s = %v, esperado %v", eventType, got, wantCategories[i])
+		}
+		if (event.Contact == nil) != (eventType == contacts.EventContactDeleted) {
+			t.Fatalf("payload de %s com contato = %v", eventType, event.Contact)
+		}
+		if eventType == contacts.EventContactUpdated && event.Contact.Name != "Ana Souza" {
+			t.Fatalf("payload de %s com nome %q", eventType, event.Contact.Name)
+		}
+
+		if err := store.MarkDelivered(ctx, message.ID); err != nil {
+			t.Fatalf("MarkDelivered: %v", err)
+		}
+	}
+
+	messages, err := store.Claim(ctx, 10, time.Minute)
+	if err != nil || len(messages) != 0 {
+		t.Fatalf("Claim após as entregas = %v, %v; esperado nenhuma mensagem", messages, err)
+	}
+}
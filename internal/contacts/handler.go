@@ -7,8 +7,14 @@
 package contacts
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/apierror"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
 	"github.com/gin-gonic/gin"
 )
 
@@ -36,11 +42,12 @@ func NewHandler(service Service) *Handler {
 	return &Handler{service: service}
 }
 
-func (h *Handler) RegisterRoutes(router *gin.Engine) {
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	contacts := router.Group("/contacts")
 	{
 		contacts.POST("", h.CreateContact)
 		contacts.GET("", h.GetAllContacts)
+		contacts.GET("/sync", h.SyncContacts)
 		contacts.GET("/:id", h.GetContactByID)
 		contacts.PUT("/:id", h.UpdateContact)
 		contacts.DELETE("/:id", h.DeleteContact)
@@ -52,21 +59,27 @@ func (h *Handler) RegisterRoutes(router *gin.Engine) {
 // @Tags        contacts
 // @Accept      json
 // @Produce     json
+// @Param       X-Tenant-ID header string false "Tenant do contato (padrão: default)"
+// @Param       Idempotency-Key header string false "Chave para repetir a requisição com segurança"
 // @Param       request body CreateContactRequest true "Dados do contato"
 // @Success     201 {object} Contact
 // @Failure     400 {object} ErrorResponse "Erro de validação dos dados"
+// @Failure     409 {object} ErrorResponse "Email já cadastrado no tenant ou requisição idempotente em andamento"
+// @Failure     422 {object} ErrorResponse "Idempotency-Key reutilizada com outro payload"
+// @Failure     429 {object} ErrorResponse "Limite de requisições excedido"
 // @Failure     500 {object} ErrorResponse "Erro interno do servidor"
+// @Failure     504 {object} ErrorResponse "Tempo limite da operação excedido"
 // @Router      /contacts [post]
 func (h *Handler) CreateContact(c *gin.Context) {
 	var req CreateContactRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	contact, err := h.service.CreateNewContact(req.Name, req.Email, req.Phone, req.CategoryID)
+	contact, err := h.service.CreateNewContact(c.Request.Context(), tenant.FromContext(c), req.Name, req.Email, req.Phone, req.CategoryID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -78,33 +91,73 @@ func (h *Handler) CreateContact(c *gin.Context) {
 // @Tags        contacts
 // @Accept      json
 // @Produce     json
+// @Param       X-Tenant-ID header string false "Tenant do contato (padrão: default)"
 // @Success     200 {array} Contact
+// @Failure     429 {object} ErrorResponse "Limite de requisições excedido"
 // @Failure     500 {object} ErrorResponse "Erro interno do servidor"
+// @Failure     504 {object} ErrorResponse "Tempo limite da operação excedido"
 // @Router      /contacts [get]
 func (h *Handler) GetAllContacts(c *gin.Context) {
-	contacts, err := h.service.GetAllContacts()
+	contacts, err := h.service.GetAllContacts(c.Request.Context(), tenant.FromContext(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, contacts)
 }
 
+// @Summary     Sincronizar contatos
+// @Description Retorna os contatos criados ou atualizados e os removidos desde o token informado, em ordem de alteração, e o token da próxima sincronização. Sem token, retorna todos os contatos. Com has_more, repita a chamada com o novo token. Um token expirado retorna 410 e exige nova sincronização sem token.
+// @Tags        contacts
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant-ID header string false "Tenant do contato (padrão: default)"
+// @Param       token query string false "Token retornado pela sincronização anterior"
+// @Param       limit query int false "Máximo de alterações por página (padrão: 500, máximo: 1000)"
+// @Success     200 {object} SyncResult
+// @Failure     400 {object} ErrorResponse "Token ou limite inválido"
+// @Failure     410 {object} ErrorResponse "Token expirado; sincronize novamente sem token"
+// @Failure     429 {object} ErrorResponse "Limite de requisições excedido"
+// @Failure     500 {object} ErrorResponse "Erro interno do servidor"
+// @Failure     504 {object} ErrorResponse "Tempo limite da operação excedido"
+// @Router      /contacts/sync [get]
+func (h *Handler) SyncContacts(c *gin.Context) {
+	limit := DefaultSyncLimit
+	if value := c.Query("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > MaxSyncLimit {
+			apierror.Respond(c, http.StatusBadRequest, fmt.Sprintf("limit deve estar entre 1 e %d", MaxSyncLimit))
+			return
+		}
+	}
+
+	result, err := h.service.SyncContacts(c.Request.Context(), tenant.FromContext(c), c.Query("token"), limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 // @Summary     Buscar contato por ID
 // @Description Retorna um contato específico com base no ID fornecido
 // @Tags        contacts
 // @Accept      json
 // @Produce     json
+// @Param       X-Tenant-ID header string false "Tenant do contato (padrão: default)"
 // @Param       id path string true "ID do contato"
 // @Success     200 {object} Contact
 // @Failure     404 {object} ErrorResponse "Contato não encontrado"
+// @Failure     429 {object} ErrorResponse "Limite de requisições excedido"
+// @Failure     504 {object} ErrorResponse "Tempo limite da operação excedido"
 // @Router      /contacts/{id} [get]
 func (h *Handler) GetContactByID(c *gin.Context) {
 	id := c.Param("id")
 
-	contact, err := h.service.GetContactByID(id)
+	contact, err := h.service.GetContactByID(c.Request.Context(), tenant.FromContext(c), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Contato não encontrado"})
+		respondError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, contact)
@@ -115,25 +168,31 @@ func (h *Handler) GetContactByID(c *gin.Context) {
 // @Tags        contacts
 // @Accept      json
 // @Produce     json
+// @Param       X-Tenant-ID header string false "Tenant do contato (padrão: default)"
+// @Param       Idempotency-Key header string false "Chave para repetir a requisição com segurança"
 // @Param       id path string true "ID do contato"
 // @Param       request body UpdateContactRequest true "Dados atualizados do contato"
 // @Success     200 {object} Contact
 // @Failure     400 {object} ErrorResponse "Erro de validação dos dados"
 // @Failure     404 {object} ErrorResponse "Contato não encontrado"
+// @Failure     409 {object} ErrorResponse "Email já cadastrado no tenant ou requisição idempotente em andamento"
+// @Failure     422 {object} ErrorResponse "Idempotency-Key reutilizada com outro payload"
+// @Failure     429 {object} ErrorResponse "Limite de requisições excedido"
 // @Failure     500 {object} ErrorResponse "Erro interno do servidor"
+// @Failure     504 {object} ErrorResponse "Tempo limite da operação excedido"
 // @Router      /contacts/{id} [put]
 func (h *Handler) UpdateContact(c *gin.Context) {
 	id := c.Param("id")
 
 	var req UpdateContactRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	contact, err := h.service.UpdateContact(id, req.Name, req.Email, req.Phone, req.CategoryID)
+	contact, err := h.service.UpdateContact(c.Request.Context(), tenant.FromContext(c), id, req.Name, req.Email, req.Phone, req.CategoryID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, contact)
@@ -144,24 +203,56 @@ func (h *Handler) UpdateContact(c *gin.Context) {
 // @Tags        contacts
 // @Accept      json
 // @Produce     json
+// @Param       X-Tenant-ID header string false "Tenant do contato (padrão: default)"
+// @Param       Idempotency-Key header string false "Chave para repetir a requisição com segurança"
 // @Param       id path string true "ID do contato"
 // @Success     204 "Contato removido com sucesso"
 // @Failure     404 {object} ErrorResponse "Contato não encontrado"
+// @Failure     409 {object} ErrorResponse "Requisição idempotente em andamento"
+// @Failure     422 {object} ErrorResponse "Idempotency-Key reutilizada com outro payload"
+// @Failure     429 {object} ErrorResponse "Limite de requisições excedido"
 // @Failure     500 {object} ErrorResponse "Erro interno do servidor"
+// @Failure     504 {object} ErrorResponse "Tempo limite da operação excedido"
 // @Router      /contacts/{id} [delete]
 func (h *Handler) DeleteContact(c *gin.Context) {
 	id := c.Param("id")
 
-	err := h.service.DeleteContact(id)
+	err := h.service.DeleteContact(c.Request.Context(), tenant.FromContext(c), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// respondError converte erros de domínio no status HTTP correspondente
+func respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		apierror.Respond(c, http.StatusNotFound, "Contato não encontrado")
+	case errors.Is(err, ErrEmailAlreadyExists):
+		apierror.Respond(c, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrInvalidSyncToken):
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrSyncTokenExpired):
+		apierror.Respond(c, http.StatusGone, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		apierror.Respond(c, http.StatusGatewayTimeout, "Tempo limite da operação excedido")
+	case errors.Is(err, context.Canceled):
+		// O cliente já desconectou; o status é registrado apenas nos logs e métricas
+		apierror.Respond(c, statusClientClosedRequest, "Requisição cancelada pelo cliente")
+	default:
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// statusClientClosedRequest é o status não padronizado usado por proxies como o
+// nginx quando o cliente encerra a conexão antes da resposta
+const statusClientClosedRequest = 499
+
 // ErrorResponse representa uma resposta de erro da API
 // @Description Estrutura padrão para respostas de erro
 type ErrorResponse struct {
-	Error string `json:"error" example:"Mensagem de erro"` // Mensagem de erro
+	Error     string `json:"error" example:"Mensagem de erro"`                                // Mensagem de erro
+	RequestID string `json:"request_id,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"` // ID da requisição (X-Request-ID)
 }
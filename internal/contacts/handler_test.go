@@ -0,0 +1,207 @@
+package contacts_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/middleware"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(repo contacts.Repository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.RequestIDMiddleware())
+	api := router.Group("", tenant.Middleware(tenant.DefaultID))
+	contacts.NewHandler(contacts.NewService(repo)).RegisterRoutes(api)
+	return router
+}
+
+func doRequest(t *testing.T, router http.Handler, method, path, tenantID string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatalf("erro ao codificar corpo: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	if tenantID != "" {
+		req.Header.Set(tenant.HeaderName, tenantID)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var value T
+	if err := json.Unmarshal(rec.Body.Bytes(), &value); err != nil {
+		t.Fatalf("erro ao decodificar resposta %q: %v", rec.Body.String(), err)
+	}
+	return value
+}
+
+func assertStatus(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status = %d, esperado %d (corpo: %s)", rec.Code, want, rec.Body.String())
+	}
+}
+
+func TestHandlerCRUD(t *testing.T) {
+	router := newTestRouter(contacts.NewMemoryRepository())
+
+	rec := doRequest(t, router, http.MethodPost, "/contacts", "acme", contacts.CreateContactRequest{
+		Name:  "João Silva",
+		Email: "joao@example.com",
+		Phone: "11999998888",
+	})
+	assertStatus(t, rec, http.StatusCreated)
+	created := decode[contacts.Contact](t, rec)
+	if created.ID == "" || created.TenantID != "acme" || created.Email != "joao@example.com" {
+		t.Fatalf("contato criado = %+v", created)
+	}
+
+	rec = doRequest(t, router, http.MethodGet, "/contacts/"+created.ID, "acme", nil)
+	assertStatus(t, rec, http.StatusOK)
+	if found := decode[contacts.Contact](t, rec); found.ID != created.ID {
+		t.Fatalf("contato retornado = %+v", found)
+	}
+
+	rec = doRequest(t, router, http.MethodGet, "/contacts", "acme", nil)
+	assertStatus(t, rec, http.StatusOK)
+	if list := decode[[]contacts.Contact](t, rec); len(list) != 1 {
+		t.Fatalf("listagem retornou %d contatos, esperado 1", len(list))
+	}
+
+	rec = doRequest(t, router, http.MethodPut, "/contacts/"+created.ID, "acme", contacts.UpdateContactRequest{
+		Name:  "João Souza",
+		Email: "souza@example.com",
+	})
+	assertStatus(t, rec, http.StatusOK)
+	if updated := decode[contacts.Contact](t, rec); updated.Name != "João Souza" || updated.Email != "souza@example.com" {
+		t.Fatalf("contato atualizado = %+v", updated)
+	}
+
+	rec = doRequest(t, router, http.MethodDelete, "/contacts/"+created.ID, "acme", nil)
+	assertStatus(t, rec, http.StatusNoContent)
+
+	rec = doRequest(t, router, http.MethodGet, "/contacts/"+created.ID, "acme", nil)
+	assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestHandlerErrors(t *testing.T) {
+	router := newTestRouter(contacts.NewMemoryRepository())
+
+	existing := contacts.CreateContactRequest{Name: "João Silva", Email: "joao@example.com"}
+	assertStatus(t, doRequest(t, router, http.MethodPost, "/contacts", "acme", existing), http.StatusCreated)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		tenant string
+		body   any
+		status int
+	}{
+		{"EmailInválido", http.MethodPost, "/contacts", "acme", contacts.CreateContactRequest{Name: "X", Email: "invalido"}, http.StatusBadRequest},
+		{"NomeAusente", http.MethodPost, "/contacts", "acme", contacts.CreateContactRequest{Email: "x@example.com"}, http.StatusBadRequest},
+		{"EmailDuplicado", http.MethodPost, "/contacts", "acme", existing, http.StatusConflict},
+		{"EmailEmOutroTenant", http.MethodPost, "/contacts", "globex", existing, http.StatusCreated},
+		{"BuscaInexistente", http.MethodGet, "/contacts/nao-existe", "acme", nil, http.StatusNotFound},
+		{"AtualizaçãoInexistente", http.MethodPut, "/contacts/nao-existe", "acme", contacts.UpdateContactRequest{Name: "X"}, http.StatusNotFound},
+		{"ExclusãoInexistente", http.MethodDelete, "/contacts/nao-existe", "acme", nil, http.StatusNotFound},
+		{"TenantInválido", http.MethodGet, "/contacts", "tenant inválido!", nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(t, router, tt.method, tt.path, tt.tenant, tt.body)
+			assertStatus(t, rec, tt.status)
+
+			if tt.status >= http.StatusBadRequest {
+				resp := decode[contacts.ErrorResponse](t, rec)
+				if resp.Error == "" {
+					t.Fatal("resposta de erro sem mensagem")
+				}
+				if resp.RequestID == "" || resp.RequestID != rec.Header().Get(middleware.RequestIDHeader) {
+					t.Fatalf("request_id = %q, cabeçalho = %q", resp.RequestID, rec.Header().Get(middleware.RequestIDHeader))
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerTenantIsolation(t *testing.T) {
+	router := newTestRouter(contacts.NewMemoryRepository())
+
+	rec := doRequest(t, router, http.MethodPost, "/contacts", "acme", contacts.CreateContactRequest{Name: "João", Email: "joao@example.com"})
+	assertStatus(t, rec, http.StatusCreated)
+	created := decode[contacts.Contact](t, rec)
+
+	assertStatus(t, doRequest(t, router, http.MethodGet, "/contacts/"+created.ID, "globex", nil), http.StatusNotFound)
+
+	// Sem cabeçalho, a requisição usa o tenant padrão
+	rec = doRequest(t, router, http.MethodGet, "/contacts", "", nil)
+	assertStatus(t, rec, http.StatusOK)
+	if list := decode[[]contacts.Contact](t, rec); len(list) != 0 {
+		t.Fatalf("tenant padrão enxergou %d contatos de outro tenant", len(list))
+	}
+}
+
+// timeoutRepository simula uma consulta que excede o prazo
+type timeoutRepository struct {
+	contacts.Repository
+}
+
+func (timeoutRepository) FindAll(ctx context.Context, tenantID string) ([]*contacts.Contact, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestHandlerQueryTimeout(t *testing.T) {
+	repo := contacts.NewTimeoutRepository(timeoutRepository{contacts.NewMemoryRepository()}, contacts.Timeouts{
+		Default: 10 * time.Millisecond,
+	})
+	router := newTestRouter(repo)
+
+	rec := doRequest(t, router, http.MethodGet, "/contacts", "acme", nil)
+	assertStatus(t, rec, http.StatusGatewayTimeout)
+}
+
+func TestTimeoutRepositoryWrapsDriverError(t *testing.T) {
+	repo := contacts.NewTimeoutRepository(driverCancelRepository{}, contacts.Timeouts{
+		Operations: map[string]time.Duration{"find_all": 10 * time.Millisecond},
+	})
+
+	_, err := repo.FindAll(context.Background(), "acme")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("erro = %v, esperado %v", err, context.DeadlineExceeded)
+	}
+}
+
+// driverCancelRepository devolve o erro do PostgreSQL em vez do erro do contexto
+type driverCancelRepository struct {
+	contacts.Repository
+}
+
+func (driverCancelRepository) FindAll(ctx context.Context, tenantID string) ([]*contacts.Contact, error) {
+	<-ctx.Done()
+	return nil, errors.New("pq: canceling statement due to user request")
+}
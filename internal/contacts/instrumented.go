@@ -0,0 +1,81 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/metrics"
+)
+
+const metricsTable = "contacts"
+
+// instrumentedRepository registra operação, tabela, duração e resultado de
+// cada chamada ao repositório decorado
+type instrumentedRepository struct {
+	next Repository
+}
+
+func NewInstrumentedRepository(next Repository) Repository {
+	return &instrumentedRepository{next: next}
+}
+
+func (r *instrumentedRepository) Create(ctx context.Context, contact *Contact) error {
+	start := time.Now()
+	err := r.next.Create(ctx, contact)
+	observe(ctx, "create", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) FindAll(ctx context.Context, tenantID string) ([]*Contact, error) {
+	start := time.Now()
+	contacts, err := r.next.FindAll(ctx, tenantID)
+	observe(ctx, "find_all", start, err)
+	return contacts, err
+}
+
+func (r *instrumentedRepository) FindByID(ctx context.Context, tenantID, id string) (*Contact, error) {
+	start := time.Now()
+	contact, err := r.next.FindByID(ctx, tenantID, id)
+	observe(ctx, "find_by_id", start, err)
+	return contact, err
+}
+
+func (r *instrumentedRepository) FindChanges(ctx context.Context, tenantID string, after int64, limit int) (*ChangeSet, error) {
+	start := time.Now()
+	changes, err := r.next.FindChanges(ctx, tenantID, after, limit)
+	observe(ctx, "find_changes", start, err)
+	return changes, err
+}
+
+func (r *instrumentedRepository) Update(ctx context.Context, contact *Contact) error {
+	start := time.Now()
+	err := r.next.Update(ctx, contact)
+	observe(ctx, "update", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) Delete(ctx context.Context, tenantID, id string) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, tenantID, id)
+	observe(ctx, "delete", start, err)
+	return err
+}
+
+// observe registra a duração até o retorno da operação, que em caso de
+// cancelamento corresponde ao tempo gasto antes do cancelamento
+func observe(ctx context.Context, operation string, start time.Time, err error) {
+	outcome := metrics.OutcomeOK
+	switch {
+	case errors.Is(err, ErrNotFound):
+		outcome = metrics.OutcomeNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		outcome = metrics.OutcomeTimeout
+	case errors.Is(err, context.Canceled):
+		outcome = metrics.OutcomeCanceled
+	case err != nil:
+		outcome = metrics.OutcomeError
+	}
+
+	metrics.ObserveDatabaseOperation(ctx, operation, metricsTable, outcome, time.Since(start))
+}
@@ -0,0 +1,197 @@
+package contacts
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryRepository guarda os contatos em memória com as mesmas regras do
+// PostgresRepository: isolamento por tenant, email único por tenant e
+// ErrNotFound para contatos inexistentes. Indicado para testes e
+// desenvolvimento local; os dados se perdem ao reiniciar.
+type MemoryRepository struct {
+	mu       sync.RWMutex
+	contacts map[string]*Contact
+
+	// seqs guarda a posição da última alteração de cada contato, lastSeq a
+	// última posição de cada tenant e tombstones as remoções, sem expurgo
+	seqs       map[string]int64
+	lastSeq    map[string]int64
+	tombstones map[string][]change
+}
+
+func NewMemoryRepository() Repository {
+	return &MemoryRepository{
+		contacts:   make(map[string]*Contact),
+		seqs:       make(map[string]int64),
+		lastSeq:    make(map[string]int64),
+		tombstones: make(map[string][]change),
+	}
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, contact *Contact) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.emailTaken(contact.TenantID, contact.Email, "") {
+		return ErrEmailAlreadyExists
+	}
+
+	stored := *contact
+	stored.ID = uuid.NewString()
+	r.contacts[stored.ID] = &stored
+	r.seqs[stored.ID] = r.nextSeq(stored.TenantID)
+
+	contact.ID = stored.ID
+	return nil
+}
+
+func (r *MemoryRepository) FindAll(ctx context.Context, tenantID string) ([]*Contact, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	contacts := []*Contact{}
+	for _, stored := range r.contacts {
+		if stored.TenantID == tenantID {
+			contact := *stored
+			contacts = append(contacts, &contact)
+		}
+	}
+
+	// O PostgreSQL não garante ordem; aqui a ordem de criação torna a listagem estável
+	sort.Slice(contacts, func(i, j int) bool {
+		if contacts[i].CreatedAt.Equal(contacts[j].CreatedAt) {
+			return contacts[i].ID < contacts[j].ID
+		}
+		return contacts[i].CreatedAt.Before(contacts[j].CreatedAt)
+	})
+
+	return contacts, nil
+}
+
+func (r *MemoryRepository) FindByID(ctx context.Context, tenantID, id string) (*Contact, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.contacts[id]
+	if !ok || stored.TenantID != tenantID {
+		return nil, ErrNotFound
+	}
+
+	contact := *stored
+	return &contact, nil
+}
+
+func (r *MemoryRepository) Update(ctx context.Context, contact *Contact) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.contacts[contact.ID]
+	if !ok || stored.TenantID != contact.TenantID {
+		return ErrNotFound
+	}
+
+	if r.emailTaken(contact.TenantID, contact.Email, contact.ID) {
+		return ErrEmailAlreadyExists
+	}
+
+	stored.Name = contact.Name
+	stored.Email = contact.Email
+	stored.Phone = contact.Phone
+	stored.CategoryID = contact.CategoryID
+	stored.UpdatedAt = contact.UpdatedAt
+	r.seqs[stored.ID] = r.nextSeq(stored.TenantID)
+	return nil
+}
+
+func (r *MemoryRepository) Delete(ctx context.Context, tenantID, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.contacts[id]
+	if !ok || stored.TenantID != tenantID {
+		return ErrNotFound
+	}
+
+	delete(r.contacts, id)
+	delete(r.seqs, id)
+	r.tombstones[tenantID] = append(r.tombstones[tenantID], change{
+		seq:     r.nextSeq(tenantID),
+		deleted: &DeletedContact{ID: id, DeletedAt: time.Now().UTC()},
+	})
+	return nil
+}
+
+func (r *MemoryRepository) FindChanges(ctx context.Context, tenantID string, after int64, limit int) (*ChangeSet, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	upper := r.lastSeq[tenantID]
+	if after > upper {
+		return nil, ErrSyncTokenExpired
+	}
+
+	var changed []change
+	for id, stored := range r.contacts {
+		if seq := r.seqs[id]; stored.TenantID == tenantID && seq > after {
+			contact := *stored
+			changed = append(changed, change{seq: seq, contact: &contact})
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].seq < changed[j].seq })
+
+	var deleted []change
+	if after >= 0 {
+		for _, tombstone := range r.tombstones[tenantID] {
+			if tombstone.seq > after {
+				deleted = append(deleted, tombstone)
+			}
+		}
+	}
+
+	return mergeChanges(changed, deleted, max(after, upper), limit), nil
+}
+
+// nextSeq avança a sequência de alterações do tenant; exige r.mu travado
+func (r *MemoryRepository) nextSeq(tenantID string) int64 {
+	r.lastSeq[tenantID]++
+	return r.lastSeq[tenantID]
+}
+
+// emailTaken verifica se outro contato do tenant, diferente de exceptID, já usa o email
+func (r *MemoryRepository) emailTaken(tenantID, email, exceptID string) bool {
+	for id, stored := range r.contacts {
+		if id != exceptID && stored.TenantID == tenantID && stored.Email == email {
+			return true
+		}
+	}
+	return false
+}
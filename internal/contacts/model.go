@@ -7,6 +7,7 @@ import (
 // @Description Informações de um contato
 type Contact struct {
 	ID         string    `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`          // ID único do contato
+	TenantID   string    `json:"tenant_id" example:"acme"`                                   // Tenant dono do contato
 	Name       string    `json:"name" example:"João Silva"`                                  // Nome do contato
 	Email      string    `json:"email" example:"joao@example.com"`                           // Email do contato
 	Phone      string    `json:"phone" example:"11999998888"`                                // Telefone do contato
@@ -14,3 +15,17 @@ type Contact struct {
 	CreatedAt  time.Time `json:"created_at" example:"2023-01-01T12:00:00Z"`                  // Data de criação
 	UpdatedAt  time.Time `json:"updated_at" example:"2023-01-01T12:00:00Z"`                  // Data de atualização
 }
+
+// @Description Contato removido desde a última sincronização
+type DeletedContact struct {
+	ID        string    `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"` // ID do contato removido
+	DeletedAt time.Time `json:"deleted_at" example:"2023-01-01T12:00:00Z"`         // Data da remoção
+}
+
+// @Description Alterações dos contatos desde o token informado
+type SyncResult struct {
+	Contacts []*Contact        `json:"contacts"`                       // Contatos criados ou atualizados, no estado atual
+	Deleted  []*DeletedContact `json:"deleted"`                        // Contatos removidos
+	Token    string            `json:"token" example:"ZGVmYXVsdDo0Mg"` // Token para a próxima sincronização
+	HasMore  bool              `json:"has_more" example:"false"`       // Há mais alterações; repita a chamada com o novo token
+}
@@ -1,110 +1,318 @@
 package contacts
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
 
 type Repository interface {
-	Create(contact *Contact) error
-	FindAll() ([]*Contact, error)
-	FindByID(id string) (*Contact, error)
-	Update(contact *Contact) error
-	Delete(id string) error
+	Create(ctx context.Context, contact *Contact) error
+	FindAll(ctx context.Context, tenantID string) ([]*Contact, error)
+	FindByID(ctx context.Context, tenantID, id string) (*Contact, error)
+	Update(ctx context.Context, contact *Contact) error
+	Delete(ctx context.Context, tenantID, id string) error
+
+	// FindChanges retorna até limit alterações do tenant após a posição after
+	// da sequência de alterações; after negativo retorna todos os contatos.
+	// Retorna ErrSyncTokenExpired quando remoções posteriores a after já
+	// foram expurgadas.
+	FindChanges(ctx context.Context, tenantID string, after int64, limit int) (*ChangeSet, error)
 }
 
+// querier abstrai *sql.DB e *sql.Tx para que as consultas possam rodar
+// dentro ou fora de uma transação
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// ReaderFunc escolhe o pool usado pelas listagens, que toleram o atraso de
+// replicação (ex.: db.Cluster.Reader)
+type ReaderFunc func(ctx context.Context) *sql.DB
+
 type PostgresRepository struct {
-	db *sql.DB
+	db         *sql.DB
+	reader     ReaderFunc
+	enforceRLS bool
+	outbox     bool
+}
+
+type PostgresOptions struct {
+	// EnforceRLS roda cada operação numa transação que define app.tenant_id
+	// para a política de row-level security
+	EnforceRLS bool
+
+	// Reader escolhe o pool de FindAll e FindChanges; nil usa o primário.
+	// FindByID e as escritas sempre usam o primário.
+	Reader ReaderFunc
+
+	// Outbox grava um evento na tabela outbox_events, na mesma transação, a
+	// cada criação, atualização ou remoção
+	Outbox bool
+}
+
+// NewPostgresRepository cria o repositório de contatos no PostgreSQL. Todas as
+// consultas são filtradas por tenant; com enforceRLS cada operação também roda
+// numa transação que define app.tenant_id para a política de row-level security.
+func NewPostgresRepository(db *sql.DB, enforceRLS bool) Repository {
+	return NewPostgresRepositoryWithOptions(db, PostgresOptions{EnforceRLS: enforceRLS})
+}
+
+// NewPostgresRepositoryWithOptions cria o repositório em que as escritas e as
+// buscas por ID usam primary e as listagens usam o pool escolhido por opts.Reader
+func NewPostgresRepositoryWithOptions(primary *sql.DB, opts PostgresOptions) Repository {
+	reader := opts.Reader
+	if reader == nil {
+		reader = func(context.Context) *sql.DB { return primary }
+	}
+	return &PostgresRepository{db: primary, reader: reader, enforceRLS: opts.EnforceRLS, outbox: opts.Outbox}
+}
+
+func (r *PostgresRepository) withTenant(ctx context.Context, db *sql.DB, tenantID string, fn func(q querier) error) error {
+	if !r.enforceRLS {
+		return fn(db)
+	}
+	return r.inTransaction(ctx, db, tenantID, fn)
+}
+
+// withMutation roda a alteração numa transação que também reserva a posição
+// na sequência de alterações do tenant e, com o outbox, grava o evento
+func (r *PostgresRepository) withMutation(ctx context.Context, tenantID string, fn func(q querier, seq int64) error) error {
+	return r.inTransaction(ctx, r.db, tenantID, func(q querier) error {
+		seq, err := nextChangeSeq(ctx, q, tenantID)
+		if err != nil {
+			return err
+		}
+		return fn(q, seq)
+	})
 }
 
-func NewPostgresRepository(db *sql.DB) Repository {
-	return &PostgresRepository{db: db}
+func (r *PostgresRepository) inTransaction(ctx context.Context, db *sql.DB, tenantID string, fn func(q querier) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if r.enforceRLS {
+		if _, err := tx.ExecContext(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (r *PostgresRepository) Create(contact *Contact) error {
+func (r *PostgresRepository) Create(ctx context.Context, contact *Contact) error {
 	query := `
-		INSERT INTO contacts (name, email, phone, category_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO contacts (tenant_id, name, email, phone, category_id, created_at, updated_at, change_seq)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id
 	`
 
 	var id string
-	err := r.db.QueryRow(query, contact.Name, contact.Email, contact.Phone, contact.CategoryID, contact.CreatedAt, contact.UpdatedAt).Scan(&id)
+	err := r.withMutation(ctx, contact.TenantID, func(q querier, seq int64) error {
+		if err := q.QueryRowContext(ctx, query, contact.TenantID, contact.Name, contact.Email, contact.Phone, contact.CategoryID, contact.CreatedAt, contact.UpdatedAt, seq).Scan(&id); err != nil {
+			return err
+		}
+		if !r.outbox {
+			return nil
+		}
+
+		created := *contact
+		created.ID = id
+		return recordEvent(ctx, q, Event{Type: EventContactCreated, TenantID: contact.TenantID, ContactID: id, CategoryID: contact.CategoryID, Contact: &created})
+	})
 	if err != nil {
-		return err
+		return translateError(err)
 	}
 
 	contact.ID = id
 	return nil
 }
 
-func (r *PostgresRepository) FindAll() ([]*Contact, error) {
+func (r *PostgresRepository) FindAll(ctx context.Context, tenantID string) ([]*Contact, error) {
 	query := `
-		SELECT id, name, email, phone, category_id, created_at, updated_at
+		SELECT id, tenant_id, name, email, phone, category_id, created_at, updated_at
 		FROM contacts
+		WHERE tenant_id = $1
 	`
 
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
+	contacts := []*Contact{}
 
-	defer rows.Close()
+	err := r.withTenant(ctx, r.reader(ctx), tenantID, func(q querier) error {
+		rows, err := q.QueryContext(ctx, query, tenantID)
+		if err != nil {
+			return err
+		}
 
-	contacts := []*Contact{}
+		defer rows.Close()
 
-	for rows.Next() {
-		contact := &Contact{}
-		if err := rows.Scan(&contact.ID, &contact.Name, &contact.Email, &contact.Phone, &contact.CategoryID, &contact.CreatedAt, &contact.UpdatedAt); err != nil {
-			return nil, err
+		for rows.Next() {
+			contact := &Contact{}
+			if err := rows.Scan(&contact.ID, &contact.TenantID, &contact.Name, &contact.Email, &contact.Phone, &contact.CategoryID, &contact.CreatedAt, &contact.UpdatedAt); err != nil {
+				return err
+			}
+			contacts = append(contacts, contact)
 		}
-		contacts = append(contacts, contact)
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return contacts, nil
 }
 
-func (r *PostgresRepository) FindByID(id string) (*Contact, error) {
+func (r *PostgresRepository) FindByID(ctx context.Context, tenantID, id string) (*Contact, error) {
 	query := `
-		SELECT id, name, email, phone, category_id, created_at, updated_at
+		SELECT id, tenant_id, name, email, phone, category_id, created_at, updated_at
 		FROM contacts
-		WHERE id = $1
+		WHERE tenant_id = $1 AND id = $2
 	`
 
-	row := r.db.QueryRow(query, id)
-
 	contact := &Contact{}
 
-	if err := row.Scan(&contact.ID, &contact.Name, &contact.Email, &contact.Phone, &contact.CategoryID, &contact.CreatedAt, &contact.UpdatedAt); err != nil {
-		return nil, err
+	err := r.withTenant(ctx, r.db, tenantID, func(q querier) error {
+		row := q.QueryRowContext(ctx, query, tenantID, id)
+		return row.Scan(&contact.ID, &contact.TenantID, &contact.Name, &contact.Email, &contact.Phone, &contact.CategoryID, &contact.CreatedAt, &contact.UpdatedAt)
+	})
+	if err != nil {
+		return nil, translateLookupError(err)
 	}
 
 	return contact, nil
 }
 
-func (r *PostgresRepository) Update(contact *Contact) error {
+func (r *PostgresRepository) Update(ctx context.Context, contact *Contact) error {
 
 	query := `
 		UPDATE contacts
-		SET name = $1, email = $2, phone = $3, category_id = $4, updated_at = $5
-		WHERE id = $6
+		SET name = $1, email = $2, phone = $3, category_id = $4, updated_at = $5, change_seq = $6
+		WHERE tenant_id = $7 AND id = $8
 	`
 
-	_, err := r.db.Exec(query, contact.Name, contact.Email, contact.Phone, contact.CategoryID, contact.UpdatedAt, contact.ID)
+	// A categoria anterior é lida na mesma transação para compor o evento
+	previousQuery := `SELECT category_id FROM contacts WHERE tenant_id = $1 AND id = $2 FOR UPDATE`
+
+	err := r.withMutation(ctx, contact.TenantID, func(q querier, seq int64) error {
+		var previousCategoryID string
+		if r.outbox {
+			if err := q.QueryRowContext(ctx, previousQuery, contact.TenantID, contact.ID).Scan(&previousCategoryID); err != nil {
+				return err
+			}
+		}
+
+		result, err := q.ExecContext(ctx, query, contact.Name, contact.Email, contact.Phone, contact.CategoryID, contact.UpdatedAt, seq, contact.TenantID, contact.ID)
+		if err != nil {
+			return err
+		}
+		if err := requireAffected(result); err != nil || !r.outbox {
+			return err
+		}
+
+		updated := *contact
+		return recordEvent(ctx, q, Event{
+			Type:               EventContactUpdated,
+			TenantID:           contact.TenantID,
+			ContactID:          contact.ID,
+			CategoryID:         contact.CategoryID,
+			PreviousCategoryID: previousCategoryID,
+			Contact:            &updated,
+		})
+	})
 	if err != nil {
-		return err
+		return translateError(err)
 	}
 
 	return nil
 }
 
-func (r *PostgresRepository) Delete(id string) error {
+func (r *PostgresRepository) Delete(ctx context.Context, tenantID, id string) error {
 	query := `
 		DELETE FROM contacts
-		WHERE id = $1
+		WHERE tenant_id = $1 AND id = $2
+		RETURNING category_id
 	`
 
-	_, err := r.db.Exec(query, id)
+	err := r.withMutation(ctx, tenantID, func(q querier, seq int64) error {
+		var categoryID string
+		if err := q.QueryRowContext(ctx, query, tenantID, id).Scan(&categoryID); err != nil {
+			return err
+		}
+		if err := recordTombstone(ctx, q, tenantID, id, seq); err != nil || !r.outbox {
+			return err
+		}
+
+		return recordEvent(ctx, q, Event{Type: EventContactDeleted, TenantID: tenantID, ContactID: id, CategoryID: categoryID})
+	})
 	if err != nil {
+		return translateLookupError(err)
+	}
+
+	return nil
+}
+
+// FindChanges lê as alterações do pool das listagens, cuja réplica pode estar
+// atrasada mas é consistente: o contador e os contatos chegam juntos
+func (r *PostgresRepository) FindChanges(ctx context.Context, tenantID string, after int64, limit int) (*ChangeSet, error) {
+	var changes *ChangeSet
+	err := r.withTenant(ctx, r.reader(ctx), tenantID, func(q querier) error {
+		var err error
+		changes, err = findChanges(ctx, q, tenantID, after, limit)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	return changes, nil
+}
+
+// requireAffected retorna ErrNotFound quando a instrução não alterou nenhuma linha
+func requireAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
 	return nil
 }
+
+// translateError converte erros do driver nos erros de domínio do pacote
+func translateError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		if pqErr.Code == "23505" { // unique_violation
+			return ErrEmailAlreadyExists
+		}
+	}
+
+	return err
+}
+
+// translateLookupError trata também IDs que não são UUIDs válidos como não encontrados
+func translateLookupError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "22P02" { // invalid_text_representation
+		return ErrNotFound
+	}
+
+	return translateError(err)
+}
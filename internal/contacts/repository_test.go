@@ -0,0 +1,190 @@
+package contacts_test
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/contacts/contactstest"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/dbtest"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/migrations"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+// testDatabaseEnv aponta para um PostgreSQL descartável usado pelos testes do
+// PostgresRepository, que são ignorados quando a variável não está definida
+const testDatabaseEnv = "TEST_DATABASE_URL"
+
+func TestMemoryRepository(t *testing.T) {
+	contactstest.RunRepositoryTests(t, func(t *testing.T) contacts.Repository {
+		return contacts.NewMemoryRepository()
+	})
+}
+
+// rlsTestRole é o papel sem privilégio de dono usado nos testes com RLS; o
+// dono da tabela ignora a política
+const rlsTestRole = "contacts_rls_test"
+
+func TestPostgresRepository(t *testing.T) {
+	db := openTestDatabase(t)
+	rlsDB := openRLSDatabase(t, db)
+
+	for _, enforceRLS := range []bool{false, true} {
+		name := "WithoutRLS"
+		repoDB := db
+		if enforceRLS {
+			name = "WithRLS"
+			repoDB = rlsDB
+		}
+
+		t.Run(name, func(t *testing.T) {
+			contactstest.RunRepositoryTests(t, func(t *testing.T) contacts.Repository {
+				truncateContacts(t, db)
+				return contacts.NewPostgresRepository(repoDB, enforceRLS)
+			})
+		})
+	}
+}
+
+func TestPostgresRowLevelSecurity(t *testing.T) {
+	db := openTestDatabase(t)
+	rlsDB := openRLSDatabase(t, db)
+	truncateContacts(t, db)
+
+	ctx := context.Background()
+	repo := contacts.NewPostgresRepository(rlsDB, true)
+	for _, tenantID := range []string{"acme", "acme", "globex"} {
+		if err := repo.Create(ctx, contactstest.NewContact(tenantID, uuid.NewString()+"@example.com")); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		tenantID string
+		want     int
+	}{
+		{name: "tenant vê apenas as próprias linhas", tenantID: "acme", want: 2},
+		{name: "outro tenant", tenantID: "globex", want: 1},
+		{name: "sem app.tenant_id nenhuma linha é visível", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := rlsDB.BeginTx(ctx, nil)
+			if err != nil {
+				t.Fatalf("erro ao iniciar transação: %v", err)
+			}
+			defer tx.Rollback()
+
+			if tt.tenantID != "" {
+				if _, err := tx.ExecContext(ctx, "SELECT set_config('app.tenant_id', $1, true)", tt.tenantID); err != nil {
+					t.Fatalf("erro ao definir tenant: %v", err)
+				}
+			}
+
+			// Consulta sem filtro por tenant_id: só a política isola os tenants
+			var count int
+			if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM contacts").Scan(&count); err != nil {
+				t.Fatalf("erro ao contar contatos: %v", err)
+			}
+			if count != tt.want {
+				t.Fatalf("%d contatos visíveis, esperado %d", count, tt.want)
+			}
+
+			if _, err := tx.ExecContext(ctx, "UPDATE contacts SET tenant_id = 'globex'"); tt.tenantID == "acme" && err == nil {
+				t.Fatal("política permitiu mover contatos para outro tenant")
+			}
+		})
+	}
+}
+
+func truncateContacts(t *testing.T, db *sql.DB) {
+	t.Helper()
+	if _, err := db.Exec("TRUNCATE contacts, contact_tombstones, contact_change_counters"); err != nil {
+		t.Fatalf("erro ao limpar contatos: %v", err)
+	}
+}
+
+func TestSQLiteRepository(t *testing.T) {
+	for _, outbox := range []bool{false, true} {
+		name := "WithoutOutbox"
+		if outbox {
+			name = "WithOutbox"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			contactstest.RunRepositoryTests(t, func(t *testing.T) contacts.Repository {
+				return contacts.NewSQLiteRepositoryWithOptions(dbtest.OpenSQLite(t), contacts.SQLiteOptions{Outbox: outbox})
+			})
+		})
+	}
+}
+
+func openTestDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	url := os.Getenv(testDatabaseEnv)
+	if url == "" {
+		t.Skipf("%s não definida", testDatabaseEnv)
+	}
+
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		t.Fatalf("erro ao abrir banco de testes: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migrations.New(db, migrations.Options{})
+	if err != nil {
+		t.Fatalf("erro ao carregar migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("erro ao aplicar migrations: %v", err)
+	}
+
+	return db
+}
+
+// openRLSDatabase conecta ao banco de testes com rlsTestRole, sujeito à
+// política de row-level security como a API executada sem privilégio de dono
+func openRLSDatabase(t *testing.T, owner *sql.DB) *sql.DB {
+	t.Helper()
+
+	setup := []string{
+		`DO $$ BEGIN CREATE ROLE ` + rlsTestRole + ` NOLOGIN; EXCEPTION WHEN duplicate_object THEN NULL; END $$`,
+		`GRANT ` + rlsTestRole + ` TO CURRENT_USER`,
+		`GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO ` + rlsTestRole,
+		`GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO ` + rlsTestRole,
+	}
+	for _, statement := range setup {
+		if _, err := owner.Exec(statement); err != nil {
+			t.Fatalf("erro ao preparar papel %s: %v", rlsTestRole, err)
+		}
+	}
+
+	parsed, err := url.Parse(os.Getenv(testDatabaseEnv))
+	if err != nil {
+		t.Fatalf("%s deve ser uma URL postgres://: %v", testDatabaseEnv, err)
+	}
+	query := parsed.Query()
+	query.Set("options", "-c role="+rlsTestRole)
+	parsed.RawQuery = query.Encode()
+
+	db, err := sql.Open("postgres", parsed.String())
+	if err != nil {
+		t.Fatalf("erro ao abrir banco de testes: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	var role string
+	if err := db.QueryRow("SELECT current_user").Scan(&role); err != nil || role != rlsTestRole {
+		t.Fatalf("conexão com o papel %s: %q, %v", rlsTestRole, role, err)
+	}
+
+	return db
+}
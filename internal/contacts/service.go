@@ -1,15 +1,20 @@
 package contacts
 
 import (
+	"context"
 	"time"
 )
 
 type Service interface {
-	CreateNewContact(name, email, phone, categoryID string) (*Contact, error)
-	GetAllContacts() ([]*Contact, error)
-	GetContactByID(id string) (*Contact, error)
-	UpdateContact(id, name, email, phone, categoryID string) (*Contact, error)
-	DeleteContact(id string) error
+	CreateNewContact(ctx context.Context, tenantID, name, email, phone, categoryID string) (*Contact, error)
+	GetAllContacts(ctx context.Context, tenantID string) ([]*Contact, error)
+	GetContactByID(ctx context.Context, tenantID, id string) (*Contact, error)
+	UpdateContact(ctx context.Context, tenantID, id, name, email, phone, categoryID string) (*Contact, error)
+	DeleteContact(ctx context.Context, tenantID, id string) error
+
+	// SyncContacts retorna as alterações desde token; sem token, retorna
+	// todos os contatos. limit fora do intervalo aceito usa o padrão.
+	SyncContacts(ctx context.Context, tenantID, token string, limit int) (*SyncResult, error)
 }
 
 type service struct {
@@ -20,10 +25,11 @@ func NewService(repo Repository) Service {
 	return &service{repo: repo}
 }
 
-func (s *service) CreateNewContact(name, email, phone, categoryID string) (*Contact, error) {
+func (s *service) CreateNewContact(ctx context.Context, tenantID, name, email, phone, categoryID string) (*Contact, error) {
 	now := time.Now()
 
 	contact := &Contact{
+		TenantID:   tenantID,
 		Name:       name,
 		Email:      email,
 		Phone:      phone,
@@ -32,23 +38,23 @@ func (s *service) CreateNewContact(name, email, phone, categoryID string) (*Cont
 		UpdatedAt:  now,
 	}
 
-	if err := s.repo.Create(contact); err != nil {
+	if err := s.repo.Create(ctx, contact); err != nil {
 		return nil, err
 	}
 
 	return contact, nil
 }
 
-func (s *service) GetAllContacts() ([]*Contact, error) {
-	return s.repo.FindAll()
+func (s *service) GetAllContacts(ctx context.Context, tenantID string) ([]*Contact, error) {
+	return s.repo.FindAll(ctx, tenantID)
 }
 
-func (s *service) GetContactByID(id string) (*Contact, error) {
-	return s.repo.FindByID(id)
+func (s *service) GetContactByID(ctx context.Context, tenantID, id string) (*Contact, error) {
+	return s.repo.FindByID(ctx, tenantID, id)
 }
 
-func (s *service) UpdateContact(id, name, email, phone, categoryID string) (*Contact, error) {
-	contact, err := s.GetContactByID(id)
+func (s *service) UpdateContact(ctx context.Context, tenantID, id, name, email, phone, categoryID string) (*Contact, error) {
+	contact, err := s.GetContactByID(ctx, tenantID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -59,13 +65,39 @@ func (s *service) UpdateContact(id, name, email, phone, categoryID string) (*Con
 	contact.CategoryID = categoryID
 	contact.UpdatedAt = time.Now()
 
-	if err := s.repo.Update(contact); err != nil {
+	if err := s.repo.Update(ctx, contact); err != nil {
 		return nil, err
 	}
 
 	return contact, nil
 }
 
-func (s *service) DeleteContact(id string) error {
-	return s.repo.Delete(id)
+func (s *service) DeleteContact(ctx context.Context, tenantID, id string) error {
+	return s.repo.Delete(ctx, tenantID, id)
+}
+
+func (s *service) SyncContacts(ctx context.Context, tenantID, token string, limit int) (*SyncResult, error) {
+	after := int64(-1)
+	if token != "" {
+		var err error
+		if after, err = decodeSyncToken(tenantID, token); err != nil {
+			return nil, err
+		}
+	}
+
+	if limit <= 0 || limit > MaxSyncLimit {
+		limit = DefaultSyncLimit
+	}
+
+	changes, err := s.repo.FindChanges(ctx, tenantID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SyncResult{
+		Contacts: changes.Contacts,
+		Deleted:  changes.Deleted,
+		Token:    encodeSyncToken(tenantID, changes.Seq),
+		HasMore:  changes.HasMore,
+	}, nil
 }
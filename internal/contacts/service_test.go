@@ -0,0 +1,91 @@
+package contacts_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+)
+
+func TestServiceCreateNewContact(t *testing.T) {
+	service := contacts.NewService(contacts.NewMemoryRepository())
+
+	contact, err := service.CreateNewContact(context.Background(), "acme", "João Silva", "joao@example.com", "11999998888", "")
+	if err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+
+	if contact.ID == "" || contact.TenantID != "acme" || contact.Name != "João Silva" {
+		t.Fatalf("contato criado = %+v", contact)
+	}
+	if contact.CreatedAt.IsZero() || !contact.CreatedAt.Equal(contact.UpdatedAt) {
+		t.Fatalf("datas = %v/%v, esperado iguais e preenchidas", contact.CreatedAt, contact.UpdatedAt)
+	}
+
+	_, err = service.CreateNewContact(context.Background(), "acme", "Outro", "joao@example.com", "", "")
+	if !errors.Is(err, contacts.ErrEmailAlreadyExists) {
+		t.Fatalf("erro = %v, esperado %v", err, contacts.ErrEmailAlreadyExists)
+	}
+}
+
+func TestServiceUpdateContact(t *testing.T) {
+	ctx := context.Background()
+	service := contacts.NewService(contacts.NewMemoryRepository())
+
+	created, err := service.CreateNewContact(ctx, "acme", "João Silva", "joao@example.com", "11999998888", "")
+	if err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+
+	updated, err := service.UpdateContact(ctx, "acme", created.ID, "João Souza", "souza@example.com", "11888887777", "")
+	if err != nil {
+		t.Fatalf("UpdateContact: %v", err)
+	}
+
+	if updated.Name != "João Souza" || updated.Email != "souza@example.com" || updated.Phone != "11888887777" {
+		t.Fatalf("contato atualizado = %+v", updated)
+	}
+	if !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Fatalf("created_at alterado de %v para %v", created.CreatedAt, updated.CreatedAt)
+	}
+	if updated.UpdatedAt.Before(created.UpdatedAt) {
+		t.Fatalf("updated_at %v anterior ao original %v", updated.UpdatedAt, created.UpdatedAt)
+	}
+
+	found, err := service.GetContactByID(ctx, "acme", created.ID)
+	if err != nil {
+		t.Fatalf("GetContactByID: %v", err)
+	}
+	if found.Email != "souza@example.com" {
+		t.Fatalf("email persistido = %s", found.Email)
+	}
+}
+
+func TestServiceTenantIsolation(t *testing.T) {
+	ctx := context.Background()
+	service := contacts.NewService(contacts.NewMemoryRepository())
+
+	created, err := service.CreateNewContact(ctx, "acme", "João Silva", "joao@example.com", "", "")
+	if err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+
+	if _, err := service.GetContactByID(ctx, "globex", created.ID); !errors.Is(err, contacts.ErrNotFound) {
+		t.Fatalf("GetContactByID de outro tenant: erro = %v, esperado %v", err, contacts.ErrNotFound)
+	}
+	if _, err := service.UpdateContact(ctx, "globex", created.ID, "x", "x@example.com", "", ""); !errors.Is(err, contacts.ErrNotFound) {
+		t.Fatalf("UpdateContact de outro tenant: erro = %v, esperado %v", err, contacts.ErrNotFound)
+	}
+	if err := service.DeleteContact(ctx, "globex", created.ID); !errors.Is(err, contacts.ErrNotFound) {
+		t.Fatalf("DeleteContact de outro tenant: erro = %v, esperado %v", err, contacts.ErrNotFound)
+	}
+
+	all, err := service.GetAllContacts(ctx, "globex")
+	if err != nil {
+		t.Fatalf("GetAllContacts: %v", err)
+	}
+	if len(all) != 0 {
+		t.Fatalf("GetAllContacts de outro tenant retornou %d contatos", len(all))
+	}
+}
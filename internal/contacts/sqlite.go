@@ -0,0 +1,220 @@
+package contacts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type SQLiteRepository struct {
+	db     *sql.DB
+	outbox bool
+}
+
+type SQLiteOptions struct {
+	// Outbox grava um evento na tabela outbox_events, na mesma transação, a
+	// cada criação, atualização ou remoção
+	Outbox bool
+}
+
+// NewSQLiteRepository cria o repositório de contatos no SQLite. Sem
+// row-level security, o isolamento entre tenants depende apenas do filtro
+// por tenant_id em todas as consultas.
+func NewSQLiteRepository(db *sql.DB) Repository {
+	return NewSQLiteRepositoryWithOptions(db, SQLiteOptions{})
+}
+
+func NewSQLiteRepositoryWithOptions(db *sql.DB, opts SQLiteOptions) Repository {
+	return &SQLiteRepository{db: db, outbox: opts.Outbox}
+}
+
+// withMutation roda a alteração numa transação que também reserva a posição
+// na sequência de alterações do tenant e, com o outbox, grava o evento
+func (r *SQLiteRepository) withMutation(ctx context.Context, tenantID string, fn func(q querier, seq int64) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	seq, err := nextChangeSeq(ctx, tx, tenantID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := fn(tx, seq); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *SQLiteRepository) Create(ctx context.Context, contact *Contact) error {
+	query := `
+		INSERT INTO contacts (id, tenant_id, name, email, phone, category_id, created_at, updated_at, change_seq)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	// O SQLite não gera UUIDs, então o ID é definido pela aplicação
+	id := uuid.NewString()
+
+	err := r.withMutation(ctx, contact.TenantID, func(q querier, seq int64) error {
+		if _, err := q.ExecContext(ctx, query, id, contact.TenantID, contact.Name, contact.Email, contact.Phone, contact.CategoryID, contact.CreatedAt, contact.UpdatedAt, seq); err != nil {
+			return err
+		}
+		if !r.outbox {
+			return nil
+		}
+
+		created := *contact
+		created.ID = id
+		return recordEvent(ctx, q, Event{Type: EventContactCreated, TenantID: contact.TenantID, ContactID: id, CategoryID: contact.CategoryID, Contact: &created})
+	})
+	if err != nil {
+		return translateSQLiteError(err)
+	}
+
+	contact.ID = id
+	return nil
+}
+
+func (r *SQLiteRepository) FindAll(ctx context.Context, tenantID string) ([]*Contact, error) {
+	query := `
+		SELECT id, tenant_id, name, email, phone, category_id, created_at, updated_at
+		FROM contacts
+		WHERE tenant_id = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	contacts := []*Contact{}
+	for rows.Next() {
+		contact := &Contact{}
+		if err := rows.Scan(&contact.ID, &contact.TenantID, &contact.Name, &contact.Email, &contact.Phone, &contact.CategoryID, &contact.CreatedAt, &contact.UpdatedAt); err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+func (r *SQLiteRepository) FindByID(ctx context.Context, tenantID, id string) (*Contact, error) {
+	query := `
+		SELECT id, tenant_id, name, email, phone, category_id, created_at, updated_at
+		FROM contacts
+		WHERE tenant_id = $1 AND id = $2
+	`
+
+	contact := &Contact{}
+
+	row := r.db.QueryRowContext(ctx, query, tenantID, id)
+	err := row.Scan(&contact.ID, &contact.TenantID, &contact.Name, &contact.Email, &contact.Phone, &contact.CategoryID, &contact.CreatedAt, &contact.UpdatedAt)
+	if err != nil {
+		return nil, translateSQLiteError(err)
+	}
+
+	return contact, nil
+}
+
+func (r *SQLiteRepository) Update(ctx context.Context, contact *Contact) error {
+	query := `
+		UPDATE contacts
+		SET name = $1, email = $2, phone = $3, category_id = $4, updated_at = $5, change_seq = $6
+		WHERE tenant_id = $7 AND id = $8
+	`
+
+	// A categoria anterior é lida na mesma transação para compor o evento
+	previousQuery := `SELECT category_id FROM contacts WHERE tenant_id = $1 AND id = $2`
+
+	err := r.withMutation(ctx, contact.TenantID, func(q querier, seq int64) error {
+		var previousCategoryID string
+		if r.outbox {
+			if err := q.QueryRowContext(ctx, previousQuery, contact.TenantID, contact.ID).Scan(&previousCategoryID); err != nil {
+				return err
+			}
+		}
+
+		result, err := q.ExecContext(ctx, query, contact.Name, contact.Email, contact.Phone, contact.CategoryID, contact.UpdatedAt, seq, contact.TenantID, contact.ID)
+		if err != nil {
+			return err
+		}
+		if err := requireAffected(result); err != nil || !r.outbox {
+			return err
+		}
+
+		updated := *contact
+		return recordEvent(ctx, q, Event{
+			Type:               EventContactUpdated,
+			TenantID:           contact.TenantID,
+			ContactID:          contact.ID,
+			CategoryID:         contact.CategoryID,
+			PreviousCategoryID: previousCategoryID,
+			Contact:            &updated,
+		})
+	})
+	if err != nil {
+		return translateSQLiteError(err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteRepository) Delete(ctx context.Context, tenantID, id string) error {
+	query := `
+		DELETE FROM contacts
+		WHERE tenant_id = $1 AND id = $2
+		RETURNING category_id
+	`
+
+	err := r.withMutation(ctx, tenantID, func(q querier, seq int64) error {
+		var categoryID string
+		if err := q.QueryRowContext(ctx, query, tenantID, id).Scan(&categoryID); err != nil {
+			return err
+		}
+		if err := recordTombstone(ctx, q, tenantID, id, seq); err != nil || !r.outbox {
+			return err
+		}
+
+		return recordEvent(ctx, q, Event{Type: EventContactDeleted, TenantID: tenantID, ContactID: id, CategoryID: categoryID})
+	})
+	if err != nil {
+		return translateSQLiteError(err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteRepository) FindChanges(ctx context.Context, tenantID string, after int64, limit int) (*ChangeSet, error) {
+	return findChanges(ctx, r.db, tenantID, after, limit)
+}
+
+// translateSQLiteError converte erros do driver do SQLite nos erros de
+// domínio do pacote. Como o ID é texto, IDs que não são UUIDs simplesmente
+// não são encontrados.
+func translateSQLiteError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return ErrEmailAlreadyExists
+	}
+
+	return err
+}
@@ -0,0 +1,244 @@
+package contacts
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSyncLimit é o número de alterações por página da sincronização
+	DefaultSyncLimit = 500
+
+	// MaxSyncLimit é o maior limite aceito por página
+	MaxSyncLimit = 1000
+)
+
+// ChangeSet são as alterações de um tenant após uma posição da sequência de
+// alterações, em ordem. Seq é a posição a partir da qual a próxima consulta
+// deve continuar.
+type ChangeSet struct {
+	Contacts []*Contact
+	Deleted  []*DeletedContact
+	Seq      int64
+	HasMore  bool
+}
+
+// encodeSyncToken gera o token opaco com o tenant e a posição na sequência
+func encodeSyncToken(tenantID string, seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(tenantID + ":" + strconv.FormatInt(seq, 10)))
+}
+
+// decodeSyncToken lê a posição do token, que só vale para o tenant que o recebeu
+func decodeSyncToken(tenantID, token string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidSyncToken
+	}
+
+	owner, value, ok := strings.Cut(string(raw), ":")
+	if !ok || owner != tenantID {
+		return 0, ErrInvalidSyncToken
+	}
+
+	seq, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, ErrInvalidSyncToken
+	}
+	return seq, nil
+}
+
+// As consultas abaixo são compatíveis com o PostgreSQL e o SQLite e usadas
+// pelos dois repositórios
+
+// nextChangeSeq reserva a próxima posição da sequência de alterações do
+// tenant. A linha do contador fica bloqueada até o fim da transação q, de modo
+// que as alterações de um tenant recebem posições na ordem de commit.
+func nextChangeSeq(ctx context.Context, q querier, tenantID string) (int64, error) {
+	query := `
+		INSERT INTO contact_change_counters (tenant_id, last_seq)
+		VALUES ($1, 1)
+		ON CONFLICT (tenant_id) DO UPDATE SET last_seq = contact_change_counters.last_seq + 1
+		RETURNING last_seq
+	`
+
+	var seq int64
+	if err := q.QueryRowContext(ctx, query, tenantID).Scan(&seq); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// recordTombstone registra a remoção do contato para a sincronização incremental
+func recordTombstone(ctx context.Context, q querier, tenantID, id string, seq int64) error {
+	query := `
+		INSERT INTO contact_tombstones (tenant_id, change_seq, contact_id, deleted_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := q.ExecContext(ctx, query, tenantID, seq, id, time.Now().UTC())
+	return err
+}
+
+// findChanges lê até limit alterações do tenant após a posição after. Com
+// after negativo, retorna todos os contatos, sem as remoções (sincronização
+// completa). A leitura vai até a última posição confirmada no contador: as
+// posições menores pertencem a transações já confirmadas.
+func findChanges(ctx context.Context, q querier, tenantID string, after int64, limit int) (*ChangeSet, error) {
+	var upper, purged int64
+	err := q.QueryRowContext(ctx, "SELECT last_seq FROM contact_change_counters WHERE tenant_id = $1", tenantID).Scan(&upper)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// Uma posição além do contador vem de antes de uma restauração do banco
+	if after > upper {
+		return nil, ErrSyncTokenExpired
+	}
+
+	contactsQuery := `
+		SELECT id, tenant_id, name, email, phone, category_id, created_at, updated_at, change_seq
+		FROM contacts
+		WHERE tenant_id = $1 AND change_seq > $2 AND change_seq <= $3
+		ORDER BY change_seq
+		LIMIT $4
+	`
+
+	rows, err := q.QueryContext(ctx, contactsQuery, tenantID, after, upper, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changed []change
+	for rows.Next() {
+		contact := &Contact{}
+		var seq int64
+		if err := rows.Scan(&contact.ID, &contact.TenantID, &contact.Name, &contact.Email, &contact.Phone, &contact.CategoryID, &contact.CreatedAt, &contact.UpdatedAt, &seq); err != nil {
+			return nil, err
+		}
+		changed = append(changed, change{seq: seq, contact: contact})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var deleted []change
+	if after >= 0 {
+		tombstonesQuery := `
+			SELECT contact_id, deleted_at, change_seq
+			FROM contact_tombstones
+			WHERE tenant_id = $1 AND change_seq > $2 AND change_seq <= $3
+			ORDER BY change_seq
+			LIMIT $4
+		`
+
+		rows, err := q.QueryContext(ctx, tombstonesQuery, tenantID, after, upper, limit+1)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			tombstone := &DeletedContact{}
+			var seq int64
+			if err := rows.Scan(&tombstone.ID, &tombstone.DeletedAt, &seq); err != nil {
+				return nil, err
+			}
+			deleted = append(deleted, change{seq: seq, deleted: tombstone})
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		// O expurgo remove as remoções e avança purged_seq numa só transação;
+		// lido depois das remoções, purged_seq revela um expurgo concorrente
+		err = q.QueryRowContext(ctx, "SELECT purged_seq FROM contact_change_counters WHERE tenant_id = $1", tenantID).Scan(&purged)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if after < purged {
+			return nil, ErrSyncTokenExpired
+		}
+	}
+
+	return mergeChanges(changed, deleted, max(after, upper), limit), nil
+}
+
+// change é uma alteração lida do banco ou do repositório em memória
+type change struct {
+	seq     int64
+	contact *Contact
+	deleted *DeletedContact
+}
+
+// mergeChanges intercala as alterações e remoções, já ordenadas, e mantém as
+// limit primeiras. Sem mais alterações, a próxima consulta parte de upper.
+func mergeChanges(changed, deleted []change, upper int64, limit int) *ChangeSet {
+	changes := &ChangeSet{Contacts: []*Contact{}, Deleted: []*DeletedContact{}, Seq: upper}
+
+	for taken := 0; len(changed)+len(deleted) > 0; taken++ {
+		if taken == limit {
+			changes.HasMore = true
+			return changes
+		}
+
+		var next change
+		if len(deleted) == 0 || (len(changed) > 0 && changed[0].seq < deleted[0].seq) {
+			next, changed = changed[0], changed[1:]
+			changes.Contacts = append(changes.Contacts, next.contact)
+		} else {
+			next, deleted = deleted[0], deleted[1:]
+			changes.Deleted = append(changes.Deleted, next.deleted)
+		}
+		changes.Seq = next.seq
+	}
+
+	changes.Seq = upper
+	return changes
+}
+
+// PurgeTombstones remove as remoções registradas há mais de olderThan e
+// avança purged_seq de cada tenant afetado, o que expira os tokens de
+// sincronização anteriores às remoções expurgadas
+func PurgeTombstones(ctx context.Context, db *sql.DB, olderThan time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-olderThan).UTC()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao expurgar remoções: %v", err)
+	}
+	defer tx.Rollback()
+
+	advance := `
+		UPDATE contact_change_counters
+		SET purged_seq = (
+			SELECT MAX(t.change_seq) FROM contact_tombstones t
+			WHERE t.tenant_id = contact_change_counters.tenant_id AND t.deleted_at < $1
+		)
+		WHERE EXISTS (
+			SELECT 1 FROM contact_tombstones t
+			WHERE t.tenant_id = contact_change_counters.tenant_id AND t.deleted_at < $1
+				AND t.change_seq > contact_change_counters.purged_seq
+		)
+	`
+	if _, err := tx.ExecContext(ctx, advance, cutoff); err != nil {
+		return 0, fmt.Errorf("erro ao expurgar remoções: %v", err)
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM contact_tombstones WHERE deleted_at < $1", cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao expurgar remoções: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("erro ao expurgar remoções: %v", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,135 @@
+package contacts_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/contacts/contactstest"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/dbtest"
+)
+
+func TestHandlerSync(t *testing.T) {
+	router := newTestRouter(contacts.NewMemoryRepository())
+
+	rec := doRequest(t, router, http.MethodPost, "/contacts", "acme", contacts.CreateContactRequest{Name: "Ana", Email: "ana@example.com"})
+	assertStatus(t, rec, http.StatusCreated)
+	ana := decode[contacts.Contact](t, rec)
+
+	rec = doRequest(t, router, http.MethodGet, "/contacts/sync", "acme", nil)
+	assertStatus(t, rec, http.StatusOK)
+	full := decode[contacts.SyncResult](t, rec)
+	if len(full.Contacts) != 1 || full.Contacts[0].ID != ana.ID || full.Token == "" {
+		t.Fatalf("sincronização completa = %+v", full)
+	}
+
+	assertStatus(t, doRequest(t, router, http.MethodDelete, "/contacts/"+ana.ID, "acme", nil), http.StatusNoContent)
+
+	rec = doRequest(t, router, http.MethodGet, "/contacts/sync?token="+full.Token, "acme", nil)
+	assertStatus(t, rec, http.StatusOK)
+	delta := decode[contacts.SyncResult](t, rec)
+	if len(delta.Contacts) != 0 || len(delta.Deleted) != 1 || delta.Deleted[0].ID != ana.ID || delta.Token == full.Token {
+		t.Fatalf("sincronização incremental = %+v", delta)
+	}
+
+	// O token não vale para outro tenant nem aceita limites fora do intervalo
+	for _, target := range []string{"/contacts/sync?token=invalido!", "/contacts/sync?limit=0", "/contacts/sync?limit=1001"} {
+		assertStatus(t, doRequest(t, router, http.MethodGet, target, "acme", nil), http.StatusBadRequest)
+	}
+	assertStatus(t, doRequest(t, router, http.MethodGet, "/contacts/sync?token="+delta.Token, "globex", nil), http.StatusBadRequest)
+}
+
+func TestSQLitePurgeTombstonesExpiresTokens(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.OpenSQLite(t)
+	service := contacts.NewService(contacts.NewSQLiteRepository(db))
+
+	contact := contactstest.NewContact("acme", "ana@example.com")
+	created, err := service.CreateNewContact(ctx, "acme", contact.Name, contact.Email, contact.Phone, contact.CategoryID)
+	if err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+
+	before, err := service.SyncContacts(ctx, "acme", "", 0)
+	if err != nil {
+		t.Fatalf("SyncContacts: %v", err)
+	}
+	if err := service.DeleteContact(ctx, "acme", created.ID); err != nil {
+		t.Fatalf("DeleteContact: %v", err)
+	}
+	after, err := service.SyncContacts(ctx, "acme", before.Token, 0)
+	if err != nil {
+		t.Fatalf("SyncContacts: %v", err)
+	}
+
+	// Remoções dentro da retenção são mantidas
+	if purged, err := contacts.PurgeTombstones(ctx, db, time.Hour); err != nil || purged != 0 {
+		t.Fatalf("PurgeTombstones = %d, %v; esperado 0", purged, err)
+	}
+	if _, err := service.SyncContacts(ctx, "acme", before.Token, 0); err != nil {
+		t.Fatalf("SyncContacts antes do expurgo: %v", err)
+	}
+
+	if purged, err := contacts.PurgeTombstones(ctx, db, -time.Hour); err != nil || purged != 1 {
+		t.Fatalf("PurgeTombstones = %d, %v; esperado 1", purged, err)
+	}
+
+	// O token anterior à remoção expurgada não enxergaria a remoção
+	if _, err := service.SyncContacts(ctx, "acme", before.Token, 0); !errors.Is(err, contacts.ErrSyncTokenExpired) {
+		t.Fatalf("erro = %v, esperado %v", err, contacts.ErrSyncTokenExpired)
+	}
+	if _, err := service.SyncContacts(ctx, "acme", after.Token, 0); err != nil {
+		t.Fatalf("SyncContacts com token posterior ao expurgo: %v", err)
+	}
+}
+
+func TestSQLiteSyncContactsFromLegacyRows(t *testing.T) {
+	ctx := context.Background()
+	db, legacy := contactstest.OpenSQLiteWithLegacyContacts(t, "acme", 5)
+	service := contacts.NewService(contacts.NewSQLiteRepository(db))
+
+	// A sincronização paginada percorre todos os contatos anteriores à
+	// sequência de alterações, na ordem de criação
+	var synced []string
+	result := &contacts.SyncResult{HasMore: true}
+	for page := 0; result.HasMore; page++ {
+		if page > len(legacy) {
+			t.Fatal("sincronização não terminou")
+		}
+
+		var err error
+		if result, err = service.SyncContacts(ctx, "acme", result.Token, 2); err != nil {
+			t.Fatalf("SyncContacts: %v", err)
+		}
+		for _, contact := range result.Contacts {
+			synced = append(synced, contact.ID)
+		}
+	}
+
+	if len(synced) != len(legacy) {
+		t.Fatalf("sincronizados %d contatos, esperado %d", len(synced), len(legacy))
+	}
+	for i, contact := range legacy {
+		if synced[i] != contact.ID {
+			t.Fatalf("contato %d = %s, esperado %s", i, synced[i], contact.ID)
+		}
+	}
+
+	// O contador parte da maior posição dos contatos existentes: o contato
+	// novo entra na sincronização incremental
+	contact := contactstest.NewContact("acme", "ana@example.com")
+	created, err := service.CreateNewContact(ctx, "acme", contact.Name, contact.Email, contact.Phone, contact.CategoryID)
+	if err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+	delta, err := service.SyncContacts(ctx, "acme", result.Token, 2)
+	if err != nil {
+		t.Fatalf("SyncContacts: %v", err)
+	}
+	if len(delta.Contacts) != 1 || delta.Contacts[0].ID != created.ID || delta.HasMore {
+		t.Fatalf("sincronização incremental = %+v", delta)
+	}
+}
@@ -0,0 +1,96 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Timeouts define o tempo máximo de cada operação do repositório. Operations
+// usa os mesmos nomes das métricas (create, find_all, find_by_id,
+// find_changes, update e delete); operações ausentes usam Default. Zero
+// desabilita o limite.
+type Timeouts struct {
+	Default    time.Duration
+	Operations map[string]time.Duration
+}
+
+func (t Timeouts) forOperation(operation string) time.Duration {
+	if timeout, ok := t.Operations[operation]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// timeoutRepository aplica um prazo a cada chamada ao repositório decorado. O
+// driver aborta a consulta no PostgreSQL quando o prazo expira ou quando o
+// contexto da requisição é cancelado.
+type timeoutRepository struct {
+	next     Repository
+	timeouts Timeouts
+}
+
+func NewTimeoutRepository(next Repository, timeouts Timeouts) Repository {
+	return &timeoutRepository{next: next, timeouts: timeouts}
+}
+
+func (r *timeoutRepository) Create(ctx context.Context, contact *Contact) error {
+	ctx, cancel := r.withTimeout(ctx, "create")
+	defer cancel()
+	return contextError(ctx, r.next.Create(ctx, contact))
+}
+
+func (r *timeoutRepository) FindAll(ctx context.Context, tenantID string) ([]*Contact, error) {
+	ctx, cancel := r.withTimeout(ctx, "find_all")
+	defer cancel()
+	contacts, err := r.next.FindAll(ctx, tenantID)
+	return contacts, contextError(ctx, err)
+}
+
+func (r *timeoutRepository) FindByID(ctx context.Context, tenantID, id string) (*Contact, error) {
+	ctx, cancel := r.withTimeout(ctx, "find_by_id")
+	defer cancel()
+	contact, err := r.next.FindByID(ctx, tenantID, id)
+	return contact, contextError(ctx, err)
+}
+
+func (r *timeoutRepository) FindChanges(ctx context.Context, tenantID string, after int64, limit int) (*ChangeSet, error) {
+	ctx, cancel := r.withTimeout(ctx, "find_changes")
+	defer cancel()
+	changes, err := r.next.FindChanges(ctx, tenantID, after, limit)
+	return changes, contextError(ctx, err)
+}
+
+func (r *timeoutRepository) Update(ctx context.Context, contact *Contact) error {
+	ctx, cancel := r.withTimeout(ctx, "update")
+	defer cancel()
+	return contextError(ctx, r.next.Update(ctx, contact))
+}
+
+func (r *timeoutRepository) Delete(ctx context.Context, tenantID, id string) error {
+	ctx, cancel := r.withTimeout(ctx, "delete")
+	defer cancel()
+	return contextError(ctx, r.next.Delete(ctx, tenantID, id))
+}
+
+func (r *timeoutRepository) withTimeout(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	timeout := r.timeouts.forOperation(operation)
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// contextError garante que falhas causadas por cancelamento ou prazo expirado
+// possam ser identificadas com errors.Is, já que o driver pode devolver o erro
+// do PostgreSQL (57014, query_canceled) em vez do erro do contexto
+func contextError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	if errors.Is(err, ctx.Err()) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ctx.Err(), err)
+}
@@ -0,0 +1,148 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Felipe8297/go-contacts-api/internal/contacts"
+
+// startSpan inicia um span com o TracerProvider global, consultado a cada
+// chamada para respeitar a configuração feita na inicialização
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan registra o erro no span, exceto contato não encontrado, que é um
+// resultado esperado
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracingService cria um span para cada método do Service decorado
+type tracingService struct {
+	next Service
+}
+
+func NewTracingService(next Service) Service {
+	return &tracingService{next: next}
+}
+
+func (s *tracingService) CreateNewContact(ctx context.Context, tenantID, name, email, phone, categoryID string) (*Contact, error) {
+	ctx, span := startSpan(ctx, "contacts.Service/CreateNewContact", attribute.String("tenant.id", tenantID))
+	contact, err := s.next.CreateNewContact(ctx, tenantID, name, email, phone, categoryID)
+	endSpan(span, err)
+	return contact, err
+}
+
+func (s *tracingService) GetAllContacts(ctx context.Context, tenantID string) ([]*Contact, error) {
+	ctx, span := startSpan(ctx, "contacts.Service/GetAllContacts", attribute.String("tenant.id", tenantID))
+	contacts, err := s.next.GetAllContacts(ctx, tenantID)
+	span.SetAttributes(attribute.Int("contacts.count", len(contacts)))
+	endSpan(span, err)
+	return contacts, err
+}
+
+func (s *tracingService) GetContactByID(ctx context.Context, tenantID, id string) (*Contact, error) {
+	ctx, span := startSpan(ctx, "contacts.Service/GetContactByID", attribute.String("tenant.id", tenantID), attribute.String("contact.id", id))
+	contact, err := s.next.GetContactByID(ctx, tenantID, id)
+	endSpan(span, err)
+	return contact, err
+}
+
+func (s *tracingService) UpdateContact(ctx context.Context, tenantID, id, name, email, phone, categoryID string) (*Contact, error) {
+	ctx, span := startSpan(ctx, "contacts.Service/UpdateContact", attribute.String("tenant.id", tenantID), attribute.String("contact.id", id))
+	contact, err := s.next.UpdateContact(ctx, tenantID, id, name, email, phone, categoryID)
+	endSpan(span, err)
+	return contact, err
+}
+
+func (s *tracingService) DeleteContact(ctx context.Context, tenantID, id string) error {
+	ctx, span := startSpan(ctx, "contacts.Service/DeleteContact", attribute.String("tenant.id", tenantID), attribute.String("contact.id", id))
+	err := s.next.DeleteContact(ctx, tenantID, id)
+	endSpan(span, err)
+	return err
+}
+
+func (s *tracingService) SyncContacts(ctx context.Context, tenantID, token string, limit int) (*SyncResult, error) {
+	ctx, span := startSpan(ctx, "contacts.Service/SyncContacts", attribute.String("tenant.id", tenantID))
+	result, err := s.next.SyncContacts(ctx, tenantID, token, limit)
+	endSpan(span, err)
+	return result, err
+}
+
+// tracingRepository cria um span de cliente para cada método do Repository
+// decorado. O span cobre a operação inteira, não cada comando SQL executado
+// por ela (ex.: o set_config do tenant com RLS).
+type tracingRepository struct {
+	next     Repository
+	dbSystem string
+}
+
+// NewTracingRepository decora next; dbSystem identifica o banco nos spans
+// (ex.: postgresql, sqlite)
+func NewTracingRepository(next Repository, dbSystem string) Repository {
+	return &tracingRepository{next: next, dbSystem: dbSystem}
+}
+
+func (r *tracingRepository) startQuerySpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "contacts.Repository/"+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", r.dbSystem),
+			attribute.String("db.operation", operation),
+			attribute.String("db.sql.table", metricsTable),
+		),
+	)
+}
+
+func (r *tracingRepository) Create(ctx context.Context, contact *Contact) error {
+	ctx, span := r.startQuerySpan(ctx, "create")
+	err := r.next.Create(ctx, contact)
+	endSpan(span, err)
+	return err
+}
+
+func (r *tracingRepository) FindAll(ctx context.Context, tenantID string) ([]*Contact, error) {
+	ctx, span := r.startQuerySpan(ctx, "find_all")
+	contacts, err := r.next.FindAll(ctx, tenantID)
+	endSpan(span, err)
+	return contacts, err
+}
+
+func (r *tracingRepository) FindByID(ctx context.Context, tenantID, id string) (*Contact, error) {
+	ctx, span := r.startQuerySpan(ctx, "find_by_id")
+	contact, err := r.next.FindByID(ctx, tenantID, id)
+	endSpan(span, err)
+	return contact, err
+}
+
+func (r *tracingRepository) FindChanges(ctx context.Context, tenantID string, after int64, limit int) (*ChangeSet, error) {
+	ctx, span := r.startQuerySpan(ctx, "find_changes")
+	changes, err := r.next.FindChanges(ctx, tenantID, after, limit)
+	endSpan(span, err)
+	return changes, err
+}
+
+func (r *tracingRepository) Update(ctx context.Context, contact *Contact) error {
+	ctx, span := r.startQuerySpan(ctx, "update")
+	err := r.next.Update(ctx, contact)
+	endSpan(span, err)
+	return err
+}
+
+func (r *tracingRepository) Delete(ctx context.Context, tenantID, id string) error {
+	ctx, span := r.startQuerySpan(ctx, "delete")
+	err := r.next.Delete(ctx, tenantID, id)
+	endSpan(span, err)
+	return err
+}
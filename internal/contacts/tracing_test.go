@@ -0,0 +1,116 @@
+package contacts_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/config"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tracing"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTracedRouter monta a cadeia HTTP -> Service -> Repository como em
+// cmd/api, com os spans gravados em memória
+func newTracedRouter(t *testing.T) (*gin.Engine, *tracetest.InMemoryExporter) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Default().Tracing
+	if _, err := tracing.Setup(context.Background(), cfg); err != nil {
+		t.Fatalf("erro ao configurar tracing: %v", err)
+	}
+
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracing.NewProvider(cfg, sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	repo := contacts.NewTracingRepository(contacts.NewMemoryRepository(), "memory")
+	service := contacts.NewTracingService(contacts.NewService(repo))
+
+	router := gin.New()
+	router.Use(otelgin.Middleware(cfg.ServiceName))
+	api := router.Group("", tenant.Middleware(tenant.DefaultID))
+	contacts.NewHandler(service).RegisterRoutes(api)
+	return router, exporter
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("span %s não encontrado entre %d spans", name, len(spans))
+	return tracetest.SpanStub{}
+}
+
+func TestTracingSpanHierarchy(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceparent string
+	}{
+		{name: "trace novo"},
+		{name: "traceparent recebido", traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, exporter := newTracedRouter(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/contacts", nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			assertStatus(t, rec, http.StatusOK)
+
+			spans := exporter.GetSpans()
+			if len(spans) != 3 {
+				t.Fatalf("%d spans, esperado 3 (HTTP, serviço e repositório): %v", len(spans), spans)
+			}
+
+			httpSpan := findSpan(t, spans, "/contacts")
+			serviceSpan := findSpan(t, spans, "contacts.Service/GetAllContacts")
+			repoSpan := findSpan(t, spans, "contacts.Repository/find_all")
+
+			if serviceSpan.Parent.SpanID() != httpSpan.SpanContext.SpanID() {
+				t.Fatal("span do serviço não é filho do span HTTP")
+			}
+			if repoSpan.Parent.SpanID() != serviceSpan.SpanContext.SpanID() {
+				t.Fatal("span do repositório não é filho do span do serviço")
+			}
+			if repoSpan.SpanKind != trace.SpanKindClient {
+				t.Fatalf("span do repositório com kind %v, esperado client", repoSpan.SpanKind)
+			}
+
+			traceID := httpSpan.SpanContext.TraceID()
+			for _, span := range spans {
+				if span.SpanContext.TraceID() != traceID {
+					t.Fatalf("span %s em outro trace", span.Name)
+				}
+			}
+
+			if tt.traceparent == "" {
+				if httpSpan.Parent.IsValid() {
+					t.Fatal("span HTTP sem traceparent tem pai")
+				}
+				return
+			}
+			if traceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || httpSpan.Parent.SpanID().String() != "00f067aa0ba902b7" || !httpSpan.Parent.IsRemote() {
+				t.Fatalf("traceparent não foi continuado: trace %s, pai %s", traceID, httpSpan.Parent.SpanID())
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus converte os erros do contacts.Service nos códigos de status gRPC
+// equivalentes aos status HTTP da API REST
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, contacts.ErrNotFound):
+		return status.Error(codes.NotFound, "Contato não encontrado")
+	case errors.Is(err, contacts.ErrEmailAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, contacts.ErrInvalidSyncToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, contacts.ErrSyncTokenExpired):
+		// O cliente precisa exportar de novo sem token, como o 410 da API REST
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "Tempo limite da operação excedido")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "Requisição cancelada pelo cliente")
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// invalidArgument é o erro de validação dos dados de uma requisição
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
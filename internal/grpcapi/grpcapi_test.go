@@ -0,0 +1,275 @@
+package grpcapi_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	contactsv1 "github.com/Felipe8297/go-contacts-api/api/contacts/v1"
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/contacts/contactstest"
+	"github.com/Felipe8297/go-contacts-api/internal/grpcapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type testEnv struct {
+	server *grpcapi.Server
+	conn   *grpc.ClientConn
+	client contactsv1.ContactsServiceClient
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	return newTestEnvWithService(t, contacts.NewService(contacts.NewMemoryRepository()))
+}
+
+func newTestEnvWithService(t *testing.T, service contacts.Service) *testEnv {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpcapi.New(service, grpcapi.Options{
+		DefaultTenant: "default",
+		Reflection:    true,
+		Logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Stop(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("erro ao conectar: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &testEnv{server: server, conn: conn, client: contactsv1.NewContactsServiceClient(conn)}
+}
+
+func withTenant(id string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-tenant-id", id)
+}
+
+func TestUnaryCRUD(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := withTenant("acme")
+
+	created, err := env.client.CreateContact(ctx, &contactsv1.CreateContactRequest{Name: "Ana", Email: "ana@example.com", Phone: "11999998888"})
+	if err != nil {
+		t.Fatalf("CreateContact: %v", err)
+	}
+	contact := created.GetContact()
+	if contact.GetId() == "" || contact.GetTenantId() != "acme" || contact.GetCreatedAt() == nil {
+		t.Fatalf("contato criado = %+v", contact)
+	}
+
+	_, err = env.client.CreateContact(ctx, &contactsv1.CreateContactRequest{Name: "Outra Ana", Email: "ana@example.com"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("email duplicado = %v, esperado AlreadyExists", err)
+	}
+	_, err = env.client.CreateContact(ctx, &contactsv1.CreateContactRequest{Name: "Sem email"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("validação = %v, esperado InvalidArgument", err)
+	}
+
+	// O contato pertence ao tenant acme; o tenant padrão não o encontra
+	if _, err := env.client.GetContact(context.Background(), &contactsv1.GetContactRequest{Id: contact.GetId()}); status.Code(err) != codes.NotFound {
+		t.Fatalf("GetContact em outro tenant = %v, esperado NotFound", err)
+	}
+	if _, err := env.client.GetContact(withTenant("acme corp"), &contactsv1.GetContactRequest{Id: contact.GetId()}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("tenant inválido = %v, esperado InvalidArgument", err)
+	}
+
+	updated, err := env.client.UpdateContact(ctx, &contactsv1.UpdateContactRequest{Id: contact.GetId(), Name: "Ana Souza", Email: "ana@example.com"})
+	if err != nil || updated.GetContact().GetName() != "Ana Souza" {
+		t.Fatalf("UpdateContact = %+v, %v", updated, err)
+	}
+
+	got, err := env.client.GetContact(ctx, &contactsv1.GetContactRequest{Id: contact.GetId()})
+	if err != nil || got.GetContact().GetName() != "Ana Souza" {
+		t.Fatalf("GetContact = %+v, %v", got, err)
+	}
+
+	if _, err := env.client.DeleteContact(ctx, &contactsv1.DeleteContactRequest{Id: contact.GetId()}); err != nil {
+		t.Fatalf("DeleteContact: %v", err)
+	}
+	if _, err := env.client.DeleteContact(ctx, &contactsv1.DeleteContactRequest{Id: contact.GetId()}); status.Code(err) != codes.NotFound {
+		t.Fatalf("DeleteContact repetido = %v, esperado NotFound", err)
+	}
+}
+
+func TestStreams(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := withTenant("acme")
+
+	batch, err := env.client.BatchContacts(ctx)
+	if err != nil {
+		t.Fatalf("BatchContacts: %v", err)
+	}
+	requests := []*contactsv1.BatchContactsRequest{
+		{RequestId: "1", Operation: &contactsv1.BatchContactsRequest_Create{Create: &contactsv1.CreateContactRequest{Name: "Ana", Email: "ana@example.com"}}},
+		{RequestId: "2", Operation: &contactsv1.BatchContactsRequest_Create{Create: &contactsv1.CreateContactRequest{Name: "Bia", Email: "bia@example.com"}}},
+		{RequestId: "3", Operation: &contactsv1.BatchContactsRequest_Create{Create: &contactsv1.CreateContactRequest{Name: "Ana 2", Email: "ana@example.com"}}},
+		{RequestId: "4", Operation: &contactsv1.BatchContactsRequest_Delete{Delete: &contactsv1.DeleteContactRequest{Id: "inexistente"}}},
+		{RequestId: "5"},
+	}
+
+	var created []string
+	for _, req := range requests {
+		if err := batch.Send(req); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+		resp, err := batch.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if resp.GetRequestId() != req.GetRequestId() {
+			t.Fatalf("request_id = %q, esperado %q", resp.GetRequestId(), req.GetRequestId())
+		}
+		if contact := resp.GetContact(); contact != nil {
+			created = append(created, contact.GetId())
+		}
+	}
+	if len(created) != 2 {
+		t.Fatalf("contatos criados = %v, esperado 2", created)
+	}
+
+	// A falha de uma operação volta com o código gRPC sem encerrar o stream
+	if err := batch.Send(requests[2]); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	resp, err := batch.Recv()
+	if err != nil || resp.GetError().GetCode() != int32(codes.AlreadyExists) {
+		t.Fatalf("erro da operação = %+v, %v", resp, err)
+	}
+	if err := batch.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+	if _, err := batch.Recv(); err != io.EOF {
+		t.Fatalf("fim do lote = %v, esperado EOF", err)
+	}
+
+	list, err := env.client.ListContacts(ctx, &contactsv1.ListContactsRequest{})
+	if err != nil {
+		t.Fatalf("ListContacts: %v", err)
+	}
+	if names := receiveAll(t, list.Recv); len(names) != 2 {
+		t.Fatalf("ListContacts = %d contatos, esperado 2", len(names))
+	}
+
+	export := exportAll(t, env.client, ctx, "")
+	if len(export.contacts) != 2 || export.token == "" {
+		t.Fatalf("exportação completa = %+v", export)
+	}
+
+	if _, err := env.client.DeleteContact(ctx, &contactsv1.DeleteContactRequest{Id: created[0]}); err != nil {
+		t.Fatalf("DeleteContact: %v", err)
+	}
+	incremental := exportAll(t, env.client, ctx, export.token)
+	if len(incremental.contacts) != 0 || len(incremental.deleted) != 1 || incremental.deleted[0] != created[0] {
+		t.Fatalf("exportação incremental = %+v", incremental)
+	}
+
+	stream, err := env.client.ExportContacts(ctx, &contactsv1.ExportContactsRequest{SyncToken: "inválido"})
+	if err != nil {
+		t.Fatalf("ExportContacts: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("token inválido = %v, esperado InvalidArgument", err)
+	}
+}
+
+func TestExportContactsFromLegacyRows(t *testing.T) {
+	// Mais contatos anteriores à sequência de alterações que uma página da
+	// exportação
+	db, legacy := contactstest.OpenSQLiteWithLegacyContacts(t, "acme", contacts.MaxSyncLimit+1)
+	env := newTestEnvWithService(t, contacts.NewService(contacts.NewSQLiteRepository(db)))
+
+	export := exportAll(t, env.client, withTenant("acme"), "")
+	if len(export.contacts) != len(legacy) || export.token == "" {
+		t.Fatalf("exportação completa = %d contatos, esperado %d", len(export.contacts), len(legacy))
+	}
+	for i, contact := range legacy {
+		if export.contacts[i] != contact.ID {
+			t.Fatalf("contato %d = %s, esperado %s", i, export.contacts[i], contact.ID)
+		}
+	}
+}
+
+func TestHealth(t *testing.T) {
+	env := newTestEnv(t)
+	health := healthpb.NewHealthClient(env.conn)
+
+	resp, err := health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: contactsv1.ContactsService_ServiceDesc.ServiceName})
+	if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("health = %v, %v", resp, err)
+	}
+
+	env.server.Drain()
+
+	resp, err = health.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("health após Drain = %v, %v", resp, err)
+	}
+}
+
+type exportResult struct {
+	contacts []string
+	deleted  []string
+	token    string
+}
+
+func exportAll(t *testing.T, client contactsv1.ContactsServiceClient, ctx context.Context, token string) exportResult {
+	t.Helper()
+
+	stream, err := client.ExportContacts(ctx, &contactsv1.ExportContactsRequest{SyncToken: token})
+	if err != nil {
+		t.Fatalf("ExportContacts: %v", err)
+	}
+
+	var result exportResult
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return result
+		}
+		if err != nil {
+			t.Fatalf("ExportContacts: %v", err)
+		}
+		switch change := resp.GetChange().(type) {
+		case *contactsv1.ExportContactsResponse_Contact:
+			result.contacts = append(result.contacts, change.Contact.GetId())
+		case *contactsv1.ExportContactsResponse_Deleted:
+			result.deleted = append(result.deleted, change.Deleted.GetId())
+		case *contactsv1.ExportContactsResponse_SyncToken:
+			result.token = change.SyncToken
+		}
+	}
+}
+
+func receiveAll(t *testing.T, recv func() (*contactsv1.ListContactsResponse, error)) []string {
+	t.Helper()
+
+	var names []string
+	for {
+		resp, err := recv()
+		if err == io.EOF {
+			return names
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		names = append(names, resp.GetContact().GetName())
+	}
+}
@@ -0,0 +1,164 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/logger"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tenantMetadataKey é o metadata equivalente ao cabeçalho X-Tenant-ID; as
+// chaves de metadata são sempre minúsculas
+var tenantMetadataKey = strings.ToLower(tenant.HeaderName)
+
+type tenantKey struct{}
+
+// tenantFromContext retorna o tenant resolvido pelos interceptors
+func tenantFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(tenantKey{}).(string)
+	return id
+}
+
+// resolveTenant lê o tenant do metadata x-tenant-id. Se não for informado,
+// usa defaultID; quando defaultID é vazio a chamada é rejeitada.
+func resolveTenant(ctx context.Context, defaultID string) (context.Context, error) {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(tenantMetadataKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = defaultID
+	}
+
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "Metadata "+tenantMetadataKey+" é obrigatório")
+	}
+	if !tenant.IsValid(id) {
+		return nil, status.Error(codes.InvalidArgument, "Tenant inválido")
+	}
+
+	ctx = context.WithValue(ctx, tenantKey{}, id)
+	return logger.WithAttrs(ctx, slog.String("tenant_id", id)), nil
+}
+
+// tenantUnaryInterceptor resolve o tenant das chamadas unárias. Os serviços de
+// health e reflection não pertencem a um tenant.
+func tenantUnaryInterceptor(defaultID string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !isContactsMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := resolveTenant(ctx, defaultID)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func tenantStreamInterceptor(defaultID string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !isContactsMethod(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := resolveTenant(ss.Context(), defaultID)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// logUnaryInterceptor registra uma linha estruturada por chamada, como o
+// access log da API REST
+func logUnaryInterceptor(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, log, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func logStreamInterceptor(log *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(ss.Context(), log, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func logCall(ctx context.Context, log *slog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.Canceled:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("errors", status.Convert(err).Message()))
+	}
+
+	log.LogAttrs(ctx, level, "Chamada gRPC", attrs...)
+}
+
+// recoveryUnaryInterceptor converte um panic no handler em codes.Internal,
+// sem derrubar o processo
+func recoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = recoverPanic(ctx, recovered)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func recoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = recoverPanic(ss.Context(), recovered)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recoverPanic(ctx context.Context, recovered any) error {
+	slog.ErrorContext(ctx, "Panic ao processar chamada gRPC",
+		"panic", recovered, "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "Erro interno do servidor")
+}
+
+// contextStream substitui o contexto do stream pelo contexto com o tenant
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
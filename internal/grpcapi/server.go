@@ -0,0 +1,111 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+
+	contactsv1 "github.com/Felipe8297/go-contacts-api/api/contacts/v1"
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Options configura o servidor gRPC
+type Options struct {
+	// DefaultTenant é usado nas chamadas sem o metadata x-tenant-id; vazio
+	// torna o metadata obrigatório
+	DefaultTenant string
+
+	// Reflection registra o serviço de reflection usado por grpcurl e afins
+	Reflection bool
+
+	// Logger registra uma linha por chamada; nil usa slog.Default
+	Logger *slog.Logger
+}
+
+// Server é o servidor gRPC do ContactsService com os serviços de health e,
+// opcionalmente, de reflection
+type Server struct {
+	grpc   *grpc.Server
+	health *health.Server
+}
+
+// New cria o servidor sobre o contacts.Service usado pela API REST
+func New(service contacts.Service, opts Options) *Server {
+	log := opts.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+
+	// O tenant é resolvido antes do log para que a linha da chamada o inclua
+	srv := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			tenantUnaryInterceptor(opts.DefaultTenant),
+			logUnaryInterceptor(log),
+			recoveryUnaryInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			tenantStreamInterceptor(opts.DefaultTenant),
+			logStreamInterceptor(log),
+			recoveryStreamInterceptor(),
+		),
+	)
+
+	contactsv1.RegisterContactsServiceServer(srv, &contactsServer{service: service})
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(contactsv1.ContactsService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthServer)
+
+	if opts.Reflection {
+		reflection.Register(srv)
+	}
+
+	return &Server{grpc: srv, health: healthServer}
+}
+
+// Serve atende chamadas em listener até Stop. O retorno nil indica que o
+// servidor foi parado.
+func (s *Server) Serve(listener net.Listener) error {
+	if err := s.grpc.Serve(listener); err != nil {
+		return fmt.Errorf("erro ao atender chamadas gRPC: %v", err)
+	}
+	return nil
+}
+
+// Drain passa o health check para NOT_SERVING enquanto o servidor ainda
+// atende, como a readiness da API REST durante o desligamento
+func (s *Server) Drain() {
+	s.health.Shutdown()
+}
+
+// Stop para de aceitar chamadas e aguarda as em andamento, inclusive os
+// streams; se ctx expirar antes, as chamadas restantes são interrompidas
+func (s *Server) Stop(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		<-stopped
+		return fmt.Errorf("erro ao finalizar servidor gRPC: %v", ctx.Err())
+	}
+}
+
+// isContactsMethod indica se o método pertence ao ContactsService
+func isContactsMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+contactsv1.ContactsService_ServiceDesc.ServiceName+"/")
+}
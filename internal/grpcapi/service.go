@@ -0,0 +1,208 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	contactsv1 "github.com/Felipe8297/go-contacts-api/api/contacts/v1"
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// contactsServer implementa o ContactsService sobre o contacts.Service, com
+// as mesmas validações da API REST
+type contactsServer struct {
+	contactsv1.UnimplementedContactsServiceServer
+
+	service contacts.Service
+}
+
+func (s *contactsServer) CreateContact(ctx context.Context, req *contactsv1.CreateContactRequest) (*contactsv1.CreateContactResponse, error) {
+	contact, err := s.create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &contactsv1.CreateContactResponse{Contact: toProto(contact)}, nil
+}
+
+func (s *contactsServer) GetContact(ctx context.Context, req *contactsv1.GetContactRequest) (*contactsv1.GetContactResponse, error) {
+	contact, err := s.service.GetContactByID(ctx, tenantFromContext(ctx), req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &contactsv1.GetContactResponse{Contact: toProto(contact)}, nil
+}
+
+func (s *contactsServer) UpdateContact(ctx context.Context, req *contactsv1.UpdateContactRequest) (*contactsv1.UpdateContactResponse, error) {
+	contact, err := s.update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &contactsv1.UpdateContactResponse{Contact: toProto(contact)}, nil
+}
+
+func (s *contactsServer) DeleteContact(ctx context.Context, req *contactsv1.DeleteContactRequest) (*contactsv1.DeleteContactResponse, error) {
+	if err := s.service.DeleteContact(ctx, tenantFromContext(ctx), req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &contactsv1.DeleteContactResponse{}, nil
+}
+
+func (s *contactsServer) ListContacts(_ *contactsv1.ListContactsRequest, stream contactsv1.ContactsService_ListContactsServer) error {
+	ctx := stream.Context()
+
+	all, err := s.service.GetAllContacts(ctx, tenantFromContext(ctx))
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for _, contact := range all {
+		if err := stream.Send(&contactsv1.ListContactsResponse{Contact: toProto(contact)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportContacts percorre as páginas da sincronização incremental e termina
+// com o token que retoma a exportação a partir deste ponto
+func (s *contactsServer) ExportContacts(req *contactsv1.ExportContactsRequest, stream contactsv1.ContactsService_ExportContactsServer) error {
+	ctx := stream.Context()
+	tenantID := tenantFromContext(ctx)
+
+	token := req.GetSyncToken()
+	for {
+		result, err := s.service.SyncContacts(ctx, tenantID, token, contacts.MaxSyncLimit)
+		if err != nil {
+			return toStatus(err)
+		}
+
+		for _, contact := range result.Contacts {
+			if err := stream.Send(&contactsv1.ExportContactsResponse{
+				Change: &contactsv1.ExportContactsResponse_Contact{Contact: toProto(contact)},
+			}); err != nil {
+				return err
+			}
+		}
+		for _, deleted := range result.Deleted {
+			if err := stream.Send(&contactsv1.ExportContactsResponse{
+				Change: &contactsv1.ExportContactsResponse_Deleted{Deleted: &contactsv1.DeletedContact{
+					Id:        deleted.ID,
+					DeletedAt: timestamppb.New(deleted.DeletedAt),
+				}},
+			}); err != nil {
+				return err
+			}
+		}
+
+		token = result.Token
+		if !result.HasMore {
+			break
+		}
+	}
+
+	return stream.Send(&contactsv1.ExportContactsResponse{
+		Change: &contactsv1.ExportContactsResponse_SyncToken{SyncToken: token},
+	})
+}
+
+// BatchContacts executa cada operação assim que ela chega. A falha de uma
+// operação volta na resposta correspondente; apenas o cancelamento da
+// chamada ou um erro do stream a encerram.
+func (s *contactsServer) BatchContacts(stream contactsv1.ContactsService_BatchContactsServer) error {
+	ctx := stream.Context()
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp := s.batchOperation(ctx, req)
+		if err := ctx.Err(); err != nil {
+			return toStatus(err)
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *contactsServer) batchOperation(ctx context.Context, req *contactsv1.BatchContactsRequest) *contactsv1.BatchContactsResponse {
+	resp := &contactsv1.BatchContactsResponse{RequestId: req.GetRequestId()}
+
+	var err error
+	switch operation := req.GetOperation().(type) {
+	case *contactsv1.BatchContactsRequest_Create:
+		var contact *contacts.Contact
+		if contact, err = s.create(ctx, operation.Create); err == nil {
+			resp.Result = &contactsv1.BatchContactsResponse_Contact{Contact: toProto(contact)}
+		}
+	case *contactsv1.BatchContactsRequest_Update:
+		var contact *contacts.Contact
+		if contact, err = s.update(ctx, operation.Update); err == nil {
+			resp.Result = &contactsv1.BatchContactsResponse_Contact{Contact: toProto(contact)}
+		}
+	case *contactsv1.BatchContactsRequest_Delete:
+		if err = toStatus(s.service.DeleteContact(ctx, tenantFromContext(ctx), operation.Delete.GetId())); err == nil {
+			resp.Result = &contactsv1.BatchContactsResponse_Deleted{Deleted: &contactsv1.DeleteContactResponse{}}
+		}
+	default:
+		err = status.Error(codes.InvalidArgument, "A operação do lote é obrigatória")
+	}
+
+	if err != nil {
+		st := status.Convert(err)
+		resp.Result = &contactsv1.BatchContactsResponse_Error{Error: &contactsv1.Error{
+			Code:    int32(st.Code()),
+			Message: st.Message(),
+		}}
+	}
+	return resp
+}
+
+func (s *contactsServer) create(ctx context.Context, req *contactsv1.CreateContactRequest) (*contacts.Contact, error) {
+	input := &contacts.CreateContactRequest{Name: req.GetName(), Email: req.GetEmail(), Phone: req.GetPhone(), CategoryID: req.GetCategoryId()}
+	if err := binding.Validator.ValidateStruct(input); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	contact, err := s.service.CreateNewContact(ctx, tenantFromContext(ctx), input.Name, input.Email, input.Phone, input.CategoryID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return contact, nil
+}
+
+func (s *contactsServer) update(ctx context.Context, req *contactsv1.UpdateContactRequest) (*contacts.Contact, error) {
+	input := &contacts.UpdateContactRequest{Name: req.GetName(), Email: req.GetEmail(), Phone: req.GetPhone(), CategoryID: req.GetCategoryId()}
+	if err := binding.Validator.ValidateStruct(input); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	contact, err := s.service.UpdateContact(ctx, tenantFromContext(ctx), req.GetId(), input.Name, input.Email, input.Phone, input.CategoryID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return contact, nil
+}
+
+func toProto(contact *contacts.Contact) *contactsv1.Contact {
+	return &contactsv1.Contact{
+		Id:         contact.ID,
+		TenantId:   contact.TenantID,
+		Name:       contact.Name,
+		Email:      contact.Email,
+		Phone:      contact.Phone,
+		CategoryId: contact.CategoryID,
+		CreatedAt:  timestamppb.New(contact.CreatedAt),
+		UpdatedAt:  timestamppb.New(contact.UpdatedAt),
+	}
+}
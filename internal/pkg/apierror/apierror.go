@@ -0,0 +1,22 @@
+package apierror
+
+import (
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// Response é o corpo padrão das respostas de erro da API
+type Response struct {
+	Error     string `json:"error"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Respond escreve a resposta de erro com o ID da requisição
+func Respond(c *gin.Context, status int, message string) {
+	c.JSON(status, Response{Error: message, RequestID: middleware.RequestID(c)})
+}
+
+// Abort escreve a resposta de erro e interrompe a cadeia de handlers
+func Abort(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, Response{Error: message, RequestID: middleware.RequestID(c)})
+}
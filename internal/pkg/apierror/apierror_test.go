@@ -0,0 +1,51 @@
+package apierror_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/apierror"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func TestResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		handler gin.HandlerFunc
+		// next indica se os handlers seguintes são executados
+		next bool
+	}{
+		{name: "Respond", handler: func(c *gin.Context) { apierror.Respond(c, http.StatusBadRequest, "payload inválido") }, next: true},
+		{name: "Abort", handler: func(c *gin.Context) { apierror.Abort(c, http.StatusBadRequest, "payload inválido") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var next bool
+			router := gin.New()
+			router.Use(middleware.RequestIDMiddleware())
+			router.GET("/", tt.handler, func(*gin.Context) { next = true })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(middleware.RequestIDHeader, "req-1")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			var body apierror.Response
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("resposta inválida %q: %v", rec.Body.String(), err)
+			}
+			if rec.Code != http.StatusBadRequest || body.Error != "payload inválido" || body.RequestID != "req-1" {
+				t.Fatalf("resposta = %d %+v", rec.Code, body)
+			}
+			if next != tt.next {
+				t.Fatalf("handler seguinte executado = %v, esperado %v", next, tt.next)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package auth autentica as requisições por chave de API e grava o tenant da
+// chave em tenant.PrincipalKey, onde ele tem precedência sobre o cabeçalho
+// X-Tenant-ID
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/apierror"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+// Realm identifica a API no cabeçalho WWW-Authenticate
+const Realm = "go-contacts-api"
+
+// Key associa uma chave de API ao tenant que ela autentica
+type Key struct {
+	Tenant string
+	Secret string
+}
+
+// Middleware exige uma das chaves em keys, informada como
+// "Authorization: Bearer <chave>" ou, para clientes CardDAV, como a senha do
+// HTTP Basic (o usuário é ignorado). Sem credencial válida a requisição é
+// rejeitada com 401.
+func Middleware(keys []Key) gin.HandlerFunc {
+	// Todas as chaves são comparadas, em tempo constante, pelo hash: o tempo da
+	// resposta não revela quantos bytes da chave informada estão corretos
+	hashed := make([][sha256.Size]byte, len(keys))
+	for i, key := range keys {
+		hashed[i] = sha256.Sum256([]byte(key.Secret))
+	}
+
+	return func(c *gin.Context) {
+		secret, ok := credential(c.Request)
+		if !ok {
+			unauthorized(c)
+			return
+		}
+
+		sum := sha256.Sum256([]byte(secret))
+		match := -1
+		for i := range hashed {
+			if subtle.ConstantTimeCompare(sum[:], hashed[i][:]) == 1 {
+				match = i
+			}
+		}
+		if match < 0 {
+			unauthorized(c)
+			return
+		}
+
+		c.Set(tenant.PrincipalKey, keys[match].Tenant)
+		c.Next()
+	}
+}
+
+// credential extrai a chave de API do cabeçalho Authorization
+func credential(r *http.Request) (string, bool) {
+	if _, password, ok := r.BasicAuth(); ok {
+		return password, password != ""
+	}
+
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// unauthorized rejeita a requisição anunciando os dois esquemas aceitos
+func unauthorized(c *gin.Context) {
+	c.Header("WWW-Authenticate", `Bearer realm="`+Realm+`"`)
+	c.Writer.Header().Add("WWW-Authenticate", `Basic realm="`+Realm+`", charset="UTF-8"`)
+	apierror.Abort(c, http.StatusUnauthorized, "Credenciais ausentes ou inválidas")
+}
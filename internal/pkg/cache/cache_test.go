@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2)
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	c.Set(ctx, "b", []byte("2"), time.Minute)
+	c.Get(ctx, "a")
+	c.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Fatal("entrada menos usada não foi descartada")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := c.Get(ctx, key); !ok {
+			t.Fatalf("entrada %s descartada indevidamente", key)
+		}
+	}
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10).(*LRU)
+
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	if value, ok, _ := c.Get(ctx, "a"); !ok || string(value) != "1" {
+		t.Fatalf("Get = %q, %v", value, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Fatal("entrada expirada retornada")
+	}
+	if c.Len() != 0 {
+		t.Fatalf("entrada expirada não foi removida: %d entradas", c.Len())
+	}
+}
+
+func TestLRUDelete(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10)
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	c.Delete(ctx, "a")
+	c.Delete(ctx, "inexistente")
+
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Fatal("entrada removida retornada")
+	}
+}
@@ -0,0 +1,978 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted substitui segredos na configuração exibida nos logs
+const redacted = "******"
+
+// Config reúne todas as configurações da aplicação. Os valores são carregados,
+// em ordem de precedência crescente, dos padrões, do arquivo YAML indicado em
+// CONFIG_FILE e das variáveis de ambiente (incluindo as do arquivo .env).
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Migrations  MigrationsConfig  `yaml:"migrations"`
+	Log         LogConfig         `yaml:"log"`
+	Tracing     TracingConfig     `yaml:"tracing"`
+	Tenant      TenantConfig      `yaml:"tenant"`
+	Auth        AuthConfig        `yaml:"auth"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	Cache       CacheConfig       `yaml:"cache"`
+	Events      EventsConfig      `yaml:"events"`
+	Webhooks    WebhooksConfig    `yaml:"webhooks"`
+	Stream      StreamConfig      `yaml:"stream"`
+	Sync        SyncConfig        `yaml:"sync"`
+	GRPC        GRPCConfig        `yaml:"grpc"`
+	Features    FeaturesConfig    `yaml:"features"`
+}
+
+type ServerConfig struct {
+	Addr              string        `yaml:"addr"`
+	TrustedProxies    []string      `yaml:"trusted_proxies"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+
+	// ShutdownDelay é o tempo em que o servidor continua atendendo, já
+	// reportando not-ready, antes de parar de aceitar conexões
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+
+	// ShutdownTimeout é o prazo para concluir as requisições em andamento e
+	// parar os workers após o sinal de término
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
+	// HealthCheckTimeout limita o tempo de cada verificação da readiness
+	HealthCheckTimeout time.Duration `yaml:"health_check_timeout"`
+}
+
+type DatabaseConfig struct {
+	// Driver aceita postgres ou sqlite. Com sqlite, os campos de conexão do
+	// PostgreSQL são ignorados e o banco fica no arquivo Path.
+	Driver string `yaml:"driver"`
+	Path   string `yaml:"path"`
+
+	// URL tem precedência sobre os campos individuais de conexão
+	URL string `yaml:"url"`
+
+	// ReplicaURLs lista réplicas de leitura do PostgreSQL, que atendem as
+	// listagens. Por ReplicaStickyWindow após uma alteração, o mesmo cliente
+	// lê do primário para enxergar a própria escrita. As réplicas são
+	// verificadas a cada ReplicaHealthInterval e, indisponíveis, dão lugar ao
+	// primário.
+	ReplicaURLs           []string      `yaml:"replica_urls"`
+	ReplicaStickyWindow   time.Duration `yaml:"replica_sticky_window"`
+	ReplicaHealthInterval time.Duration `yaml:"replica_health_interval"`
+
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+
+	// SSLMode aceita disable, require, verify-ca ou verify-full
+	SSLMode     string `yaml:"sslmode"`
+	SSLRootCert string `yaml:"sslrootcert"`
+	SSLCert     string `yaml:"sslcert"`
+	SSLKey      string `yaml:"sslkey"`
+
+	MaxOpenConns int `yaml:"max_open_conns"`
+	MaxIdleConns int `yaml:"max_idle_conns"`
+
+	// ConnMaxLifetime e ConnMaxIdleTime reciclam as conexões do pool, o que
+	// distribui a carga após um failover ou a troca de réplicas. Zero mantém
+	// as conexões indefinidamente.
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+
+	// ConnectTimeout limita cada tentativa de conexão na inicialização. Em
+	// caso de falha, a conexão é refeita até ConnectRetries vezes, com espera
+	// que dobra a partir de ConnectBackoff até ConnectMaxBackoff.
+	ConnectTimeout    time.Duration `yaml:"connect_timeout"`
+	ConnectRetries    int           `yaml:"connect_retries"`
+	ConnectBackoff    time.Duration `yaml:"connect_backoff"`
+	ConnectMaxBackoff time.Duration `yaml:"connect_max_backoff"`
+
+	// QueryTimeout limita cada operação do repositório; OperationTimeouts
+	// sobrescreve o limite por operação (create, find_all, find_by_id,
+	// find_changes, update e delete). Zero desabilita o limite.
+	QueryTimeout      time.Duration            `yaml:"query_timeout"`
+	OperationTimeouts map[string]time.Duration `yaml:"operation_timeouts"`
+}
+
+type MigrationsConfig struct {
+	// Mode define o que a API faz com as migrations ao iniciar: apply aplica as
+	// pendentes, verify recusa iniciar se houver pendentes e skip não as verifica
+	Mode string `yaml:"mode"`
+
+	// AllowDrift permite iniciar mesmo quando uma migration aplicada foi
+	// alterada no código. Use apenas para contornar uma divergência conhecida.
+	AllowDrift bool `yaml:"allow_drift"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level"`
+
+	// Format aceita json ou text
+	Format string `yaml:"format"`
+}
+
+type TracingConfig struct {
+	// Exporter aceita otlp, stdout ou none
+	Exporter string `yaml:"exporter"`
+
+	// Endpoint é a URL completa do coletor OTLP/HTTP (ex.:
+	// http://otel-collector:4318/v1/traces). Vazio usa as variáveis OTEL_EXPORTER_OTLP_*.
+	Endpoint    string  `yaml:"endpoint"`
+	ServiceName string  `yaml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+type TenantConfig struct {
+	// DefaultID é usado quando a requisição não informa o tenant. Vazio torna o
+	// cabeçalho X-Tenant-ID obrigatório.
+	DefaultID  string `yaml:"default_id"`
+	EnforceRLS bool   `yaml:"enforce_rls"`
+}
+
+type AuthConfig struct {
+	// APIKeys são as chaves aceitas nas rotas de webhooks e CardDAV. O tenant
+	// da chave usada substitui o cabeçalho X-Tenant-ID.
+	APIKeys []APIKey `yaml:"api_keys"`
+}
+
+type APIKey struct {
+	Tenant string `yaml:"tenant"`
+	Key    string `yaml:"key"`
+}
+
+// minAPIKeyLength é o tamanho mínimo de uma chave de API
+const minAPIKeyLength = 16
+
+type RateLimitConfig struct {
+	// Store aceita memory ou postgres
+	Store string  `yaml:"store"`
+	Rate  float64 `yaml:"rate"`
+	Burst float64 `yaml:"burst"`
+}
+
+type IdempotencyConfig struct {
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type CacheConfig struct {
+	// Size é o número máximo de contatos no cache em memória de cada réplica
+	Size int `yaml:"size"`
+
+	// TTL limita por quanto tempo uma réplica pode servir um contato alterado
+	// por outra réplica, já que a invalidação alcança apenas o próprio processo
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type EventsConfig struct {
+	// Sinks lista os destinos dos eventos de contatos; aceita log
+	Sinks []string `yaml:"sinks"`
+
+	// PollInterval é o intervalo entre as buscas por eventos pendentes no outbox
+	PollInterval time.Duration `yaml:"poll_interval"`
+	BatchSize    int           `yaml:"batch_size"`
+
+	// RetryBackoff é a espera após a primeira falha de entrega, dobrada a cada
+	// nova falha até RetryMaxBackoff
+	RetryBackoff    time.Duration `yaml:"retry_backoff"`
+	RetryMaxBackoff time.Duration `yaml:"retry_max_backoff"`
+
+	// Retention é por quanto tempo os eventos entregues ficam no outbox
+	Retention time.Duration `yaml:"retention"`
+}
+
+type WebhooksConfig struct {
+	// Timeout limita cada requisição à URL de uma assinatura
+	Timeout time.Duration `yaml:"timeout"`
+
+	// MaxAttempts é o número de falhas após o qual a entrega passa a dead e
+	// só volta a ser enviada por replay
+	MaxAttempts int `yaml:"max_attempts"`
+
+	// RetryBackoff é a espera após a primeira falha, dobrada a cada nova falha
+	// até RetryMaxBackoff
+	RetryBackoff    time.Duration `yaml:"retry_backoff"`
+	RetryMaxBackoff time.Duration `yaml:"retry_max_backoff"`
+
+	PollInterval time.Duration `yaml:"poll_interval"`
+	BatchSize    int           `yaml:"batch_size"`
+
+	// Retention é por quanto tempo as entregas concluídas ficam no log
+	Retention time.Duration `yaml:"retention"`
+
+	// AllowPrivateNetworks permite assinaturas e entregas para loopback e
+	// redes privadas; desabilitado, protege a rede interna contra SSRF
+	AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+}
+
+type StreamConfig struct {
+	// HeartbeatInterval é o intervalo entre os comentários que mantêm as
+	// conexões do stream abertas em proxies e balanceadores
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"`
+
+	// BufferSize é o número de eventos que aguardam envio a um cliente; o
+	// cliente que o deixa encher é desconectado e deve reconectar com Last-Event-ID
+	BufferSize int `yaml:"buffer_size"`
+}
+
+type SyncConfig struct {
+	// TombstoneRetention é por quanto tempo as remoções ficam disponíveis para
+	// a sincronização; tokens mais antigos exigem uma sincronização completa
+	TombstoneRetention time.Duration `yaml:"tombstone_retention"`
+}
+
+type GRPCConfig struct {
+	// Addr é o endereço do servidor gRPC, separado do servidor HTTP
+	Addr string `yaml:"addr"`
+
+	// Reflection expõe o serviço de reflection usado por grpcurl e afins
+	Reflection bool `yaml:"reflection"`
+}
+
+type FeaturesConfig struct {
+	Swagger     bool `yaml:"swagger"`
+	Metrics     bool `yaml:"metrics"`
+	RateLimit   bool `yaml:"rate_limit"`
+	Idempotency bool `yaml:"idempotency"`
+	Cache       bool `yaml:"cache"`
+	Events      bool `yaml:"events"`
+	Webhooks    bool `yaml:"webhooks"`
+	Stream      bool `yaml:"stream"`
+	CardDAV     bool `yaml:"carddav"`
+	GRPC        bool `yaml:"grpc"`
+}
+
+// Default retorna a configuração usada quando nada é informado
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:               "0.0.0.0:8080",
+			TrustedProxies:     []string{"127.0.0.1"},
+			ReadTimeout:        15 * time.Second,
+			ReadHeaderTimeout:  5 * time.Second,
+			WriteTimeout:       30 * time.Second,
+			IdleTimeout:        60 * time.Second,
+			ShutdownDelay:      5 * time.Second,
+			ShutdownTimeout:    20 * time.Second,
+			HealthCheckTimeout: 2 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:                "postgres",
+			Path:                  "contacts.db",
+			Host:                  "localhost",
+			Port:                  5432,
+			Name:                  "contactsdb",
+			SSLMode:               "require",
+			ReplicaURLs:           []string{},
+			ReplicaStickyWindow:   5 * time.Second,
+			ReplicaHealthInterval: 5 * time.Second,
+			MaxOpenConns:          10,
+			MaxIdleConns:          5,
+			ConnMaxLifetime:       30 * time.Minute,
+			ConnMaxIdleTime:       5 * time.Minute,
+			ConnectTimeout:        5 * time.Second,
+			ConnectRetries:        10,
+			ConnectBackoff:        500 * time.Millisecond,
+			ConnectMaxBackoff:     10 * time.Second,
+			QueryTimeout:          5 * time.Second,
+			OperationTimeouts: map[string]time.Duration{
+				"find_all": 10 * time.Second,
+			},
+		},
+		Migrations: MigrationsConfig{
+			Mode: "apply",
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "go-contacts-api",
+			SampleRatio: 1,
+		},
+		Tenant: TenantConfig{
+			DefaultID: tenant.DefaultID,
+		},
+		RateLimit: RateLimitConfig{
+			Store: "memory",
+			Rate:  10,
+			Burst: 60,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL: 24 * time.Hour,
+		},
+		Cache: CacheConfig{
+			Size: 10000,
+			TTL:  30 * time.Second,
+		},
+		Events: EventsConfig{
+			Sinks:           []string{"log"},
+			PollInterval:    time.Second,
+			BatchSize:       100,
+			RetryBackoff:    time.Second,
+			RetryMaxBackoff: 5 * time.Minute,
+			Retention:       7 * 24 * time.Hour,
+		},
+		Webhooks: WebhooksConfig{
+			Timeout:         10 * time.Second,
+			MaxAttempts:     10,
+			RetryBackoff:    30 * time.Second,
+			RetryMaxBackoff: time.Hour,
+			PollInterval:    time.Second,
+			BatchSize:       20,
+			Retention:       30 * 24 * time.Hour,
+		},
+		Stream: StreamConfig{
+			HeartbeatInterval: 15 * time.Second,
+			BufferSize:        256,
+		},
+		Sync: SyncConfig{
+			TombstoneRetention: 30 * 24 * time.Hour,
+		},
+		GRPC: GRPCConfig{
+			Addr:       "0.0.0.0:9090",
+			Reflection: true,
+		},
+		Features: FeaturesConfig{
+			Swagger:     true,
+			Metrics:     true,
+			RateLimit:   true,
+			Idempotency: true,
+		},
+	}
+}
+
+// Load monta a configuração a partir dos padrões, do arquivo YAML opcional
+// (CONFIG_FILE) e das variáveis de ambiente, e a valida
+func Load() (Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := loadEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+func loadFile(path string, cfg *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("erro ao ler arquivo de configuração %s: %v", path, err)
+	}
+
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil {
+		return fmt.Errorf("erro ao interpretar arquivo de configuração %s: %v", path, err)
+	}
+
+	return nil
+}
+
+func loadEnv(cfg *Config) error {
+	var errs []string
+
+	setString := func(name string, target *string) {
+		if value, ok := os.LookupEnv(name); ok {
+			*target = value
+		}
+	}
+	setInt := func(name string, target *int) {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s deve ser um número inteiro", name))
+				return
+			}
+			*target = parsed
+		}
+	}
+	setFloat := func(name string, target *float64) {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s deve ser um número", name))
+				return
+			}
+			*target = parsed
+		}
+	}
+	setBool := func(name string, target *bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s deve ser true ou false", name))
+				return
+			}
+			*target = parsed
+		}
+	}
+	setDuration := func(name string, target *time.Duration) {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s deve ser uma duração (ex.: 30s, 24h)", name))
+				return
+			}
+			*target = parsed
+		}
+	}
+	setDurationMap := func(name string, target *map[string]time.Duration) {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := parseDurationMap(value)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s deve estar no formato operacao=duracao,... (ex.: find_all=10s)", name))
+				return
+			}
+			if *target == nil {
+				*target = map[string]time.Duration{}
+			}
+			for key, duration := range parsed {
+				(*target)[key] = duration
+			}
+		}
+	}
+	setList := func(name string, target *[]string) {
+		if value, ok := os.LookupEnv(name); ok {
+			*target = splitList(value)
+		}
+	}
+
+	setString("HTTP_ADDR", &cfg.Server.Addr)
+	setList("TRUSTED_PROXIES", &cfg.Server.TrustedProxies)
+	setDuration("HTTP_READ_TIMEOUT", &cfg.Server.ReadTimeout)
+	setDuration("HTTP_READ_HEADER_TIMEOUT", &cfg.Server.ReadHeaderTimeout)
+	setDuration("HTTP_WRITE_TIMEOUT", &cfg.Server.WriteTimeout)
+	setDuration("HTTP_IDLE_TIMEOUT", &cfg.Server.IdleTimeout)
+	setDuration("HTTP_SHUTDOWN_DELAY", &cfg.Server.ShutdownDelay)
+	setDuration("HTTP_SHUTDOWN_TIMEOUT", &cfg.Server.ShutdownTimeout)
+	setDuration("HEALTH_CHECK_TIMEOUT", &cfg.Server.HealthCheckTimeout)
+
+	setString("DB_DRIVER", &cfg.Database.Driver)
+	setString("SQLITE_PATH", &cfg.Database.Path)
+	setString("DATABASE_URL", &cfg.Database.URL)
+	setList("DATABASE_REPLICA_URLS", &cfg.Database.ReplicaURLs)
+	setDuration("DB_REPLICA_STICKY_WINDOW", &cfg.Database.ReplicaStickyWindow)
+	setDuration("DB_REPLICA_HEALTH_INTERVAL", &cfg.Database.ReplicaHealthInterval)
+	setString("POSTGRES_HOST", &cfg.Database.Host)
+	setInt("POSTGRES_PORT", &cfg.Database.Port)
+	setString("POSTGRES_USERNAME", &cfg.Database.User)
+	setString("POSTGRES_PASSWORD", &cfg.Database.Password)
+	setString("POSTGRES_DATABASE", &cfg.Database.Name)
+	setString("POSTGRES_SSLMODE", &cfg.Database.SSLMode)
+	setString("POSTGRES_SSLROOTCERT", &cfg.Database.SSLRootCert)
+	setString("POSTGRES_SSLCERT", &cfg.Database.SSLCert)
+	setString("POSTGRES_SSLKEY", &cfg.Database.SSLKey)
+	setInt("DB_MAX_OPEN_CONNS", &cfg.Database.MaxOpenConns)
+	setInt("DB_MAX_IDLE_CONNS", &cfg.Database.MaxIdleConns)
+	setDuration("DB_CONN_MAX_LIFETIME", &cfg.Database.ConnMaxLifetime)
+	setDuration("DB_CONN_MAX_IDLE_TIME", &cfg.Database.ConnMaxIdleTime)
+	setDuration("DB_CONNECT_TIMEOUT", &cfg.Database.ConnectTimeout)
+	setInt("DB_CONNECT_RETRIES", &cfg.Database.ConnectRetries)
+	setDuration("DB_CONNECT_BACKOFF", &cfg.Database.ConnectBackoff)
+	setDuration("DB_CONNECT_MAX_BACKOFF", &cfg.Database.ConnectMaxBackoff)
+	setDuration("DB_QUERY_TIMEOUT", &cfg.Database.QueryTimeout)
+	setDurationMap("DB_OPERATION_TIMEOUTS", &cfg.Database.OperationTimeouts)
+
+	setString("MIGRATIONS_MODE", &cfg.Migrations.Mode)
+	setBool("MIGRATIONS_ALLOW_DRIFT", &cfg.Migrations.AllowDrift)
+
+	setString("LOG_LEVEL", &cfg.Log.Level)
+	setString("LOG_FORMAT", &cfg.Log.Format)
+
+	setString("TRACING_EXPORTER", &cfg.Tracing.Exporter)
+	setString("TRACING_OTLP_ENDPOINT", &cfg.Tracing.Endpoint)
+	setString("OTEL_SERVICE_NAME", &cfg.Tracing.ServiceName)
+	setFloat("TRACING_SAMPLE_RATIO", &cfg.Tracing.SampleRatio)
+
+	setString("DEFAULT_TENANT_ID", &cfg.Tenant.DefaultID)
+	setBool("TENANT_RLS_ENABLED", &cfg.Tenant.EnforceRLS)
+
+	if value, ok := os.LookupEnv("AUTH_API_KEYS"); ok {
+		keys, err := parseAPIKeys(value)
+		if err != nil {
+			errs = append(errs, "AUTH_API_KEYS deve estar no formato tenant=chave,...")
+		} else {
+			cfg.Auth.APIKeys = keys
+		}
+	}
+
+	setString("RATE_LIMIT_STORE", &cfg.RateLimit.Store)
+	setFloat("RATE_LIMIT_RATE", &cfg.RateLimit.Rate)
+	setFloat("RATE_LIMIT_BURST", &cfg.RateLimit.Burst)
+
+	setDuration("IDEMPOTENCY_TTL", &cfg.Idempotency.TTL)
+
+	setInt("CACHE_SIZE", &cfg.Cache.Size)
+	setDuration("CACHE_TTL", &cfg.Cache.TTL)
+
+	setList("EVENTS_SINKS", &cfg.Events.Sinks)
+	setDuration("EVENTS_POLL_INTERVAL", &cfg.Events.PollInterval)
+	setInt("EVENTS_BATCH_SIZE", &cfg.Events.BatchSize)
+	setDuration("EVENTS_RETRY_BACKOFF", &cfg.Events.RetryBackoff)
+	setDuration("EVENTS_RETRY_MAX_BACKOFF", &cfg.Events.RetryMaxBackoff)
+	setDuration("EVENTS_RETENTION", &cfg.Events.Retention)
+
+	setDuration("WEBHOOKS_TIMEOUT", &cfg.Webhooks.Timeout)
+	setInt("WEBHOOKS_MAX_ATTEMPTS", &cfg.Webhooks.MaxAttempts)
+	setDuration("WEBHOOKS_RETRY_BACKOFF", &cfg.Webhooks.RetryBackoff)
+	setDuration("WEBHOOKS_RETRY_MAX_BACKOFF", &cfg.Webhooks.RetryMaxBackoff)
+	setDuration("WEBHOOKS_POLL_INTERVAL", &cfg.Webhooks.PollInterval)
+	setInt("WEBHOOKS_BATCH_SIZE", &cfg.Webhooks.BatchSize)
+	setDuration("WEBHOOKS_RETENTION", &cfg.Webhooks.Retention)
+	setBool("WEBHOOKS_ALLOW_PRIVATE_NETWORKS", &cfg.Webhooks.AllowPrivateNetworks)
+
+	setDuration("STREAM_HEARTBEAT_INTERVAL", &cfg.Stream.HeartbeatInterval)
+	setInt("STREAM_BUFFER_SIZE", &cfg.Stream.BufferSize)
+
+	setDuration("SYNC_TOMBSTONE_RETENTION", &cfg.Sync.TombstoneRetention)
+
+	setString("GRPC_ADDR", &cfg.GRPC.Addr)
+	setBool("GRPC_REFLECTION", &cfg.GRPC.Reflection)
+
+	setBool("FEATURE_SWAGGER", &cfg.Features.Swagger)
+	setBool("FEATURE_METRICS", &cfg.Features.Metrics)
+	setBool("FEATURE_RATE_LIMIT", &cfg.Features.RateLimit)
+	setBool("FEATURE_IDEMPOTENCY", &cfg.Features.Idempotency)
+	setBool("FEATURE_CACHE", &cfg.Features.Cache)
+	setBool("FEATURE_EVENTS", &cfg.Features.Events)
+	setBool("FEATURE_WEBHOOKS", &cfg.Features.Webhooks)
+	setBool("FEATURE_STREAM", &cfg.Features.Stream)
+	setBool("FEATURE_CARDDAV", &cfg.Features.CardDAV)
+	setBool("FEATURE_GRPC", &cfg.Features.GRPC)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("variáveis de ambiente inválidas: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// Validate verifica se a configuração é consistente
+func (c Config) Validate() error {
+	var errs []string
+
+	if _, _, err := net.SplitHostPort(c.Server.Addr); err != nil {
+		errs = append(errs, fmt.Sprintf("server.addr inválido: %v", err))
+	}
+	if c.Server.ReadTimeout <= 0 || c.Server.ReadHeaderTimeout <= 0 || c.Server.WriteTimeout <= 0 ||
+		c.Server.IdleTimeout <= 0 || c.Server.ShutdownTimeout <= 0 || c.Server.HealthCheckTimeout <= 0 {
+		errs = append(errs, "os timeouts do servidor devem ser positivos")
+	}
+	if c.Server.ShutdownDelay < 0 {
+		errs = append(errs, "server.shutdown_delay não pode ser negativo")
+	}
+
+	switch c.Database.Driver {
+	case "postgres":
+		errs = append(errs, c.Database.validatePostgres()...)
+	case "sqlite":
+		if c.Database.Path == "" {
+			errs = append(errs, "database.path é obrigatório com o driver sqlite")
+		}
+		if c.Tenant.EnforceRLS {
+			errs = append(errs, "tenant.enforce_rls requer o driver postgres")
+		}
+		if c.RateLimit.Store == "postgres" {
+			errs = append(errs, "rate_limit.store postgres requer o driver postgres")
+		}
+		if len(c.Database.ReplicaURLs) > 0 {
+			errs = append(errs, "database.replica_urls requer o driver postgres")
+		}
+	default:
+		errs = append(errs, "database.driver deve ser postgres ou sqlite")
+	}
+
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 {
+		errs = append(errs, "os tamanhos do pool não podem ser negativos")
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		errs = append(errs, "database.max_idle_conns não pode ser maior que database.max_open_conns")
+	}
+	if c.Database.ConnMaxLifetime < 0 || c.Database.ConnMaxIdleTime < 0 {
+		errs = append(errs, "database.conn_max_lifetime e database.conn_max_idle_time não podem ser negativos")
+	}
+	if c.Database.ConnectTimeout <= 0 {
+		errs = append(errs, "database.connect_timeout deve ser positivo")
+	}
+	if c.Database.ConnectRetries < 0 {
+		errs = append(errs, "database.connect_retries não pode ser negativo")
+	}
+	if c.Database.ConnectBackoff <= 0 || c.Database.ConnectMaxBackoff < c.Database.ConnectBackoff {
+		errs = append(errs, "database.connect_backoff deve ser positivo e não maior que database.connect_max_backoff")
+	}
+	if c.Database.QueryTimeout < 0 {
+		errs = append(errs, "database.query_timeout não pode ser negativo")
+	}
+	for operation, timeout := range c.Database.OperationTimeouts {
+		switch operation {
+		case "create", "find_all", "find_by_id", "find_changes", "update", "delete":
+		default:
+			errs = append(errs, fmt.Sprintf("database.operation_timeouts: operação desconhecida %q", operation))
+		}
+		if timeout < 0 {
+			errs = append(errs, fmt.Sprintf("database.operation_timeouts.%s não pode ser negativo", operation))
+		}
+	}
+
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, "log.level deve ser debug, info, warn ou error")
+	}
+	switch c.Migrations.Mode {
+	case "apply", "verify", "skip":
+	default:
+		errs = append(errs, "migrations.mode deve ser apply, verify ou skip")
+	}
+
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		errs = append(errs, "log.format deve ser json ou text")
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		errs = append(errs, "tracing.exporter deve ser otlp, stdout ou none")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, "tracing.sample_ratio deve estar entre 0 e 1")
+	}
+	if c.Tracing.ServiceName == "" {
+		errs = append(errs, "tracing.service_name é obrigatório")
+	}
+
+	if c.Tenant.DefaultID != "" && !tenant.IsValid(c.Tenant.DefaultID) {
+		errs = append(errs, "tenant.default_id inválido")
+	}
+
+	seenKeys := map[string]bool{}
+	for i, key := range c.Auth.APIKeys {
+		if !tenant.IsValid(key.Tenant) {
+			errs = append(errs, fmt.Sprintf("auth.api_keys[%d].tenant inválido", i))
+		}
+		if len(key.Key) < minAPIKeyLength {
+			errs = append(errs, fmt.Sprintf("auth.api_keys[%d].key deve ter pelo menos %d caracteres", i, minAPIKeyLength))
+		}
+		if seenKeys[key.Key] {
+			errs = append(errs, fmt.Sprintf("auth.api_keys[%d].key repete uma chave anterior", i))
+		}
+		seenKeys[key.Key] = true
+	}
+
+	switch c.RateLimit.Store {
+	case "memory", "postgres":
+	default:
+		errs = append(errs, "rate_limit.store deve ser memory ou postgres")
+	}
+	if c.RateLimit.Rate <= 0 || c.RateLimit.Burst < 1 {
+		errs = append(errs, "rate_limit.rate deve ser positivo e rate_limit.burst pelo menos 1")
+	}
+
+	if c.Idempotency.TTL <= 0 {
+		errs = append(errs, "idempotency.ttl deve ser positivo")
+	}
+
+	if c.Cache.Size <= 0 || c.Cache.TTL <= 0 {
+		errs = append(errs, "cache.size e cache.ttl devem ser positivos")
+	}
+
+	for _, sink := range c.Events.Sinks {
+		if sink != "log" {
+			errs = append(errs, fmt.Sprintf("events.sinks: sink desconhecido %q", sink))
+		}
+	}
+	if c.Events.PollInterval <= 0 || c.Events.BatchSize <= 0 || c.Events.Retention <= 0 {
+		errs = append(errs, "events.poll_interval, events.batch_size e events.retention devem ser positivos")
+	}
+	if c.Events.RetryBackoff <= 0 || c.Events.RetryMaxBackoff < c.Events.RetryBackoff {
+		errs = append(errs, "events.retry_backoff deve ser positivo e não maior que events.retry_max_backoff")
+	}
+
+	if c.Features.Webhooks && !c.Features.Events {
+		errs = append(errs, "features.webhooks requer features.events, que publica os eventos entregues")
+	}
+	// Sem credencial, o tenant viria do cabeçalho X-Tenant-ID e qualquer cliente
+	// leria ou alteraria os dados de outro tenant
+	if c.Features.Webhooks && len(c.Auth.APIKeys) == 0 {
+		errs = append(errs, "features.webhooks requer auth.api_keys, que autenticam o tenant das assinaturas")
+	}
+	if c.Features.CardDAV && len(c.Auth.APIKeys) == 0 {
+		errs = append(errs, "features.carddav requer auth.api_keys, que autenticam o tenant do catálogo")
+	}
+	if c.Webhooks.Timeout <= 0 || c.Webhooks.PollInterval <= 0 || c.Webhooks.BatchSize <= 0 || c.Webhooks.Retention <= 0 {
+		errs = append(errs, "webhooks.timeout, webhooks.poll_interval, webhooks.batch_size e webhooks.retention devem ser positivos")
+	}
+	if c.Webhooks.MaxAttempts < 1 {
+		errs = append(errs, "webhooks.max_attempts deve ser pelo menos 1")
+	}
+	if c.Webhooks.RetryBackoff <= 0 || c.Webhooks.RetryMaxBackoff < c.Webhooks.RetryBackoff {
+		errs = append(errs, "webhooks.retry_backoff deve ser positivo e não maior que webhooks.retry_max_backoff")
+	}
+
+	if c.Features.Stream && !c.Features.Events {
+		errs = append(errs, "features.stream requer features.events, que publica os eventos transmitidos")
+	}
+	if c.Stream.HeartbeatInterval <= 0 || c.Stream.BufferSize <= 0 {
+		errs = append(errs, "stream.heartbeat_interval e stream.buffer_size devem ser positivos")
+	}
+
+	if c.Sync.TombstoneRetention <= 0 {
+		errs = append(errs, "sync.tombstone_retention deve ser positivo")
+	}
+
+	if c.Features.GRPC {
+		if _, _, err := net.SplitHostPort(c.GRPC.Addr); err != nil {
+			errs = append(errs, fmt.Sprintf("grpc.addr inválido: %v", err))
+		} else if c.GRPC.Addr == c.Server.Addr {
+			errs = append(errs, "grpc.addr deve ser diferente de server.addr")
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("configuração inválida: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// validatePostgres verifica os campos de conexão usados pelo driver postgres
+func (d DatabaseConfig) validatePostgres() []string {
+	var errs []string
+
+	if d.URL != "" {
+		// Strings no formato chave=valor não recebem as opções de TLS e
+		// não podem ter a senha ocultada com segurança nos logs
+		if !isPostgresURL(d.URL) {
+			errs = append(errs, "database.url deve ser uma URL postgres://")
+		}
+	} else {
+		if d.Host == "" {
+			errs = append(errs, "database.host é obrigatório")
+		}
+		if d.Port <= 0 || d.Port > 65535 {
+			errs = append(errs, "database.port deve estar entre 1 e 65535")
+		}
+		if d.User == "" {
+			errs = append(errs, "database.user é obrigatório")
+		}
+		if d.Name == "" {
+			errs = append(errs, "database.name é obrigatório")
+		}
+	}
+
+	switch d.SSLMode {
+	case "disable", "require", "verify-ca", "verify-full":
+	default:
+		errs = append(errs, "database.sslmode deve ser disable, require, verify-ca ou verify-full")
+	}
+	if (d.SSLCert == "") != (d.SSLKey == "") {
+		errs = append(errs, "database.sslcert e database.sslkey devem ser informados juntos")
+	}
+	if d.SSLMode == "disable" && (d.SSLRootCert != "" || d.SSLCert != "") {
+		errs = append(errs, "os certificados TLS exigem database.sslmode diferente de disable")
+	}
+
+	for i, replica := range d.ReplicaURLs {
+		if !isPostgresURL(replica) {
+			errs = append(errs, fmt.Sprintf("database.replica_urls[%d] deve ser uma URL postgres://", i))
+		}
+	}
+	if d.ReplicaStickyWindow < 0 {
+		errs = append(errs, "database.replica_sticky_window não pode ser negativo")
+	}
+	if d.ReplicaHealthInterval <= 0 {
+		errs = append(errs, "database.replica_health_interval deve ser positivo")
+	}
+
+	return errs
+}
+
+// isPostgresURL informa se a string de conexão é uma URL postgres://
+func isPostgresURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && (parsed.Scheme == "postgres" || parsed.Scheme == "postgresql")
+}
+
+// DSN retorna a string de conexão com o PostgreSQL. As opções de TLS
+// completam a URL quando ela não define os mesmos parâmetros.
+func (d DatabaseConfig) DSN() string {
+	if d.URL != "" {
+		return d.withTLS(d.URL)
+	}
+
+	query := d.tlsParams()
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.User, d.Password),
+		Host:     net.JoinHostPort(d.Host, strconv.Itoa(d.Port)),
+		Path:     "/" + d.Name,
+		RawQuery: query.Encode(),
+	}
+
+	return dsn.String()
+}
+
+// ReplicaDSNs retorna as strings de conexão das réplicas de leitura, com as
+// mesmas opções de TLS do primário
+func (d DatabaseConfig) ReplicaDSNs() []string {
+	dsns := make([]string, 0, len(d.ReplicaURLs))
+	for _, replica := range d.ReplicaURLs {
+		dsns = append(dsns, d.withTLS(replica))
+	}
+	return dsns
+}
+
+// withTLS completa a URL com as opções de TLS que ela não define
+func (d DatabaseConfig) withTLS(rawURL string) string {
+	if !isPostgresURL(rawURL) {
+		return rawURL
+	}
+	parsed, _ := url.Parse(rawURL)
+
+	query := parsed.Query()
+	for key, value := range d.tlsParams() {
+		if !query.Has(key) {
+			query[key] = value
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+// tlsParams retorna os parâmetros de TLS da string de conexão
+func (d DatabaseConfig) tlsParams() url.Values {
+	query := url.Values{}
+	query.Set("sslmode", d.SSLMode)
+	if d.SSLRootCert != "" {
+		query.Set("sslrootcert", d.SSLRootCert)
+	}
+	if d.SSLCert != "" {
+		query.Set("sslcert", d.SSLCert)
+	}
+	if d.SSLKey != "" {
+		query.Set("sslkey", d.SSLKey)
+	}
+	return query
+}
+
+// Redacted retorna uma cópia da configuração sem segredos, própria para logs
+func (c Config) Redacted() Config {
+	if c.Database.Password != "" {
+		c.Database.Password = redacted
+	}
+
+	if c.Database.URL != "" {
+		c.Database.URL = redactURL(c.Database.URL)
+	}
+
+	replicas := make([]string, 0, len(c.Database.ReplicaURLs))
+	for _, replica := range c.Database.ReplicaURLs {
+		replicas = append(replicas, redactURL(replica))
+	}
+	c.Database.ReplicaURLs = replicas
+
+	keys := make([]APIKey, 0, len(c.Auth.APIKeys))
+	for _, key := range c.Auth.APIKeys {
+		keys = append(keys, APIKey{Tenant: key.Tenant, Key: redacted})
+	}
+	c.Auth.APIKeys = keys
+
+	return c
+}
+
+// redactURL oculta a senha de uma URL de conexão, inclusive a informada no
+// parâmetro password. Qualquer outro formato é ocultado por inteiro.
+func redactURL(rawURL string) string {
+	if !isPostgresURL(rawURL) {
+		return redacted
+	}
+	parsed, _ := url.Parse(rawURL)
+
+	if _, ok := parsed.User.Password(); ok {
+		parsed.User = url.UserPassword(parsed.User.Username(), redacted)
+	}
+	if query := parsed.Query(); query.Has("password") {
+		query.Set("password", redacted)
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String()
+}
+
+// String formata a configuração sem segredos em YAML
+func (c Config) String() string {
+	out, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Sprintf("erro ao formatar configuração: %v", err)
+	}
+	return string(out)
+}
+
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseAPIKeys interpreta listas no formato "tenant=chave,tenant=chave"
+func parseAPIKeys(value string) ([]APIKey, error) {
+	keys := []APIKey{}
+	for _, item := range splitList(value) {
+		id, key, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("item sem '='")
+		}
+		keys = append(keys, APIKey{Tenant: strings.TrimSpace(id), Key: strings.TrimSpace(key)})
+	}
+	return keys, nil
+}
+
+// parseDurationMap interpreta listas no formato "chave=duração,chave=duração"
+func parseDurationMap(value string) (map[string]time.Duration, error) {
+	parsed := map[string]time.Duration{}
+	for _, item := range splitList(value) {
+		key, raw, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("item sem '=': %s", item)
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, err
+		}
+		parsed[strings.TrimSpace(key)] = duration
+	}
+	return parsed, nil
+}
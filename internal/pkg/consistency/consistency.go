@@ -0,0 +1,89 @@
+// Package consistency decide quando as leituras de um cliente precisam ir ao
+// primário para que ele enxergue as próprias escritas
+package consistency
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// HeaderName e CookieName carregam o instante, em milissegundos desde a
+	// época Unix, até o qual o cliente deve ler do primário
+	HeaderName = "X-Read-Primary-Until"
+	CookieName = "read_primary_until"
+)
+
+type primaryKey struct{}
+
+// WithPrimary marca o contexto para que as leituras usem o primário
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// UsePrimary informa se o contexto foi marcado com WithPrimary
+func UsePrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// Middleware garante que o cliente enxergue as próprias alterações mesmo com
+// réplicas atrasadas. Cada requisição que altera dados devolve, no cabeçalho
+// X-Read-Primary-Until e num cookie, o fim da janela em que as leituras desse
+// cliente usam o primário. Clientes sem suporte a cookies podem reenviar o
+// cabeçalho. Valores além da janela são ignorados, para que um cliente não
+// fixe as leituras no primário indefinidamente.
+func Middleware(window time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		now := time.Now()
+
+		if until, ok := readPrimaryUntil(c); ok && until.After(now) && !until.After(now.Add(window)) {
+			c.Request = c.Request.WithContext(WithPrimary(c.Request.Context()))
+		}
+
+		if isMutation(c.Request.Method) && window > 0 {
+			value := strconv.FormatInt(now.Add(window).UnixMilli(), 10)
+			c.Header(HeaderName, value)
+			http.SetCookie(c.Writer, &http.Cookie{
+				Name:     CookieName,
+				Value:    value,
+				Path:     "/",
+				MaxAge:   int(math.Ceil(window.Seconds())),
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		c.Next()
+	}
+}
+
+func readPrimaryUntil(c *gin.Context) (time.Time, bool) {
+	value := c.GetHeader(HeaderName)
+	if value == "" {
+		cookie, err := c.Cookie(CookieName)
+		if err != nil {
+			return time.Time{}, false
+		}
+		value = cookie
+	}
+
+	millis, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(millis), true
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package consistency_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/consistency"
+	"github.com/gin-gonic/gin"
+)
+
+func newRouter(window time.Duration) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(consistency.Middleware(window))
+	handler := func(c *gin.Context) {
+		c.String(http.StatusOK, strconv.FormatBool(consistency.UsePrimary(c.Request.Context())))
+	}
+	router.GET("/contacts", handler)
+	router.POST("/contacts", handler)
+	return router
+}
+
+func serve(router http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddlewareStickinessAfterMutation(t *testing.T) {
+	router := newRouter(5 * time.Second)
+
+	if rec := serve(router, httptest.NewRequest(http.MethodGet, "/contacts", nil)); rec.Body.String() != "false" {
+		t.Fatal("leitura sem alteração prévia usou o primário")
+	}
+
+	rec := serve(router, httptest.NewRequest(http.MethodPost, "/contacts", nil))
+	until := rec.Header().Get(consistency.HeaderName)
+	cookies := rec.Result().Cookies()
+	if until == "" || len(cookies) != 1 || cookies[0].Value != until {
+		t.Fatalf("alteração não devolveu a janela: cabeçalho %q, cookies %v", until, cookies)
+	}
+
+	withHeader := httptest.NewRequest(http.MethodGet, "/contacts", nil)
+	withHeader.Header.Set(consistency.HeaderName, until)
+	if rec := serve(router, withHeader); rec.Body.String() != "true" {
+		t.Fatal("leitura com o cabeçalho não usou o primário")
+	}
+
+	withCookie := httptest.NewRequest(http.MethodGet, "/contacts", nil)
+	withCookie.AddCookie(cookies[0])
+	if rec := serve(router, withCookie); rec.Body.String() != "true" {
+		t.Fatal("leitura com o cookie não usou o primário")
+	}
+}
+
+func TestMiddlewareIgnoresInvalidWindow(t *testing.T) {
+	router := newRouter(5 * time.Second)
+
+	for name, until := range map[string]time.Time{
+		"Expirada":     time.Now().Add(-time.Second),
+		"AlémDaJanela": time.Now().Add(time.Hour),
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/contacts", nil)
+			req.Header.Set(consistency.HeaderName, strconv.FormatInt(until.UnixMilli(), 10))
+			if rec := serve(router, req); rec.Body.String() != "false" {
+				t.Fatal("janela inválida fez a leitura usar o primário")
+			}
+		})
+	}
+}
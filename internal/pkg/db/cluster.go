@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/config"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/consistency"
+)
+
+// Cluster reúne o pool do primário e os pools das réplicas de leitura. As
+// escritas usam sempre Primary; as leituras que toleram atraso de replicação
+// usam Reader.
+type Cluster struct {
+	Primary *sql.DB
+
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+type replica struct {
+	db      *sql.DB
+	index   int
+	healthy atomic.Bool
+	checked atomic.Bool
+}
+
+// ConnectCluster conecta ao primário com Connect e abre um pool para cada
+// réplica configurada. Uma réplica indisponível não impede a inicialização:
+// ela fica fora do roteamento até passar em CheckReplicas.
+func ConnectCluster(ctx context.Context, cfg config.DatabaseConfig) (*Cluster, error) {
+	primary, err := Connect(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := &Cluster{Primary: primary}
+	for i, dsn := range cfg.ReplicaDSNs() {
+		database, err := sql.Open("postgres", dsn)
+		if err != nil {
+			cluster.Close()
+			return nil, fmt.Errorf("falha ao abrir conexão com a réplica %d: %v", i, err)
+		}
+
+		database.SetMaxOpenConns(cfg.MaxOpenConns)
+		database.SetMaxIdleConns(cfg.MaxIdleConns)
+		database.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		database.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+		cluster.replicas = append(cluster.replicas, &replica{db: database, index: i})
+	}
+
+	if len(cluster.replicas) > 0 {
+		cluster.CheckReplicas(ctx, cfg.ConnectTimeout)
+	}
+
+	return cluster, nil
+}
+
+// Reader retorna o pool para uma leitura: uma réplica saudável, em rodízio,
+// ou o primário quando o contexto foi marcado com consistency.WithPrimary ou
+// nenhuma réplica está disponível
+func (c *Cluster) Reader(ctx context.Context) *sql.DB {
+	if len(c.replicas) == 0 || consistency.UsePrimary(ctx) {
+		return c.Primary
+	}
+
+	start := c.next.Add(1)
+	for i := range c.replicas {
+		replica := c.replicas[(start+uint64(i))%uint64(len(c.replicas))]
+		if replica.healthy.Load() {
+			return replica.db
+		}
+	}
+
+	return c.Primary
+}
+
+// Replicas retorna os pools das réplicas, na ordem da configuração
+func (c *Cluster) Replicas() []*sql.DB {
+	replicas := make([]*sql.DB, 0, len(c.replicas))
+	for _, replica := range c.replicas {
+		replicas = append(replicas, replica.db)
+	}
+	return replicas
+}
+
+// CheckReplicas verifica cada réplica e atualiza quais podem receber leituras.
+// Retorna erro apenas quando nenhuma réplica está disponível.
+func (c *Cluster) CheckReplicas(ctx context.Context, timeout time.Duration) error {
+	available := 0
+	for _, replica := range c.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := replica.db.PingContext(pingCtx)
+		cancel()
+
+		// Registra apenas as mudanças de estado, e a falha já na primeira verificação
+		healthy := err == nil
+		changed := replica.healthy.Swap(healthy) != healthy
+		if first := !replica.checked.Swap(true); changed || (first && !healthy) {
+			if healthy {
+				slog.Info("Réplica de leitura disponível", "replica", replica.index)
+			} else {
+				slog.Warn("Réplica de leitura indisponível, leituras seguem para o primário", "replica", replica.index, "error", err)
+			}
+		}
+		if healthy {
+			available++
+		}
+	}
+
+	if len(c.replicas) > 0 && available == 0 {
+		return errors.New("nenhuma réplica de leitura disponível")
+	}
+	return nil
+}
+
+// Close fecha os pools do primário e das réplicas
+func (c *Cluster) Close() error {
+	var errs []error
+	for _, replica := range c.replicas {
+		errs = append(errs, replica.db.Close())
+	}
+	errs = append(errs, c.Primary.Close())
+	return errors.Join(errs...)
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/consistency"
+)
+
+func openSQLite(t *testing.T, name string) *sql.DB {
+	t.Helper()
+
+	database, err := sql.Open("sqlite", sqliteDSN(filepath.Join(t.TempDir(), name)))
+	if err != nil {
+		t.Fatalf("erro ao abrir banco de testes: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func TestClusterReader(t *testing.T) {
+	ctx := context.Background()
+	primary := openSQLite(t, "primary.db")
+	first, second := openSQLite(t, "first.db"), openSQLite(t, "second.db")
+
+	cluster := &Cluster{Primary: primary, replicas: []*replica{{db: first}, {db: second, index: 1}}}
+	if err := cluster.CheckReplicas(ctx, time.Second); err != nil {
+		t.Fatalf("CheckReplicas: %v", err)
+	}
+
+	seen := map[*sql.DB]int{}
+	for i := 0; i < 4; i++ {
+		seen[cluster.Reader(ctx)]++
+	}
+	if seen[first] != 2 || seen[second] != 2 {
+		t.Fatalf("leituras não alternaram entre as réplicas: %v", seen)
+	}
+
+	if got := cluster.Reader(consistency.WithPrimary(ctx)); got != primary {
+		t.Fatal("contexto marcado com WithPrimary não usou o primário")
+	}
+
+	// Uma réplica indisponível sai do rodízio; sem nenhuma, lê do primário
+	first.Close()
+	if err := cluster.CheckReplicas(ctx, time.Second); err != nil {
+		t.Fatalf("CheckReplicas com uma réplica disponível: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if got := cluster.Reader(ctx); got != second {
+			t.Fatal("leitura enviada a uma réplica indisponível")
+		}
+	}
+
+	second.Close()
+	if err := cluster.CheckReplicas(ctx, time.Second); err == nil {
+		t.Fatal("CheckReplicas sem réplicas disponíveis não retornou erro")
+	}
+	if got := cluster.Reader(ctx); got != primary {
+		t.Fatal("leitura sem réplicas disponíveis não usou o primário")
+	}
+}
+
+func TestClusterWithoutReplicas(t *testing.T) {
+	primary := openSQLite(t, "primary.db")
+	cluster := &Cluster{Primary: primary}
+
+	if got := cluster.Reader(context.Background()); got != primary {
+		t.Fatal("leitura sem réplicas configuradas não usou o primário")
+	}
+	if err := cluster.CheckReplicas(context.Background(), time.Second); err != nil {
+		t.Fatalf("CheckReplicas sem réplicas configuradas: %v", err)
+	}
+}
@@ -1,44 +0,0 @@
-package db
-
-import (
-	"database/sql"
-	"fmt"
-	"log"
-
-	_ "github.com/lib/pq"
-)
-
-var DB *sql.DB
-
-func InitDB() (*sql.DB, error) {
-	host := "postgres"
-	port := "5432"
-	user := "docker"
-	password := "docker"
-	dbname := "contactsdb"
-
-	connStr := fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s sslmode=disable",
-		user, password, host, port, dbname)
-
-	var err error
-	DB, err = sql.Open("postgres", connStr)
-	if err != nil {
-		return nil, err
-	}
-
-	err = DB.Ping()
-	if err != nil {
-		return nil, err
-	}
-
-	log.Println("Conexão com o banco de dados estabelecida com sucesso")
-
-	return DB, nil
-}
-
-func CloseDB() {
-	if DB != nil {
-		DB.Close()
-		log.Println("Conexão com o banco de dados fechada")
-	}
-}
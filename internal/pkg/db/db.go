@@ -0,0 +1,83 @@
+// Package db abre e configura o pool de conexões com o banco de dados
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/config"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/worker"
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+// Connect abre o pool de conexões do driver configurado, aplica os limites do
+// pool e aguarda o banco responder. Enquanto o banco não aceita conexões (por
+// exemplo, ainda iniciando no docker compose), a tentativa é repetida com
+// espera exponencial até cfg.ConnectRetries vezes ou até ctx ser cancelado.
+func Connect(ctx context.Context, cfg config.DatabaseConfig) (*sql.DB, error) {
+	driverName, dsn := "postgres", cfg.DSN()
+	if cfg.Driver == "sqlite" {
+		driverName, dsn = "sqlite", sqliteDSN(cfg.Path)
+	}
+
+	database, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao abrir conexão: %v", err)
+	}
+
+	database.SetMaxOpenConns(cfg.MaxOpenConns)
+	database.SetMaxIdleConns(cfg.MaxIdleConns)
+	database.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	database.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := ping(ctx, database, cfg); err != nil {
+		database.Close()
+		return nil, err
+	}
+
+	slog.Info("Conexão com o banco de dados estabelecida com sucesso", "driver", driverName)
+	return database, nil
+}
+
+func ping(ctx context.Context, database *sql.DB, cfg config.DatabaseConfig) error {
+	for attempt := 0; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, cfg.ConnectTimeout)
+		err := database.PingContext(attemptCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= cfg.ConnectRetries {
+			return fmt.Errorf("falha ao conectar ao banco de dados após %d tentativas: %v", attempt+1, err)
+		}
+
+		delay := worker.Backoff(attempt, cfg.ConnectBackoff, cfg.ConnectMaxBackoff)
+		slog.Warn("Banco de dados indisponível, tentando novamente",
+			"attempt", attempt+1, "retry_in", delay, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("conexão com o banco de dados cancelada: %v", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// sqliteDSN abre o arquivo em modo WAL, que permite leituras durante uma
+// escrita, e faz as conexões aguardarem o lock de escrita em vez de falhar
+// com SQLITE_BUSY. As transações já começam reservando a escrita.
+func sqliteDSN(path string) string {
+	query := url.Values{}
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Set("_txlock", "immediate")
+
+	return "file:" + path + "?" + query.Encode()
+}
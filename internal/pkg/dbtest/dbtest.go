@@ -0,0 +1,70 @@
+// Package dbtest prepara bancos de dados para os testes dos demais pacotes
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/config"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/db"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/migrations"
+)
+
+// OpenSQLite cria um banco SQLite vazio num diretório temporário, aberto com
+// as mesmas opções de conexão da API, e aplica todas as migrations
+func OpenSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+
+	database := connectSQLite(t)
+	MigrateSQLite(t, database)
+	return database
+}
+
+// OpenSQLiteAt cria o banco como OpenSQLite, mas aplica as migrations só até
+// version. Serve para gravar dados no esquema anterior a uma migration e
+// conferir como ela os converte ao chamar MigrateSQLite.
+func OpenSQLiteAt(t *testing.T, version int64) *sql.DB {
+	t.Helper()
+
+	database := connectSQLite(t)
+	if _, err := newMigrator(t, database).Goto(context.Background(), version); err != nil {
+		t.Fatalf("erro ao aplicar migrations até a versão %d: %v", version, err)
+	}
+	return database
+}
+
+// MigrateSQLite aplica as migrations pendentes no banco
+func MigrateSQLite(t *testing.T, database *sql.DB) {
+	t.Helper()
+
+	if _, err := newMigrator(t, database).Up(context.Background()); err != nil {
+		t.Fatalf("erro ao aplicar migrations: %v", err)
+	}
+}
+
+func connectSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+
+	cfg := config.Default().Database
+	cfg.Driver = migrations.DriverSQLite
+	cfg.Path = filepath.Join(t.TempDir(), "contacts.db")
+
+	database, err := db.Connect(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("erro ao abrir banco de testes: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func newMigrator(t *testing.T, database *sql.DB) *migrations.Migrator {
+	t.Helper()
+
+	migrator, err := migrations.New(database, migrations.Options{Driver: migrations.DriverSQLite})
+	if err != nil {
+		t.Fatalf("erro ao carregar migrations: %v", err)
+	}
+	return migrator
+}
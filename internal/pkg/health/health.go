@@ -0,0 +1,157 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check verifica uma dependência e retorna erro se ela não estiver pronta
+type Check func(ctx context.Context) error
+
+// CheckResult é o resultado de uma verificação
+type CheckResult struct {
+	Status     string `json:"status" example:"up"`
+	DurationMS int64  `json:"duration_ms" example:"3"`
+	Error      string `json:"error,omitempty" example:"context deadline exceeded"`
+}
+
+// Response é o corpo retornado por /healthz e /readyz
+// @Description Estado da aplicação e de cada dependência
+type Response struct {
+	Status string                 `json:"status" example:"up"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker agrega as verificações de readiness e controla o estado de
+// desligamento
+type Checker struct {
+	timeout      time.Duration
+	checks       []namedCheck
+	shuttingDown atomic.Bool
+}
+
+// NewChecker cria um Checker em que cada verificação tem até timeout para responder
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add registra uma dependência verificada pela readiness
+func (h *Checker) Add(name string, check Check) {
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+// SetShuttingDown faz a readiness falhar a partir de agora
+func (h *Checker) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+func (h *Checker) RegisterRoutes(router gin.IRouter) {
+	router.GET("/healthz", h.Liveness)
+	router.GET("/readyz", h.Readiness)
+}
+
+// @Summary     Liveness
+// @Description Indica que o processo está ativo
+// @Tags        health
+// @Produce     json
+// @Success     200 {object} Response
+// @Router      /healthz [get]
+func (h *Checker) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{Status: StatusUp})
+}
+
+// @Summary     Readiness
+// @Description Verifica as dependências (banco de dados, migrations, workers) e retorna o estado de cada uma
+// @Tags        health
+// @Produce     json
+// @Success     200 {object} Response
+// @Failure     503 {object} Response "Alguma dependência não está pronta ou a aplicação está sendo desligada"
+// @Router      /readyz [get]
+func (h *Checker) Readiness(c *gin.Context) {
+	results := h.run(c.Request.Context())
+
+	response := Response{Status: StatusUp, Checks: results}
+	for _, result := range results {
+		if result.Status != StatusUp {
+			response.Status = StatusDown
+		}
+	}
+
+	if h.shuttingDown.Load() {
+		response.Status = StatusDown
+		response.Checks["shutdown"] = CheckResult{Status: StatusDown, Error: "aplicação em desligamento"}
+	}
+
+	status := http.StatusOK
+	if response.Status != StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, response)
+}
+
+// run executa as verificações em paralelo, cada uma com o timeout configurado
+func (h *Checker) run(ctx context.Context) map[string]CheckResult {
+	results := make(map[string]CheckResult, len(h.checks))
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for _, nc := range h.checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := runCheck(checkCtx, nc.check)
+
+			result := CheckResult{Status: StatusUp, DurationMS: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Status = StatusDown
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			results[nc.name] = result
+			mu.Unlock()
+		}(nc)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// runCheck garante o timeout mesmo para verificações que ignoram o contexto
+func runCheck(ctx context.Context, check Check) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
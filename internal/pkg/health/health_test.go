@@ -0,0 +1,91 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/health"
+	"github.com/gin-gonic/gin"
+)
+
+func get(checker *health.Checker, path string) (int, health.Response) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	checker.RegisterRoutes(router)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var response health.Response
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	return rec.Code, response
+}
+
+func TestReadiness(t *testing.T) {
+	up := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("conexão recusada") }
+	// hang ignora o contexto; o Checker precisa respeitar o timeout mesmo assim
+	hang := func(context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+
+	tests := []struct {
+		name         string
+		checks       map[string]health.Check
+		shuttingDown bool
+		wantStatus   int
+		wantChecks   map[string]string
+	}{
+		{name: "sem verificações", wantStatus: http.StatusOK, wantChecks: map[string]string{}},
+		{name: "todas prontas", checks: map[string]health.Check{"database": up, "workers": up}, wantStatus: http.StatusOK,
+			wantChecks: map[string]string{"database": health.StatusUp, "workers": health.StatusUp}},
+		{name: "dependência com erro", checks: map[string]health.Check{"database": down, "workers": up}, wantStatus: http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"database": health.StatusDown, "workers": health.StatusUp}},
+		{name: "verificação que não responde", checks: map[string]health.Check{"database": hang}, wantStatus: http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"database": health.StatusDown}},
+		{name: "desligamento", checks: map[string]health.Check{"database": up}, shuttingDown: true, wantStatus: http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"database": health.StatusUp, "shutdown": health.StatusDown}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.NewChecker(50 * time.Millisecond)
+			for name, check := range tt.checks {
+				checker.Add(name, check)
+			}
+			if tt.shuttingDown {
+				checker.SetShuttingDown()
+			}
+
+			status, response := get(checker, "/readyz")
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d, esperado %d", status, tt.wantStatus)
+			}
+			if len(response.Checks) != len(tt.wantChecks) {
+				t.Fatalf("checks = %v, esperado %v", response.Checks, tt.wantChecks)
+			}
+			for name, want := range tt.wantChecks {
+				if got := response.Checks[name]; got.Status != want || (want == health.StatusDown) != (got.Error != "") {
+					t.Fatalf("check %s = %+v, esperado %s", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLivenessIgnoresDependencies(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", func(context.Context) error { return errors.New("fora do ar") })
+	checker.SetShuttingDown()
+
+	if status, response := get(checker, "/healthz"); status != http.StatusOK || response.Status != health.StatusUp {
+		t.Fatalf("liveness = %d %+v, esperado 200 up", status, response)
+	}
+}
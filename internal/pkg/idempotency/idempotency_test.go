@@ -0,0 +1,255 @@
+package idempotency_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/idempotency"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/migrations"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+// testDatabaseEnv aponta para um PostgreSQL descartável usado pelos testes do
+// PostgresStore, que são ignorados quando a variável não está definida
+const testDatabaseEnv = "TEST_DATABASE_URL"
+
+// memoryStore reproduz a semântica do PostgresStore sem expiração
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]*idempotency.Record
+	tokens  map[string]string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: map[string]*idempotency.Record{}, tokens: map[string]string{}}
+}
+
+func (s *memoryStore) Reserve(_ context.Context, scope, key, requestHash string) (string, *idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[scope+"/"+key]; ok {
+		copied := *record
+		return "", &copied, nil
+	}
+
+	token := uuid.NewString()
+	s.records[scope+"/"+key] = &idempotency.Record{RequestHash: requestHash}
+	s.tokens[scope+"/"+key] = token
+	return token, nil, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, scope, key, token string, record idempotency.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokens[scope+"/"+key] != token || s.records[scope+"/"+key].Completed {
+		return idempotency.ErrReservationLost
+	}
+	s.records[scope+"/"+key] = &record
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, scope, key, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokens[scope+"/"+key] == token && !s.records[scope+"/"+key].Completed {
+		delete(s.records, scope+"/"+key)
+		delete(s.tokens, scope+"/"+key)
+	}
+	return nil
+}
+
+// failingStore falha em todas as reservas
+type failingStore struct {
+	*memoryStore
+	err error
+}
+
+func (s failingStore) Reserve(context.Context, string, string, string) (string, *idempotency.Record, error) {
+	return "", nil, s.err
+}
+
+type testEnv struct {
+	router *gin.Engine
+	calls  atomic.Int32
+
+	// block, se definido, segura o handler até ser fechado
+	block   chan struct{}
+	started chan struct{}
+	status  int
+}
+
+func newTestEnv(store idempotency.Store) *testEnv {
+	gin.SetMode(gin.TestMode)
+
+	env := &testEnv{status: http.StatusCreated}
+	env.router = gin.New()
+	env.router.Use(tenant.Middleware(tenant.DefaultID), idempotency.Middleware(store))
+	env.router.POST("/contacts", func(c *gin.Context) {
+		n := env.calls.Add(1)
+		if env.block != nil {
+			close(env.started)
+			<-env.block
+		}
+		c.JSON(env.status, gin.H{"call": n})
+	})
+	return env
+}
+
+func (env *testEnv) post(key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/contacts", strings.NewReader(body))
+	req.Header.Set(idempotency.HeaderName, key)
+	rec := httptest.NewRecorder()
+	env.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name string
+		// status é a resposta do handler na primeira requisição
+		status     int
+		secondKey  string
+		secondBody string
+		wantStatus int
+		wantCalls  int32
+		replayed   bool
+	}{
+		{name: "repetição reproduz a resposta", status: http.StatusCreated, secondKey: "k1", secondBody: `{"a":1}`, wantStatus: http.StatusCreated, wantCalls: 1, replayed: true},
+		{name: "payload diferente retorna 422", status: http.StatusCreated, secondKey: "k1", secondBody: `{"a":2}`, wantStatus: http.StatusUnprocessableEntity, wantCalls: 1},
+		{name: "outra chave executa de novo", status: http.StatusCreated, secondKey: "k2", secondBody: `{"a":1}`, wantStatus: http.StatusCreated, wantCalls: 2},
+		{name: "resposta 5xx não é armazenada", status: http.StatusInternalServerError, secondKey: "k1", secondBody: `{"a":1}`, wantStatus: http.StatusInternalServerError, wantCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(newMemoryStore())
+			env.status = tt.status
+
+			first := env.post("k1", `{"a":1}`)
+			second := env.post(tt.secondKey, tt.secondBody)
+
+			if second.Code != tt.wantStatus {
+				t.Fatalf("status = %d, esperado %d (%s)", second.Code, tt.wantStatus, second.Body.String())
+			}
+			if calls := env.calls.Load(); calls != tt.wantCalls {
+				t.Fatalf("handler executado %d vezes, esperado %d", calls, tt.wantCalls)
+			}
+			if replayed := second.Header().Get(idempotency.ReplayedHeader) == "true"; replayed != tt.replayed {
+				t.Fatalf("%s = %v, esperado %v", idempotency.ReplayedHeader, replayed, tt.replayed)
+			}
+			if tt.replayed && second.Body.String() != first.Body.String() {
+				t.Fatalf("corpo reproduzido = %s, esperado %s", second.Body.String(), first.Body.String())
+			}
+		})
+	}
+}
+
+func TestMiddlewareStoreError(t *testing.T) {
+	env := newTestEnv(failingStore{memoryStore: newMemoryStore(), err: errors.New(`pq: relation "idempotency_keys" does not exist`)})
+
+	rec := env.post("k1", `{"a":1}`)
+	if rec.Code != http.StatusInternalServerError || env.calls.Load() != 0 {
+		t.Fatalf("status = %d, handler executado %d vezes; esperado 500 sem executar", rec.Code, env.calls.Load())
+	}
+	if strings.Contains(rec.Body.String(), "idempotency_keys") || !strings.Contains(rec.Body.String(), "Erro interno do servidor") {
+		t.Fatalf("resposta expõe o erro do store: %s", rec.Body.String())
+	}
+}
+
+func TestMiddlewareConcurrentRequest(t *testing.T) {
+	env := newTestEnv(newMemoryStore())
+	env.block = make(chan struct{})
+	env.started = make(chan struct{})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- env.post("k1", `{"a":1}`) }()
+	<-env.started
+
+	// A original ainda está em andamento
+	rec := env.post("k1", `{"a":1}`)
+	if rec.Code != http.StatusConflict || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("requisição concorrente = %d, Retry-After %q; esperado 409", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	close(env.block)
+	if first := <-done; first.Code != http.StatusCreated {
+		t.Fatalf("requisição original = %d", first.Code)
+	}
+	if rec := env.post("k1", `{"a":1}`); rec.Code != http.StatusCreated || rec.Header().Get(idempotency.ReplayedHeader) != "true" {
+		t.Fatalf("repetição após a conclusão = %d, esperado replay", rec.Code)
+	}
+}
+
+func TestPostgresStoreReservationTakeover(t *testing.T) {
+	url := os.Getenv(testDatabaseEnv)
+	if url == "" {
+		t.Skipf("%s não definida", testDatabaseEnv)
+	}
+
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		t.Fatalf("erro ao abrir banco de testes: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migrations.New(db, migrations.Options{})
+	if err != nil {
+		t.Fatalf("erro ao carregar migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("erro ao aplicar migrations: %v", err)
+	}
+	if _, err := db.Exec("TRUNCATE idempotency_keys"); err != nil {
+		t.Fatalf("erro ao limpar chaves: %v", err)
+	}
+
+	ctx := context.Background()
+	// Reservas sem resposta são consideradas abandonadas quase de imediato
+	store := idempotency.NewPostgresStore(db, time.Hour, time.Millisecond)
+	response := idempotency.Record{RequestHash: "hash", StatusCode: http.StatusCreated, ContentType: "application/json", Body: []byte(`{}`)}
+
+	first, existing, err := store.Reserve(ctx, "acme", "k1", "hash")
+	if err != nil || first == "" || existing != nil {
+		t.Fatalf("primeira reserva = %q, %+v, %v", first, existing, err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	second, _, err := store.Reserve(ctx, "acme", "k1", "hash")
+	if err != nil || second == "" || second == first {
+		t.Fatalf("reserva abandonada não foi assumida: %q, %v", second, err)
+	}
+
+	// A original não grava nem libera a reserva que perdeu
+	if err := store.Release(ctx, "acme", "k1", first); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if err := store.Complete(ctx, "acme", "k1", first, response); !errors.Is(err, idempotency.ErrReservationLost) {
+		t.Fatalf("Complete da reserva perdida = %v, esperado ErrReservationLost", err)
+	}
+	if err := store.Complete(ctx, "acme", "k1", second, response); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if err := store.Complete(ctx, "acme", "k1", second, response); !errors.Is(err, idempotency.ErrReservationLost) {
+		t.Fatalf("segundo Complete = %v, esperado ErrReservationLost", err)
+	}
+
+	_, record, err := store.Reserve(ctx, "acme", "k1", "hash")
+	if err != nil || record == nil || !record.Completed || record.StatusCode != http.StatusCreated {
+		t.Fatalf("registro = %+v, %v", record, err)
+	}
+}
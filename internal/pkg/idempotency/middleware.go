@@ -0,0 +1,162 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/apierror"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// HeaderName é o cabeçalho com a chave de idempotência enviada pelo cliente
+	HeaderName = "Idempotency-Key"
+
+	// ReplayedHeader é adicionado às respostas reproduzidas a partir do store
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+)
+
+// responseRecorder copia o corpo da resposta enquanto ele é escrito
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Middleware honra o cabeçalho Idempotency-Key nas rotas de escrita. A
+// primeira resposta é armazenada e reproduzida nas repetições com a mesma
+// chave e o mesmo payload; reutilizar a chave com outro payload retorna 422 e
+// uma repetição enquanto a original ainda está em andamento retorna 409.
+// Respostas 5xx não são armazenadas, permitindo que o cliente tente de novo.
+func Middleware(store Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderName)
+		if key == "" || !isMutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxKeyLength {
+			apierror.Abort(c, http.StatusBadRequest, "Idempotency-Key excede o tamanho máximo")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			apierror.Abort(c, http.StatusBadRequest, "Não foi possível ler o corpo da requisição")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := tenant.FromContext(c)
+		hash := requestHash(c.Request, body)
+
+		token, existing, err := store.Reserve(c.Request.Context(), scope, key, hash)
+		if err != nil {
+			// O erro do store pode trazer detalhes do banco; fica apenas no log
+			slog.ErrorContext(c.Request.Context(), "Erro ao reservar chave de idempotência", "error", err)
+			apierror.Abort(c, http.StatusInternalServerError, "Erro interno do servidor")
+			return
+		}
+
+		if existing != nil {
+			replay(c, existing, hash)
+			return
+		}
+
+		// A gravação do resultado não deve ser cancelada se o cliente desconectar
+		ctx := context.WithoutCancel(c.Request.Context())
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			// Panic ou erro inesperado: libera a chave para uma nova tentativa
+			if err := store.Release(ctx, scope, key, token); err != nil {
+				slog.ErrorContext(ctx, "Erro ao liberar chave de idempotência", "error", err)
+			}
+		}()
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		err = store.Complete(ctx, scope, key, token, Record{
+			RequestHash: hash,
+			Completed:   true,
+			StatusCode:  status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+		if errors.Is(err, ErrReservationLost) {
+			// A reserva expirou durante a requisição e outra com a mesma chave
+			// a assumiu; a resposta dela prevalece
+			slog.WarnContext(ctx, "Resposta idempotente descartada", "error", err)
+			completed = true
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Erro ao armazenar resposta idempotente", "error", err)
+			return
+		}
+		completed = true
+	}
+}
+
+func replay(c *gin.Context, record *Record, hash string) {
+	if record.RequestHash != hash {
+		apierror.Abort(c, http.StatusUnprocessableEntity, "Idempotency-Key já utilizada com um payload diferente")
+		return
+	}
+
+	if !record.Completed {
+		c.Header("Retry-After", "1")
+		apierror.Abort(c, http.StatusConflict, "Requisição com a mesma Idempotency-Key em andamento")
+		return
+	}
+
+	c.Header(ReplayedHeader, "true")
+	c.Data(record.StatusCode, record.ContentType, record.Body)
+	c.Abort()
+}
+
+// requestHash identifica o payload pela rota e pelo corpo da requisição
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
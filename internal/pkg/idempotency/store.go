@@ -0,0 +1,168 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrReservationLost indica que a reserva expirou e foi assumida por outra
+// requisição com a mesma chave; a resposta da original não é gravada
+var ErrReservationLost = errors.New("reserva da chave de idempotência perdida para outra requisição")
+
+// Record é o estado de uma chave de idempotência. Enquanto a primeira
+// requisição está em andamento, Completed é falso e a resposta está vazia.
+type Record struct {
+	RequestHash string
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// Store persiste as chaves de idempotência por escopo (tenant)
+type Store interface {
+	// Reserve tenta reservar a chave para a requisição atual. Retorna o token
+	// da reserva, se ela foi feita, ou o registro existente caso a chave já
+	// esteja em uso.
+	Reserve(ctx context.Context, scope, key, requestHash string) (token string, existing *Record, err error)
+
+	// Complete grava a resposta da requisição dona da reserva. Retorna
+	// ErrReservationLost se a reserva passou a outra requisição.
+	Complete(ctx context.Context, scope, key, token string, record Record) error
+
+	// Release libera a chave, se a reserva ainda for da requisição, para que
+	// uma nova tentativa possa ser processada
+	Release(ctx context.Context, scope, key, token string) error
+}
+
+// PostgresStore guarda as chaves na tabela idempotency_keys, compartilhada
+// entre réplicas. A chave primária (tenant_id, key) garante que apenas uma
+// requisição concorrente consiga a reserva.
+type PostgresStore struct {
+	db *sql.DB
+
+	// ttl é o tempo em que a resposta gravada continua disponível para replay
+	ttl time.Duration
+
+	// lockTimeout é o tempo após o qual uma reserva sem resposta é considerada
+	// abandonada (ex.: o processo caiu durante a requisição)
+	lockTimeout time.Duration
+}
+
+func NewPostgresStore(db *sql.DB, ttl, lockTimeout time.Duration) *PostgresStore {
+	return &PostgresStore{db: db, ttl: ttl, lockTimeout: lockTimeout}
+}
+
+func (s *PostgresStore) Reserve(ctx context.Context, scope, key, requestHash string) (string, *Record, error) {
+	// Chaves expiradas ou reservas abandonadas são reaproveitadas pelo próprio upsert
+	reserveQuery := `
+		INSERT INTO idempotency_keys (tenant_id, key, request_hash, reservation_token, expires_at)
+		VALUES ($1, $2, $3, $6, now() + make_interval(secs => $4::float8))
+		ON CONFLICT (tenant_id, key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			reservation_token = EXCLUDED.reservation_token,
+			status_code = NULL,
+			content_type = NULL,
+			response_body = NULL,
+			created_at = now(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < now()
+			OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < now() - make_interval(secs => $5::float8))
+		RETURNING true
+	`
+
+	selectQuery := `
+		SELECT request_hash, status_code, content_type, response_body
+		FROM idempotency_keys
+		WHERE tenant_id = $1 AND key = $2
+	`
+
+	token := uuid.NewString()
+
+	// Se o registro existente sumir entre as duas consultas, tenta novamente
+	for attempt := 0; attempt < 3; attempt++ {
+		var reserved bool
+		err := s.db.QueryRowContext(ctx, reserveQuery, scope, key, requestHash, s.ttl.Seconds(), s.lockTimeout.Seconds(), token).Scan(&reserved)
+		if err == nil {
+			return token, nil, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return "", nil, fmt.Errorf("erro ao reservar chave de idempotência: %v", err)
+		}
+
+		var (
+			record      Record
+			statusCode  sql.NullInt64
+			contentType sql.NullString
+		)
+		err = s.db.QueryRowContext(ctx, selectQuery, scope, key).Scan(&record.RequestHash, &statusCode, &contentType, &record.Body)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("erro ao consultar chave de idempotência: %v", err)
+		}
+
+		record.Completed = statusCode.Valid
+		record.StatusCode = int(statusCode.Int64)
+		record.ContentType = contentType.String
+		return "", &record, nil
+	}
+
+	return "", nil, fmt.Errorf("não foi possível reservar a chave de idempotência %q", key)
+}
+
+func (s *PostgresStore) Complete(ctx context.Context, scope, key, token string, record Record) error {
+	// Após lockTimeout outra requisição pode ter assumido a reserva; apenas a
+	// dona do token grava, e uma única vez
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $1, content_type = $2, response_body = $3
+		WHERE tenant_id = $4 AND key = $5 AND request_hash = $6
+			AND reservation_token = $7 AND status_code IS NULL
+	`
+
+	result, err := s.db.ExecContext(ctx, query, record.StatusCode, record.ContentType, record.Body, scope, key, record.RequestHash, token)
+	if err != nil {
+		return fmt.Errorf("erro ao gravar resposta idempotente: %v", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao gravar resposta idempotente: %v", err)
+	}
+	if affected == 0 {
+		return ErrReservationLost
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) Release(ctx context.Context, scope, key, token string) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE tenant_id = $1 AND key = $2 AND reservation_token = $3 AND status_code IS NULL
+	`
+
+	_, err := s.db.ExecContext(ctx, query, scope, key, token)
+	if err != nil {
+		return fmt.Errorf("erro ao liberar chave de idempotência: %v", err)
+	}
+
+	return nil
+}
+
+// Purge remove as chaves expiradas
+func (s *PostgresStore) Purge(ctx context.Context) (int64, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at < now()")
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover chaves de idempotência expiradas: %v", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// redactedValue substitui o valor de campos sensíveis nos logs
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeys são os atributos cujo valor nunca deve aparecer nos logs
+var sensitiveKeys = map[string]bool{
+	"email":    true,
+	"phone":    true,
+	"password": true,
+}
+
+type contextKey struct{}
+
+// New cria um logger estruturado com o nível (debug, info, warn ou error) e o
+// formato (json ou text) informados. Os atributos adicionados ao contexto com
+// WithAttrs são incluídos em toda linha registrada com as variantes *Context
+// do slog.
+func New(level, format string, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level:       parseLevel(level),
+		ReplaceAttr: redact,
+	}
+
+	var handler slog.Handler
+	if format == "text" {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+// WithAttrs retorna um contexto cujos logs incluirão os atributos informados,
+// além dos que já estavam no contexto
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(contextKey{}).([]slog.Attr)
+
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+	merged = append(merged, existing...)
+	merged = append(merged, attrs...)
+
+	return context.WithValue(ctx, contextKey{}, merged)
+}
+
+// contextHandler adiciona ao registro os atributos guardados no contexto e o
+// trace ativo, permitindo correlacionar logs e traces
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs, ok := ctx.Value(contextKey{}).([]slog.Attr); ok {
+		record.AddAttrs(attrs...)
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// redact oculta o valor dos atributos sensíveis, inclusive dentro de grupos
+func redact(_ []string, attr slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, redactedValue)
+	}
+	return attr
+}
+
+func parseLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,116 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRedaction(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(log *slog.Logger)
+	}{
+		{name: "atributo do registro", log: func(log *slog.Logger) {
+			log.Info("msg", "email", "joao@example.com", "phone", "11999998888", "password", "hunter2")
+		}},
+		{name: "chave com maiúsculas", log: func(log *slog.Logger) {
+			log.Info("msg", "Email", "joao@example.com", "PASSWORD", "hunter2")
+		}},
+		{name: "dentro de grupo", log: func(log *slog.Logger) {
+			log.Info("msg", slog.Group("contact", "email", "joao@example.com", "phone", "11999998888"))
+		}},
+		{name: "atributo do logger", log: func(log *slog.Logger) {
+			log.With("email", "joao@example.com").WithGroup("request").Info("msg", "password", "hunter2")
+		}},
+		{name: "atributo do contexto", log: func(log *slog.Logger) {
+			ctx := logger.WithAttrs(context.Background(), slog.String("phone", "11999998888"))
+			log.InfoContext(ctx, "msg")
+		}},
+	}
+
+	for _, format := range []string{"json", "text"} {
+		for _, tt := range tests {
+			t.Run(format+"/"+tt.name, func(t *testing.T) {
+				var out bytes.Buffer
+				tt.log(logger.New("info", format, &out))
+
+				line := out.String()
+				for _, secret := range []string{"joao@example.com", "11999998888", "hunter2"} {
+					if strings.Contains(line, secret) {
+						t.Fatalf("log expõe %q: %s", secret, line)
+					}
+				}
+				if !strings.Contains(line, "[REDACTED]") {
+					t.Fatalf("log sem [REDACTED]: %s", line)
+				}
+			})
+		}
+	}
+}
+
+func TestContextAttrs(t *testing.T) {
+	var out bytes.Buffer
+	log := logger.New("info", "json", &out)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled,
+	}))
+	ctx = logger.WithAttrs(ctx, slog.String("request_id", "req-1"))
+	ctx = logger.WithAttrs(ctx, slog.String("tenant_id", "acme"))
+
+	log.InfoContext(ctx, "msg")
+
+	var line map[string]any
+	if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+		t.Fatalf("linha inválida %q: %v", out.String(), err)
+	}
+	want := map[string]string{
+		"request_id": "req-1",
+		"tenant_id":  "acme",
+		"trace_id":   traceID.String(),
+		"span_id":    spanID.String(),
+	}
+	for key, value := range want {
+		if line[key] != value {
+			t.Errorf("%s = %v, esperado %s", key, line[key], value)
+		}
+	}
+}
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		level   string
+		enabled []slog.Level
+		// disabled são os níveis descartados
+		disabled []slog.Level
+	}{
+		{level: "debug", enabled: []slog.Level{slog.LevelDebug}},
+		{level: "info", enabled: []slog.Level{slog.LevelInfo}, disabled: []slog.Level{slog.LevelDebug}},
+		{level: "warn", enabled: []slog.Level{slog.LevelWarn}, disabled: []slog.Level{slog.LevelInfo}},
+		{level: "error", enabled: []slog.Level{slog.LevelError}, disabled: []slog.Level{slog.LevelWarn}},
+		{level: "desconhecido", enabled: []slog.Level{slog.LevelInfo}, disabled: []slog.Level{slog.LevelDebug}},
+	}
+
+	for _, tt := range tests {
+		log := logger.New(tt.level, "json", &bytes.Buffer{})
+		for _, level := range tt.enabled {
+			if !log.Enabled(context.Background(), level) {
+				t.Errorf("nível %s: %s deveria estar habilitado", tt.level, level)
+			}
+		}
+		for _, level := range tt.disabled {
+			if log.Enabled(context.Background(), level) {
+				t.Errorf("nível %s: %s deveria estar desabilitado", tt.level, level)
+			}
+		}
+	}
+}
@@ -1,8 +1,32 @@
 package metrics
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Resultados possíveis de uma operação no banco de dados
+const (
+	OutcomeOK       = "ok"
+	OutcomeNotFound = "not_found"
+	OutcomeError    = "error"
+
+	// OutcomeCanceled indica que o cliente desistiu da requisição e
+	// OutcomeTimeout que o prazo configurado para a operação expirou
+	OutcomeCanceled = "canceled"
+	OutcomeTimeout  = "timeout"
+)
+
+// Resultados possíveis de uma consulta ao cache
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
 )
 
 var (
@@ -15,6 +39,15 @@ var (
 		[]string{"method", "endpoint", "status"},
 	)
 
+	// RateLimitRejectionsTotal é um contador que registra as requisições rejeitadas pelo rate limit
+	RateLimitRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_rate_limit_rejections_total",
+			Help: "Total de requisições HTTP rejeitadas pelo rate limit",
+		},
+		[]string{"method", "endpoint"},
+	)
+
 	// HTTPRequestDuration é um histograma que registra a duração das requisições HTTP
 	HTTPRequestDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -31,7 +64,7 @@ var (
 			Name: "database_operations_total",
 			Help: "Total de operações no banco de dados",
 		},
-		[]string{"operation", "table"},
+		[]string{"operation", "table", "outcome"},
 	)
 
 	// DatabaseOperationDuration é um histograma que registra a duração das operações no banco de dados
@@ -41,6 +74,73 @@ var (
 			Help:    "Duração das operações no banco de dados em segundos",
 			Buckets: prometheus.DefBuckets,
 		},
-		[]string{"operation", "table"},
+		[]string{"operation", "table", "outcome"},
+	)
+
+	// CacheRequestsTotal é um contador que registra as consultas ao cache por resultado (hit ou miss)
+	CacheRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_requests_total",
+			Help: "Total de consultas ao cache",
+		},
+		[]string{"cache", "result"},
+	)
+
+	// OutboxDeliveriesTotal é um contador que registra as entregas de eventos do outbox por sink e resultado
+	OutboxDeliveriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "outbox_deliveries_total",
+			Help: "Total de entregas de eventos do outbox",
+		},
+		[]string{"sink", "outcome"},
+	)
+
+	// WebhookDeliveriesTotal é um contador que registra as tentativas de entrega de webhooks por resultado
+	WebhookDeliveriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_deliveries_total",
+			Help: "Total de tentativas de entrega de webhooks",
+		},
+		[]string{"outcome"},
+	)
+
+	// StreamSubscribers é um gauge com o número de clientes conectados ao stream de eventos
+	StreamSubscribers = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "stream_subscribers",
+			Help: "Clientes conectados ao stream de eventos de contatos",
+		},
+	)
+
+	// StreamSlowConsumersTotal é um contador que registra os clientes do stream desconectados por não acompanharem os eventos
+	StreamSlowConsumersTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "stream_slow_consumers_total",
+			Help: "Total de clientes do stream desconectados por lentidão",
+		},
 	)
 )
+
+// ObserveDatabaseOperation registra o total e a duração de uma operação no banco de dados
+func ObserveDatabaseOperation(ctx context.Context, operation, table, outcome string, duration time.Duration) {
+	DatabaseOperationsTotal.WithLabelValues(operation, table, outcome).Inc()
+	ObserveWithTrace(ctx, DatabaseOperationDuration.WithLabelValues(operation, table, outcome), duration.Seconds())
+}
+
+// ObserveWithTrace registra o valor no histograma anexando o trace_id do span
+// ativo como exemplar, quando o trace foi amostrado
+func ObserveWithTrace(ctx context.Context, observer prometheus.Observer, value float64) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok && spanContext.IsSampled() {
+		exemplarObserver.ObserveWithExemplar(value, prometheus.Labels{"trace_id": spanContext.TraceID().String()})
+		return
+	}
+
+	observer.Observe(value)
+}
+
+// RegisterDBStats exporta as estatísticas do pool de conexões (conexões
+// abertas, em uso, ociosas, número e tempo de espera) identificadas por dbName
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, dbName))
+}
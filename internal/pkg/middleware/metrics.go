@@ -16,12 +16,12 @@ func MetricsMiddleware() gin.HandlerFunc {
 		// Processa a requisição
 		c.Next()
 
-		// Registra a duração da requisição
+		// Registra a duração da requisição, com o trace como exemplar
 		duration := time.Since(start).Seconds()
-		metrics.HTTPRequestDuration.WithLabelValues(
+		metrics.ObserveWithTrace(c.Request.Context(), metrics.HTTPRequestDuration.WithLabelValues(
 			c.Request.Method,
 			c.FullPath(),
-		).Observe(duration)
+		), duration)
 
 		// Registra o total de requisições
 		metrics.HTTPRequestsTotal.WithLabelValues(
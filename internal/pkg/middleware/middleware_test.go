@@ -0,0 +1,137 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/logger"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/metrics"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// newRouter monta a cadeia de middlewares de cmd/api com um access log em out
+func newRouter(out *bytes.Buffer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.RequestIDMiddleware(), middleware.AccessLogMiddleware(logger.New("info", "json", out)), middleware.MetricsMiddleware())
+	router.GET("/contacts/:id", func(c *gin.Context) {
+		// Atributos sensíveis adicionados por handlers chegam ao access log
+		ctx := logger.WithAttrs(c.Request.Context(), slog.String("email", "joao@example.com"), slog.String("phone", "11999998888"))
+		c.Request = c.Request.WithContext(ctx)
+
+		switch c.Param("id") {
+		case "erro":
+			c.Status(http.StatusInternalServerError)
+		case "inexistente":
+			c.Status(http.StatusNotFound)
+		default:
+			c.String(http.StatusOK, middleware.RequestID(c))
+		}
+	})
+	return router
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		reused bool
+	}{
+		{name: "sem cabeçalho gera um ID"},
+		{name: "ID do cliente é reaproveitado", header: "req-123:abc.def_1", reused: true},
+		{name: "ID com caracteres inválidos é substituído", header: "req 123\n"},
+		{name: "ID longo demais é substituído", header: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			router := newRouter(&out)
+
+			req := httptest.NewRequest(http.MethodGet, "/contacts/1", nil)
+			if tt.header != "" {
+				req.Header.Set(middleware.RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(middleware.RequestIDHeader)
+			if tt.reused && id != tt.header {
+				t.Fatalf("ID = %q, esperado %q", id, tt.header)
+			}
+			if !tt.reused && (len(id) != 32 || id == tt.header) {
+				t.Fatalf("ID gerado = %q", id)
+			}
+			if rec.Body.String() != id {
+				t.Fatalf("ID no contexto = %q, esperado %q", rec.Body.String(), id)
+			}
+
+			var line map[string]any
+			if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+				t.Fatalf("access log inválido %q: %v", out.String(), err)
+			}
+			if line["request_id"] != id {
+				t.Fatalf("request_id no access log = %v, esperado %q", line["request_id"], id)
+			}
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	tests := []struct {
+		path      string
+		route     string
+		status    int
+		wantLevel string
+	}{
+		{path: "/contacts/1", route: "/contacts/:id", status: http.StatusOK, wantLevel: "INFO"},
+		{path: "/contacts/inexistente", route: "/contacts/:id", status: http.StatusNotFound, wantLevel: "WARN"},
+		{path: "/contacts/erro", route: "/contacts/:id", status: http.StatusInternalServerError, wantLevel: "ERROR"},
+		{path: "/desconhecida", route: "", status: http.StatusNotFound, wantLevel: "WARN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			var out bytes.Buffer
+			router := newRouter(&out)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			var line map[string]any
+			if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+				t.Fatalf("access log inválido %q: %v", out.String(), err)
+			}
+			if line["level"] != tt.wantLevel || line["status"] != float64(tt.status) || line["route"] != tt.route || line["path"] != tt.path {
+				t.Fatalf("access log = %v", line)
+			}
+			if tt.route != "" && (line["email"] != "[REDACTED]" || line["phone"] != "[REDACTED]") {
+				t.Fatalf("access log expõe atributos sensíveis: %s", out.String())
+			}
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	var out bytes.Buffer
+	router := newRouter(&out)
+
+	counter := metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "/contacts/:id", "404")
+	before := testutil.ToFloat64(counter)
+
+	for range 2 {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/contacts/inexistente", nil))
+	}
+
+	if got := testutil.ToFloat64(counter) - before; got != 2 {
+		t.Fatalf("http_requests_total pela rota = %v, esperado 2", got)
+	}
+}
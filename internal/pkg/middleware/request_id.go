@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader é o cabeçalho que identifica a requisição nos logs e nas respostas
+const RequestIDHeader = "X-Request-ID"
+
+const requestIDKey = "request_id"
+
+// validRequestID limita os IDs aceitos do cliente para evitar injeção nos logs
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware reaproveita o X-Request-ID enviado pelo cliente ou gera
+// um novo, devolve-o na resposta e o adiciona ao contexto de log da requisição
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logger.WithAttrs(c.Request.Context(), slog.String("request_id", id)))
+
+		c.Next()
+	}
+}
+
+// RequestID retorna o ID definido pelo RequestIDMiddleware
+func RequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,10 @@
+DROP POLICY IF EXISTS contacts_tenant_isolation ON contacts;
+
+ALTER TABLE contacts DISABLE ROW LEVEL SECURITY;
+
+DROP INDEX IF EXISTS contacts_tenant_email_key;
+
+-- Falha se o mesmo email estiver cadastrado em mais de um tenant
+ALTER TABLE contacts ADD CONSTRAINT contacts_email_key UNIQUE (email);
+
+ALTER TABLE contacts DROP COLUMN IF EXISTS tenant_id;
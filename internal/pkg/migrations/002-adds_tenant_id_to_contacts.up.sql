@@ -0,0 +1,19 @@
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+
+ALTER TABLE contacts ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE contacts DROP CONSTRAINT IF EXISTS contacts_email_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS contacts_tenant_email_key ON contacts (tenant_id, email);
+
+-- Row-level security como segunda camada de isolamento. O dono da tabela ignora
+-- a política, a menos que FORCE ROW LEVEL SECURITY seja habilitado ou a API use
+-- um papel sem privilégio de dono; nesses casos o repositório precisa definir
+-- app.tenant_id em cada transação (TENANT_RLS_ENABLED=true).
+ALTER TABLE contacts ENABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS contacts_tenant_isolation ON contacts;
+
+CREATE POLICY contacts_tenant_isolation ON contacts
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
@@ -0,0 +1,19 @@
+-- Eventos gravados na mesma transação das alterações de contatos e entregues
+-- pelo relay, em ordem por aggregate_id
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL UNIQUE,
+    event_type VARCHAR(64) NOT NULL,
+    tenant_id VARCHAR(64) NOT NULL,
+    aggregate_id VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    locked_until TIMESTAMPTZ,
+    delivered_at TIMESTAMPTZ,
+    last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS outbox_events_pending_idx ON outbox_events (aggregate_id, id) WHERE delivered_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_events_delivered_at_idx ON outbox_events (delivered_at) WHERE delivered_at IS NOT NULL;
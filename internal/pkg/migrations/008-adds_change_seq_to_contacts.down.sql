@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS contact_tombstones;
+DROP TABLE IF EXISTS contact_change_counters;
+ALTER TABLE contacts DROP COLUMN IF EXISTS change_seq;
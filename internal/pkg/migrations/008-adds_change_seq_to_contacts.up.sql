@@ -0,0 +1,39 @@
+-- Sequência de alterações por tenant usada na sincronização incremental. O
+-- contador do tenant é incrementado na transação de cada alteração, o que
+-- serializa as alterações do tenant e faz a sequência seguir a ordem de commit.
+-- O índice é criado fora desta transação, na migration 011.
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL DEFAULT 0;
+
+-- purged_seq é a maior sequência entre as remoções já expurgadas: tokens
+-- anteriores a ela exigem uma sincronização completa
+CREATE TABLE IF NOT EXISTS contact_change_counters (
+    tenant_id VARCHAR(64) NOT NULL PRIMARY KEY,
+    last_seq BIGINT NOT NULL,
+    purged_seq BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS contact_tombstones (
+    tenant_id VARCHAR(64) NOT NULL,
+    change_seq BIGINT NOT NULL,
+    contact_id UUID NOT NULL,
+    deleted_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tenant_id, change_seq)
+);
+
+CREATE INDEX IF NOT EXISTS contact_tombstones_deleted_at_idx ON contact_tombstones (deleted_at);
+
+-- Os contatos anteriores a esta migration recebem posições distintas, na ordem
+-- de criação, e o contador de cada tenant parte da maior delas. Com todos em
+-- change_seq 0, a sincronização paginada não teria como continuar após a
+-- primeira página.
+UPDATE contacts SET change_seq = numbered.seq
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY tenant_id ORDER BY created_at, id) AS seq
+    FROM contacts
+    WHERE change_seq = 0
+) AS numbered
+WHERE contacts.id = numbered.id;
+
+INSERT INTO contact_change_counters (tenant_id, last_seq)
+SELECT tenant_id, MAX(change_seq) FROM contacts GROUP BY tenant_id
+ON CONFLICT (tenant_id) DO NOTHING;
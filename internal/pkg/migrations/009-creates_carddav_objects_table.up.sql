@@ -0,0 +1,13 @@
+-- Nome do recurso e UID escolhidos pelo cliente CardDAV para cada contato
+-- criado por ele. Contatos sem registro usam o próprio ID nos dois. O
+-- registro é mantido após a remoção do contato para que a sincronização
+-- informe a remoção no mesmo recurso.
+CREATE TABLE IF NOT EXISTS carddav_objects (
+    tenant_id VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    contact_id UUID NOT NULL,
+    uid VARCHAR(255) NOT NULL,
+    PRIMARY KEY (tenant_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS carddav_objects_contact_id_idx ON carddav_objects (tenant_id, contact_id);
@@ -0,0 +1,3 @@
+-- Identifica a requisição dona da reserva; uma reserva abandonada pode ser
+-- assumida por outra requisição e a original não deve mais gravar a resposta
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS reservation_token UUID;
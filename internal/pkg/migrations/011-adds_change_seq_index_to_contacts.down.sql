@@ -0,0 +1,2 @@
+-- +migrate notransaction
+DROP INDEX CONCURRENTLY IF EXISTS contacts_tenant_change_seq_idx;
@@ -0,0 +1,4 @@
+-- +migrate notransaction
+-- Índice da sincronização incremental, criado sem bloquear as escritas em
+-- contacts
+CREATE INDEX CONCURRENTLY IF NOT EXISTS contacts_tenant_change_seq_idx ON contacts (tenant_id, change_seq);
@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// BackfillOptions controla o tamanho dos lotes de um Backfill
+type BackfillOptions struct {
+	// BatchSize é passado como $1 à consulta. Padrão: 1000.
+	BatchSize int
+
+	// Pause é a espera entre lotes, que reduz a concorrência com o tráfego da API
+	Pause time.Duration
+}
+
+// Backfill executa query repetidamente até que ela não altere mais nenhuma
+// linha e retorna o total alterado. A consulta deve limitar cada execução a
+// $1 linhas e ignorar as já processadas, por exemplo:
+//
+//	UPDATE contacts SET email = lower(email)
+//	WHERE id IN (SELECT id FROM contacts WHERE email <> lower(email) LIMIT $1)
+//
+// Numa migration com NoTransaction cada lote é confirmado ao terminar, então
+// uma execução interrompida continua de onde parou. Dentro de uma transação o
+// progresso só é confirmado no fim da migration.
+func Backfill(ctx context.Context, db Executor, name, query string, opts BackfillOptions) (int64, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 1000
+	}
+
+	var total int64
+	for batch := 1; ; batch++ {
+		result, err := db.ExecContext(ctx, query, opts.BatchSize)
+		if err != nil {
+			return total, fmt.Errorf("erro no lote %d do backfill %s: %v", batch, name, err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("erro no lote %d do backfill %s: %v", batch, name, err)
+		}
+		total += affected
+
+		slog.InfoContext(ctx, "Lote do backfill concluído", "backfill", name, "batch", batch, "rows", affected, "total", total)
+
+		if affected == 0 {
+			return total, nil
+		}
+
+		if opts.Pause > 0 {
+			select {
+			case <-ctx.Done():
+				return total, ctx.Err()
+			case <-time.After(opts.Pause):
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Drivers de banco suportados pelo Migrator
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// dialect reúne o que muda entre os bancos na tabela de controle e na
+// serialização das execuções
+type dialect struct {
+	// versionTypeQuery retorna o tipo da coluna version de schema_migrations,
+	// ou nenhuma linha quando a tabela não existe
+	versionTypeQuery string
+
+	createTableQuery string
+
+	// upgradeTableQuery adiciona as colunas criadas depois da primeira versão
+	// da tabela. Vazio quando o banco nunca teve o formato antigo.
+	upgradeTableQuery string
+
+	// lock serializa as migrations entre processos e retorna a função que o
+	// libera. Nil quando o próprio banco já serializa as escritas.
+	lock func(ctx context.Context, conn *sql.Conn) (func(), error)
+}
+
+var postgresDialect = dialect{
+	versionTypeQuery: `
+		SELECT data_type
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'schema_migrations' AND column_name = 'version'
+	`,
+	createTableQuery: `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT NOT NULL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			dirty BOOLEAN NOT NULL DEFAULT false,
+			checksum CHAR(64),
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			applied_by VARCHAR(255),
+			duration_ms BIGINT
+		)`,
+	upgradeTableQuery: `
+		ALTER TABLE schema_migrations
+			ADD COLUMN IF NOT EXISTS checksum CHAR(64),
+			ADD COLUMN IF NOT EXISTS applied_by VARCHAR(255),
+			ADD COLUMN IF NOT EXISTS duration_ms BIGINT`,
+	lock: advisoryLock,
+}
+
+// sqliteDialect não usa lock: o SQLite permite um único escritor por arquivo
+// e cada migration roda numa transação própria
+var sqliteDialect = dialect{
+	versionTypeQuery: "SELECT type FROM pragma_table_info('schema_migrations') WHERE name = 'version'",
+	createTableQuery: `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER NOT NULL PRIMARY KEY,
+			name TEXT NOT NULL,
+			dirty BOOLEAN NOT NULL DEFAULT false,
+			checksum TEXT,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			applied_by TEXT,
+			duration_ms INTEGER
+		)`,
+}
+
+func dialectFor(driver string) (dialect, error) {
+	switch driver {
+	case "", DriverPostgres:
+		return postgresDialect, nil
+	case DriverSQLite:
+		return sqliteDialect, nil
+	}
+	return dialect{}, fmt.Errorf("driver de migrations desconhecido: %s", driver)
+}
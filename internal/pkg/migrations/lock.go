@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// advisoryLockID identifica o lock que serializa a execução das migrations
+// entre réplicas da API e o comando migrate
+const advisoryLockID int64 = 0x636f6e7461637473 // "contacts"
+
+// withLock executa fn numa conexão dedicada que detém o lock das migrations
+// durante toda a operação
+func (m *Migrator) withLock(ctx context.Context, fn func(conn dbConn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao obter conexão para as migrations: %v", err)
+	}
+	defer conn.Close()
+
+	if m.dialect.lock != nil {
+		unlock, err := m.dialect.lock(ctx, conn)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	return fn(conn)
+}
+
+// advisoryLock obtém o advisory lock do PostgreSQL na sessão da conexão,
+// aguardando caso outra instância já o detenha
+func advisoryLock(ctx context.Context, conn *sql.Conn) (func(), error) {
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", advisoryLockID).Scan(&acquired); err != nil {
+		return nil, fmt.Errorf("erro ao obter lock das migrations: %v", err)
+	}
+
+	if !acquired {
+		slog.Info("Aguardando outra instância concluir as migrations")
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockID); err != nil {
+			return nil, fmt.Errorf("erro ao obter lock das migrations: %v", err)
+		}
+	}
+
+	return func() {
+		// O lock pertence à sessão: se não for possível liberá-lo, a conexão é
+		// descartada em vez de voltar ao pool ainda com o lock
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if _, err := conn.ExecContext(unlockCtx, "SELECT pg_advisory_unlock($1)", advisoryLockID); err != nil {
+			slog.Error("Erro ao liberar lock das migrations", "error", err)
+			conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}, nil
+}
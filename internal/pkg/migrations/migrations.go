@@ -1,159 +1,206 @@
 package migrations
 
 import (
+	"context"
+	"crypto/sha256"
 	"database/sql"
+	"embed"
+	"encoding/hex"
 	"fmt"
-	"log"
-	"os"
-	"path/filepath"
-	"runtime"
+	"io/fs"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 )
 
-func RunMigrations(db *sql.DB) error {
-	migrationDir, err := getMigrationsDir()
-	if err != nil {
-		return fmt.Errorf("erro ao localizar diretório de migrations: %v", err)
-	}
-
-	log.Printf("Usando diretório de migrations: %s", migrationDir)
+// files contém as migrations do PostgreSQL embutidas no binário, no formato
+// NNN-nome.up.sql e NNN-nome.down.sql
+//
+//go:embed *.sql
+var files embed.FS
+
+// sqliteFiles contém o conjunto próprio de migrations do SQLite, no mesmo formato
+//
+//go:embed sqlite/*.sql
+var sqliteFiles embed.FS
+
+// noTransactionDirective, numa linha própria do arquivo, faz o script rodar
+// fora de uma transação, comando a comando (ex.: CREATE INDEX CONCURRENTLY)
+const noTransactionDirective = "-- +migrate notransaction"
+
+// fileNamePattern separa versão, nome e direção do arquivo de migration
+var fileNamePattern = regexp.MustCompile(`^(\d+)-([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration é um par de scripts up/down identificado pela versão. As
+// migrations em Go usam UpFunc e DownFunc no lugar dos scripts SQL.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+
+	UpFunc   GoFunc
+	DownFunc GoFunc
+
+	// UpNoTransaction e DownNoTransaction executam a direção fora de uma
+	// transação; a versão fica marcada como dirty até terminar com sucesso
+	UpNoTransaction   bool
+	DownNoTransaction bool
+
+	// Checksum é o SHA-256 do script up, usado para detectar migrations
+	// alteradas depois de aplicadas
+	Checksum string
+}
 
-	err = createMigrationsTable(db)
-	if err != nil {
-		return err
-	}
+// String retorna a migration no formato do nome do arquivo, sem a direção
+func (m Migration) String() string {
+	return fmt.Sprintf("%03d-%s", m.Version, m.Name)
+}
 
-	files, err := listMigrationFiles(migrationDir)
-	if err != nil {
-		return err
+// Embedded retorna as migrations SQL embutidas no binário para o driver
+// informado. No PostgreSQL, as migrations em Go registradas com Register
+// entram na mesma sequência, ordenada por versão.
+func Embedded(driver string) ([]Migration, error) {
+	switch driver {
+	case "", DriverPostgres:
+		migrations, err := Load(files)
+		if err != nil {
+			return nil, err
+		}
+		return merge(migrations, registered())
+	case DriverSQLite:
+		source, err := fs.Sub(sqliteFiles, "sqlite")
+		if err != nil {
+			return nil, fmt.Errorf("erro ao listar migrations: %v", err)
+		}
+		return Load(source)
 	}
+	return nil, fmt.Errorf("driver de migrations desconhecido: %s", driver)
+}
 
-	sort.Strings(files)
-
-	appliedMigrations, err := getAppliedMigrations(db)
+// Load lê as migrations da raiz de source. Toda versão precisa dos arquivos up
+// e down, e versões repetidas com nomes diferentes são rejeitadas.
+func Load(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("erro ao listar migrations: %v", err)
 	}
 
-	for _, file := range files {
-		fileName := filepath.Base(file)
-
-		if appliedMigrations[fileName] {
-			log.Printf("Migration '%s' já foi aplicada anteriormente", fileName)
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
 			continue
 		}
 
-		content, err := os.ReadFile(file)
-		if err != nil {
-			return fmt.Errorf("erro ao ler arquivo de migration %s: %v", fileName, err)
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("nome de migration inválido: %s (esperado NNN-nome.up.sql ou NNN-nome.down.sql)", entry.Name())
 		}
 
-		log.Printf("Aplicando migration: %s", fileName)
-
-		tx, err := db.Begin()
-		if err != nil {
-			return fmt.Errorf("erro ao iniciar transação: %v", err)
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("versão de migration inválida: %s", entry.Name())
 		}
 
-		_, err = tx.Exec(string(content))
+		content, err := fs.ReadFile(source, entry.Name())
 		if err != nil {
-			tx.Rollback()
-			return fmt.Errorf("erro ao executar migration %s: %v", fileName, err)
+			return nil, fmt.Errorf("erro ao ler arquivo de migration %s: %v", entry.Name(), err)
 		}
 
-		_, err = tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", fileName)
-		if err != nil {
-			tx.Rollback()
-			return fmt.Errorf("erro ao registrar migration %s: %v", fileName, err)
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
 		}
-
-		err = tx.Commit()
-		if err != nil {
-			return fmt.Errorf("erro ao finalizar transação: %v", err)
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("versão %d usada por mais de uma migration: %s e %s", version, migration.Name, match[2])
 		}
 
-		log.Printf("Migração '%s' aplicada com sucesso", fileName)
+		if match[3] == "up" {
+			migration.Up = string(content)
+			migration.UpNoTransaction = hasNoTransactionDirective(migration.Up)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+			migration.DownNoTransaction = hasNoTransactionDirective(migration.Down)
+		}
 	}
 
-	log.Println("Todas as migrations foram aplicadas com sucesso")
-	return nil
-}
-
-func getMigrationsDir() (string, error) {
-	paths := []string{
-		"internal/pkg/migrations",
-		"../internal/pkg/migrations",
-		"../../internal/pkg/migrations",
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %s precisa dos arquivos up e down", migration)
+		}
+		migrations = append(migrations, *migration)
 	}
 
-	_, filename, _, ok := runtime.Caller(0)
-	if ok {
-		dir := filepath.Dir(filename)
-		paths = append(paths, dir)
-	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
 
-	for _, path := range paths {
-		if _, err := os.Stat(path); !os.IsNotExist(err) {
-			return path, nil
+	return migrations, nil
+}
+
+func hasNoTransactionDirective(script string) bool {
+	for _, line := range strings.Split(script, "\n") {
+		if strings.TrimSpace(line) == noTransactionDirective {
+			return true
 		}
 	}
-
-	return "", fmt.Errorf("diretório de migrations não encontrado")
+	return false
 }
 
-func createMigrationsTable(db *sql.DB) error {
-	query := `
-	CREATE TABLE IF NOT EXISTS schema_migrations (
-		version VARCHAR(255) NOT NULL PRIMARY KEY,
-		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
-	);`
-
-	_, err := db.Exec(query)
-	if err != nil {
-		return fmt.Errorf("erro ao criar tabela de controle de migrations: %v", err)
+// merge combina duas sequências de migrations rejeitando versões repetidas
+func merge(a, b []Migration) ([]Migration, error) {
+	seen := make(map[int64]Migration, len(a)+len(b))
+	merged := make([]Migration, 0, len(a)+len(b))
+	for _, migration := range append(append([]Migration{}, a...), b...) {
+		if other, ok := seen[migration.Version]; ok {
+			return nil, fmt.Errorf("versão %d usada por mais de uma migration: %s e %s", migration.Version, other.Name, migration.Name)
+		}
+		seen[migration.Version] = migration
+		merged = append(merged, migration)
 	}
 
-	return nil
-}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Version < merged[j].Version
+	})
 
-func getAppliedMigrations(db *sql.DB) (map[string]bool, error) {
-	migrations := make(map[string]bool)
+	return merged, nil
+}
 
-	rows, err := db.Query("SELECT version FROM schema_migrations")
+// RunMigrations aplica todas as migrations embutidas que ainda não foram aplicadas
+func RunMigrations(db *sql.DB, opts Options) error {
+	migrator, err := New(db, opts)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao obter migrations aplicadas: %v", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var version string
-		if err := rows.Scan(&version); err != nil {
-			return nil, err
-		}
-		migrations[version] = true
+		return err
 	}
 
-	return migrations, nil
+	_, err = migrator.Up(context.Background())
+	return err
 }
 
-func listMigrationFiles(dir string) ([]string, error) {
-	var files []string
-
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("diretório de migrations não encontrado: %s", dir)
+// PendingMigrations retorna, em ordem, as migrations que ainda não foram
+// aplicadas no banco do driver informado. A consulta respeita o prazo de ctx.
+func PendingMigrations(ctx context.Context, db *sql.DB, driver string) ([]string, error) {
+	migrator, err := New(db, Options{Driver: driver})
+	if err != nil {
+		return nil, err
 	}
 
-	entries, err := os.ReadDir(dir)
+	statuses, err := migrator.Status(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".sql") {
-			files = append(files, filepath.Join(dir, entry.Name()))
+	pending := []string{}
+	for _, status := range statuses {
+		if !status.Applied {
+			pending = append(pending, status.Migration.String())
 		}
 	}
 
-	return files, nil
+	return pending, nil
 }
@@ -0,0 +1,101 @@
+package migrations
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestEmbedded(t *testing.T) {
+	for _, driver := range []string{DriverPostgres, DriverSQLite} {
+		t.Run(driver, func(t *testing.T) {
+			migrations, err := Embedded(driver)
+			if err != nil {
+				t.Fatalf("Embedded: %v", err)
+			}
+			if len(migrations) == 0 {
+				t.Fatal("nenhuma migration embutida")
+			}
+
+			for i, migration := range migrations {
+				if migration.Version != int64(i+1) {
+					t.Errorf("migration %s fora de sequência na posição %d", migration, i)
+				}
+				if len(migration.Checksum) != 64 {
+					t.Errorf("migration %s sem checksum", migration)
+				}
+			}
+		})
+	}
+
+	if _, err := Embedded("mysql"); err == nil {
+		t.Fatal("Embedded aceitou driver desconhecido")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	source := fstest.MapFS{
+		"002-second.up.sql":   {Data: []byte("-- +migrate notransaction\nCREATE INDEX CONCURRENTLY i ON t (c);")},
+		"002-second.down.sql": {Data: []byte("DROP INDEX i;")},
+		"001-first.up.sql":    {Data: []byte("CREATE TABLE t (c int);")},
+		"001-first.down.sql":  {Data: []byte("DROP TABLE t;")},
+	}
+
+	migrations, err := Load(source)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if len(migrations) != 2 || migrations[0].String() != "001-first" || migrations[1].String() != "002-second" {
+		t.Fatalf("migrations = %v", migrations)
+	}
+	if migrations[0].UpNoTransaction || !migrations[1].UpNoTransaction || migrations[1].DownNoTransaction {
+		t.Fatalf("diretiva notransaction interpretada incorretamente: %+v", migrations)
+	}
+}
+
+func TestLoadRejectsInvalidFiles(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"SemDown": {
+			"001-first.up.sql": {Data: []byte("SELECT 1;")},
+		},
+		"NomeInválido": {
+			"first.sql": {Data: []byte("SELECT 1;")},
+		},
+		"VersãoRepetida": {
+			"001-first.up.sql":   {Data: []byte("SELECT 1;")},
+			"001-first.down.sql": {Data: []byte("SELECT 1;")},
+			"001-other.up.sql":   {Data: []byte("SELECT 1;")},
+			"001-other.down.sql": {Data: []byte("SELECT 1;")},
+		},
+	}
+
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Load(source); err == nil {
+				t.Fatal("Load aceitou migrations inválidas")
+			}
+		})
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- +migrate notransaction
+CREATE INDEX CONCURRENTLY IF NOT EXISTS a ON b (c);
+INSERT INTO t VALUES ('a;b', 'it''s');
+CREATE FUNCTION f() RETURNS int AS $body$ SELECT 1; $body$ LANGUAGE sql;
+/* x; y */ SELECT $1;
+-- comentário final;
+`
+
+	want := []string{
+		"-- +migrate notransaction\nCREATE INDEX CONCURRENTLY IF NOT EXISTS a ON b (c)",
+		"INSERT INTO t VALUES ('a;b', 'it''s')",
+		"CREATE FUNCTION f() RETURNS int AS $body$ SELECT 1; $body$ LANGUAGE sql",
+		"/* x; y */ SELECT $1",
+	}
+
+	if got := splitStatements(script); !reflect.DeepEqual(got, want) {
+		t.Fatalf("splitStatements = %q, esperado %q", got, want)
+	}
+}
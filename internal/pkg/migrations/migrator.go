@@ -0,0 +1,557 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Direções em que uma migration pode ser executada
+const (
+	DirectionUp   = "up"
+	DirectionDown = "down"
+)
+
+// ErrPendingMigrations indica que o banco não está na versão esperada pelo código
+var ErrPendingMigrations = errors.New("migrations pendentes")
+
+// ErrChecksumMismatch indica que uma migration já aplicada foi alterada no código
+var ErrChecksumMismatch = errors.New("migrations aplicadas foram alteradas")
+
+// Options ajusta o comportamento do Migrator
+type Options struct {
+	// AllowDrift permite operar mesmo quando o checksum de uma migration
+	// aplicada difere do arquivo atual; a divergência é apenas registrada no log
+	AllowDrift bool
+
+	// AppliedBy identifica quem aplicou as migrations. Vazio usa
+	// "<programa>@<hostname>".
+	AppliedBy string
+
+	// Driver escolhe o banco (postgres ou sqlite) e, em New, o conjunto de
+	// migrations embutidas. Vazio usa postgres.
+	Driver string
+}
+
+// Status descreve a situação de uma migration no banco
+type Status struct {
+	Migration Migration
+	Applied   bool
+	AppliedAt time.Time
+	AppliedBy string
+	Duration  time.Duration
+
+	// Dirty indica que a execução foi interrompida e o esquema precisa ser
+	// corrigido manualmente antes de usar force
+	Dirty bool
+
+	// Missing indica uma versão aplicada no banco que não existe no código
+	Missing bool
+
+	// Drift indica que o arquivo foi alterado depois de aplicado
+	Drift bool
+}
+
+// dbConn abstrai *sql.DB e *sql.Conn. As operações que alteram o banco rodam
+// na conexão que detém o lock das migrations.
+type dbConn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// Migrator aplica e reverte migrations registrando as versões na tabela
+// schema_migrations
+type Migrator struct {
+	db         *sql.DB
+	dialect    dialect
+	migrations []Migration
+	opts       Options
+}
+
+// New cria um Migrator com as migrations embutidas no binário para o driver
+// de opts
+func New(db *sql.DB, opts Options) (*Migrator, error) {
+	migrations, err := Embedded(opts.Driver)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithMigrations(db, migrations, opts)
+}
+
+// NewWithMigrations cria um Migrator com um conjunto de migrations ordenado por versão
+func NewWithMigrations(db *sql.DB, migrations []Migration, opts Options) (*Migrator, error) {
+	dialect, err := dialectFor(opts.Driver)
+	if err != nil {
+		return nil, err
+	}
+	if opts.AppliedBy == "" {
+		opts.AppliedBy = defaultAppliedBy()
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations, opts: opts}, nil
+}
+
+// Migrations retorna as migrations conhecidas pelo Migrator
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Status retorna a situação de cada migration conhecida, seguida das versões
+// aplicadas que não existem no código. Não altera o banco.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.AppliedAt
+			status.AppliedBy = record.AppliedBy
+			status.Duration = record.Duration
+			status.Dirty = record.Dirty
+			status.Drift = record.Checksum != "" && record.Checksum != migration.Checksum
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, record := range applied {
+		statuses = append(statuses, Status{
+			Migration: Migration{Version: record.Version, Name: record.Name},
+			Applied:   true,
+			AppliedAt: record.AppliedAt,
+			AppliedBy: record.AppliedBy,
+			Duration:  record.Duration,
+			Dirty:     record.Dirty,
+			Missing:   true,
+		})
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].Migration.Version < statuses[j].Migration.Version
+	})
+
+	return statuses, nil
+}
+
+// Verify confere, sem alterar o banco, se todas as migrations conhecidas foram
+// aplicadas sem alterações. O erro lista as versões pendentes, incompletas ou
+// alteradas.
+func (m *Migrator) Verify(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending, dirty, drifted []string
+	for _, status := range statuses {
+		switch {
+		case !status.Applied:
+			pending = append(pending, status.Migration.String())
+		case status.Dirty:
+			dirty = append(dirty, status.Migration.String())
+		case status.Drift:
+			drifted = append(drifted, status.Migration.String())
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %s", ErrPendingMigrations, strings.Join(pending, ", "))
+	}
+	if len(dirty) > 0 {
+		return fmt.Errorf("migrations incompletas; corrija o esquema e use force: %s", strings.Join(dirty, ", "))
+	}
+	if len(drifted) > 0 {
+		if !m.opts.AllowDrift {
+			return fmt.Errorf("%w: %s", ErrChecksumMismatch, strings.Join(drifted, ", "))
+		}
+		slog.Warn("Migrations aplicadas foram alteradas; divergência ignorada", "migrations", drifted)
+	}
+
+	return nil
+}
+
+// Up aplica, em ordem, todas as migrations pendentes
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(ctx, func(conn dbConn) error {
+		applied, err := m.prepare(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.run(ctx, conn, migration, DirectionUp); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+
+		slog.Info("Todas as migrations foram aplicadas com sucesso")
+		return nil
+	})
+
+	return done, err
+}
+
+// Down reverte as últimas n migrations aplicadas
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("a quantidade de migrations a reverter deve ser positiva")
+	}
+
+	var done []Migration
+
+	err := m.withLock(ctx, func(conn dbConn) error {
+		applied, err := m.prepare(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := sortedVersions(applied)
+		if n > len(versions) {
+			n = len(versions)
+		}
+
+		for i := len(versions) - 1; i >= len(versions)-n; i-- {
+			migration, err := m.find(versions[i])
+			if err != nil {
+				return err
+			}
+			if err := m.run(ctx, conn, migration, DirectionDown); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// Goto leva o banco até a versão informada, revertendo as migrations
+// posteriores e aplicando as pendentes até ela. A versão 0 reverte todas.
+func (m *Migrator) Goto(ctx context.Context, version int64) ([]Migration, error) {
+	if version != 0 {
+		if _, err := m.find(version); err != nil {
+			return nil, err
+		}
+	}
+
+	var done []Migration
+
+	err := m.withLock(ctx, func(conn dbConn) error {
+		applied, err := m.prepare(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := sortedVersions(applied)
+		for i := len(versions) - 1; i >= 0 && versions[i] > version; i-- {
+			migration, err := m.find(versions[i])
+			if err != nil {
+				return err
+			}
+			if err := m.run(ctx, conn, migration, DirectionDown); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.run(ctx, conn, migration, DirectionUp); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// Force registra as migrations até a versão informada como aplicadas, e as
+// posteriores como não aplicadas, sem executar nenhum script. Os checksums
+// passam a ser os dos arquivos atuais. Serve para recuperar o controle após
+// uma correção manual do esquema.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if version != 0 {
+		if _, err := m.find(version); err != nil {
+			return err
+		}
+	}
+
+	return m.withLock(ctx, func(conn dbConn) error {
+		if err := m.ensureTable(ctx, conn); err != nil {
+			return err
+		}
+
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("erro ao iniciar transação: %v", err)
+		}
+		defer tx.Rollback()
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version > $1", version); err != nil {
+			return fmt.Errorf("erro ao forçar versão %d: %v", version, err)
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO schema_migrations (version, name, checksum, applied_by)
+				VALUES ($1, $2, $3, $4)
+				ON CONFLICT (version) DO UPDATE SET dirty = false, checksum = EXCLUDED.checksum
+			`, migration.Version, migration.Name, migration.Checksum, m.opts.AppliedBy)
+			if err != nil {
+				return fmt.Errorf("erro ao forçar versão %d: %v", version, err)
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, "UPDATE schema_migrations SET dirty = false"); err != nil {
+			return fmt.Errorf("erro ao forçar versão %d: %v", version, err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("erro ao finalizar transação: %v", err)
+		}
+
+		slog.Info("Versão das migrations forçada", "version", version)
+		return nil
+	})
+}
+
+// prepare garante a tabela de controle e recusa operar sobre um banco em
+// estado inconsistente ou com migrations aplicadas alteradas
+func (m *Migrator) prepare(ctx context.Context, conn dbConn) (map[int64]appliedRecord, error) {
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifted []string
+	for _, record := range applied {
+		if record.Dirty {
+			return nil, fmt.Errorf("migration %03d-%s ficou incompleta; corrija o esquema e use force", record.Version, record.Name)
+		}
+
+		migration, err := m.find(record.Version)
+		if err != nil {
+			continue
+		}
+
+		if record.Checksum == "" {
+			// Versões registradas antes dos checksums adotam o arquivo atual
+			if err := m.recordChecksum(ctx, conn, migration); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if record.Checksum != migration.Checksum {
+			drifted = append(drifted, migration.String())
+		}
+	}
+
+	if len(drifted) > 0 {
+		sort.Strings(drifted)
+		if !m.opts.AllowDrift {
+			return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, strings.Join(drifted, ", "))
+		}
+		slog.Warn("Migrations aplicadas foram alteradas; divergência ignorada", "migrations", drifted)
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) recordChecksum(ctx context.Context, conn dbConn, migration Migration) error {
+	_, err := conn.ExecContext(ctx, "UPDATE schema_migrations SET checksum = $1 WHERE version = $2", migration.Checksum, migration.Version)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar checksum da migration %s: %v", migration, err)
+	}
+	return nil
+}
+
+// run executa uma direção da migration. Por padrão o script e a atualização
+// da tabela de controle rodam na mesma transação; sem transação, a versão é
+// marcada como dirty antes da execução e liberada apenas ao final.
+func (m *Migrator) run(ctx context.Context, conn dbConn, migration Migration, direction string) error {
+	slog.Info("Executando migration", "migration", migration.String(), "direction", direction)
+
+	noTransaction := migration.UpNoTransaction
+	if direction == DirectionDown {
+		noTransaction = migration.DownNoTransaction
+	}
+
+	start := time.Now()
+
+	var err error
+	if noTransaction {
+		err = m.runWithoutTransaction(ctx, conn, migration, direction, start)
+	} else {
+		err = m.runInTransaction(ctx, conn, migration, direction, start)
+	}
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Migration executada com sucesso", "migration", migration.String(), "direction", direction, "duration", time.Since(start))
+	return nil
+}
+
+func (m *Migrator) runInTransaction(ctx context.Context, conn dbConn, migration Migration, direction string, start time.Time) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := execute(ctx, tx, migration, direction, false); err != nil {
+		return err
+	}
+
+	if direction == DirectionUp {
+		err = m.recordApplied(ctx, tx, migration, false, time.Since(start))
+	} else {
+		err = recordReverted(ctx, tx, migration)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao finalizar transação: %v", err)
+	}
+	return nil
+}
+
+func (m *Migrator) runWithoutTransaction(ctx context.Context, conn dbConn, migration Migration, direction string, start time.Time) error {
+	var err error
+	if direction == DirectionUp {
+		err = m.recordApplied(ctx, conn, migration, true, 0)
+	} else {
+		_, err = conn.ExecContext(ctx, "UPDATE schema_migrations SET dirty = true WHERE version = $1", migration.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao marcar migration %s como em execução: %v", migration, err)
+	}
+
+	if err := execute(ctx, conn, migration, direction, true); err != nil {
+		return fmt.Errorf("%v; a versão %d ficou marcada como incompleta", err, migration.Version)
+	}
+
+	if direction == DirectionUp {
+		_, err = conn.ExecContext(ctx,
+			"UPDATE schema_migrations SET dirty = false, duration_ms = $1 WHERE version = $2",
+			time.Since(start).Milliseconds(), migration.Version)
+		if err != nil {
+			return fmt.Errorf("erro ao registrar migration %s: %v", migration, err)
+		}
+		return nil
+	}
+
+	return recordReverted(ctx, conn, migration)
+}
+
+// execute roda a função Go ou o script SQL da direção. Sem transação, o script
+// é enviado comando a comando para que cada um seja confirmado separadamente.
+func execute(ctx context.Context, db Executor, migration Migration, direction string, noTransaction bool) error {
+	fn, script := migration.UpFunc, migration.Up
+	if direction == DirectionDown {
+		fn, script = migration.DownFunc, migration.Down
+	}
+
+	if fn != nil {
+		if err := fn(ctx, db); err != nil {
+			return fmt.Errorf("erro ao executar migration %s (%s): %v", migration, direction, err)
+		}
+		return nil
+	}
+
+	statements := []string{script}
+	if noTransaction {
+		statements = splitStatements(script)
+	}
+
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("erro ao executar migration %s (%s): %v", migration, direction, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) recordApplied(ctx context.Context, db Executor, migration Migration, dirty bool, duration time.Duration) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO schema_migrations (version, name, dirty, checksum, duration_ms, applied_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, migration.Version, migration.Name, dirty, migration.Checksum, duration.Milliseconds(), m.opts.AppliedBy)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar migration %s: %v", migration, err)
+	}
+	return nil
+}
+
+func recordReverted(ctx context.Context, db Executor, migration Migration) error {
+	if _, err := db.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+		return fmt.Errorf("erro ao registrar reversão da migration %s: %v", migration, err)
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int64) (Migration, error) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, nil
+		}
+	}
+	return Migration{}, fmt.Errorf("migration %d não existe no código", version)
+}
+
+func sortedVersions(applied map[int64]appliedRecord) []int64 {
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+func defaultAppliedBy() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "desconhecido"
+	}
+	return filepath.Base(os.Args[0]) + "@" + hostname
+}
@@ -0,0 +1,35 @@
+package migrations_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/dbtest"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/migrations"
+)
+
+func TestPendingMigrations(t *testing.T) {
+	db := dbtest.OpenSQLiteAt(t, 4)
+
+	pending, err := migrations.PendingMigrations(context.Background(), db, migrations.DriverSQLite)
+	if err != nil {
+		t.Fatalf("PendingMigrations: %v", err)
+	}
+	want := []string{"005-adds_change_seq_to_contacts", "006-creates_carddav_objects_table"}
+	if !reflect.DeepEqual(pending, want) {
+		t.Fatalf("pendentes = %v, esperado %v", pending, want)
+	}
+
+	// A verificação de prontidão cancela a consulta ao fim do seu prazo
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := migrations.PendingMigrations(ctx, db, migrations.DriverSQLite); err == nil {
+		t.Fatal("PendingMigrations ignorou o contexto cancelado")
+	}
+
+	dbtest.MigrateSQLite(t, db)
+	if pending, err := migrations.PendingMigrations(context.Background(), db, migrations.DriverSQLite); err != nil || len(pending) != 0 {
+		t.Fatalf("pendentes após aplicar = %v, %v", pending, err)
+	}
+}
@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// Executor é o acesso ao banco recebido pelas migrations em Go: a transação da
+// migration ou, com NoTransaction, a conexão que detém o lock das migrations
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// GoFunc é uma direção de uma migration escrita em Go
+type GoFunc func(ctx context.Context, db Executor) error
+
+// GoMigration descreve uma migration escrita em Go
+type GoMigration struct {
+	Version int64
+	Name    string
+	Up      GoFunc
+	Down    GoFunc
+
+	// NoTransaction executa as duas direções fora de uma transação, permitindo
+	// que cada comando (ou cada lote de um Backfill) seja confirmado à parte
+	NoTransaction bool
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[int64]GoMigration{}
+)
+
+// Register adiciona uma migration em Go à sequência das migrations embutidas
+// do PostgreSQL. Deve ser chamado em um init; versões repetidas ou incompletas
+// causam panic.
+func Register(migration GoMigration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if migration.Version <= 0 || !fileNamePattern.MatchString(fmt.Sprintf("%03d-%s.up.sql", migration.Version, migration.Name)) {
+		panic(fmt.Sprintf("migrations: versão ou nome inválido: %d-%s", migration.Version, migration.Name))
+	}
+	if migration.Up == nil || migration.Down == nil {
+		panic(fmt.Sprintf("migrations: %03d-%s precisa das funções Up e Down", migration.Version, migration.Name))
+	}
+	if _, ok := registry[migration.Version]; ok {
+		panic(fmt.Sprintf("migrations: versão %d registrada mais de uma vez", migration.Version))
+	}
+
+	registry[migration.Version] = migration
+}
+
+func registered() []Migration {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	migrations := make([]Migration, 0, len(registry))
+	for _, goMigration := range registry {
+		// O código não é comparável entre versões do binário; o checksum só
+		// identifica a migration pelo nome
+		sum := sha256.Sum256([]byte(fmt.Sprintf("go:%d-%s", goMigration.Version, goMigration.Name)))
+
+		migrations = append(migrations, Migration{
+			Version:           goMigration.Version,
+			Name:              goMigration.Name,
+			UpFunc:            goMigration.Up,
+			DownFunc:          goMigration.Down,
+			UpNoTransaction:   goMigration.NoTransaction,
+			DownNoTransaction: goMigration.NoTransaction,
+			Checksum:          hex.EncodeToString(sum[:]),
+		})
+	}
+
+	return migrations
+}
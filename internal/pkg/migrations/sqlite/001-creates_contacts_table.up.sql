@@ -0,0 +1,14 @@
+-- Os IDs são UUIDs gerados pela aplicação e os horários, gravados pelo driver
+-- em texto no formato RFC 3339
+CREATE TABLE IF NOT EXISTS contacts (
+    id TEXT NOT NULL PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    email TEXT,
+    phone TEXT,
+    category_id TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS contacts_tenant_email_key ON contacts (tenant_id, email);
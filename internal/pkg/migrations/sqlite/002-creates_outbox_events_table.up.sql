@@ -0,0 +1,18 @@
+-- Mesma estrutura da tabela do PostgreSQL; o payload JSON fica em texto
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id TEXT NOT NULL UNIQUE,
+    event_type TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP,
+    delivered_at TIMESTAMP,
+    last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS outbox_events_pending_idx ON outbox_events (aggregate_id, id) WHERE delivered_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_events_delivered_at_idx ON outbox_events (delivered_at) WHERE delivered_at IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Retomada do stream de eventos a partir do Last-Event-ID de cada tenant
+CREATE INDEX IF NOT EXISTS outbox_events_tenant_idx ON outbox_events (tenant_id, id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS contact_tombstones;
+DROP TABLE IF EXISTS contact_change_counters;
+DROP INDEX IF EXISTS contacts_tenant_change_seq_idx;
+ALTER TABLE contacts DROP COLUMN change_seq;
@@ -0,0 +1,34 @@
+-- Mesma estrutura das tabelas do PostgreSQL
+ALTER TABLE contacts ADD COLUMN change_seq INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS contacts_tenant_change_seq_idx ON contacts (tenant_id, change_seq);
+
+CREATE TABLE IF NOT EXISTS contact_change_counters (
+    tenant_id TEXT NOT NULL PRIMARY KEY,
+    last_seq INTEGER NOT NULL,
+    purged_seq INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS contact_tombstones (
+    tenant_id TEXT NOT NULL,
+    change_seq INTEGER NOT NULL,
+    contact_id TEXT NOT NULL,
+    deleted_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (tenant_id, change_seq)
+);
+
+CREATE INDEX IF NOT EXISTS contact_tombstones_deleted_at_idx ON contact_tombstones (deleted_at);
+
+-- Mesma numeração dos contatos existentes feita no PostgreSQL
+UPDATE contacts SET change_seq = numbered.seq
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY tenant_id ORDER BY created_at, id) AS seq
+    FROM contacts
+    WHERE change_seq = 0
+) AS numbered
+WHERE contacts.id = numbered.id;
+
+-- O WHERE evita que o SQLite leia o ON CONFLICT como parte do SELECT
+INSERT INTO contact_change_counters (tenant_id, last_seq)
+SELECT tenant_id, MAX(change_seq) FROM contacts WHERE true GROUP BY tenant_id
+ON CONFLICT (tenant_id) DO NOTHING;
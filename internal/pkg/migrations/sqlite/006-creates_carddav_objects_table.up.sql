@@ -0,0 +1,10 @@
+-- Mesma estrutura da tabela do PostgreSQL
+CREATE TABLE IF NOT EXISTS carddav_objects (
+    tenant_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    contact_id TEXT NOT NULL,
+    uid TEXT NOT NULL,
+    PRIMARY KEY (tenant_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS carddav_objects_contact_id_idx ON carddav_objects (tenant_id, contact_id);
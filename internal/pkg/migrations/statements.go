@@ -0,0 +1,106 @@
+package migrations
+
+import "strings"
+
+// splitStatements divide um script SQL nos comandos separados por ";",
+// ignorando os que aparecem em strings, identificadores, comentários e blocos
+// delimitados por dollar quotes. Usado nos scripts sem transação, já que o
+// PostgreSQL executa numa transação implícita vários comandos enviados juntos.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" && !onlyComments(statement) {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+
+		switch {
+		case c == '-' && strings.HasPrefix(script[i:], "--"):
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				end = len(script) - i
+			}
+			current.WriteString(script[i : i+end])
+			i += end - 1
+
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				end = len(script) - i - 2
+			} else {
+				end += 2
+			}
+			current.WriteString(script[i : i+2+end])
+			i += 2 + end - 1
+
+		case c == '\'' || c == '"':
+			end := i + 1
+			for end < len(script) {
+				if script[end] == c {
+					// Aspas duplicadas representam a própria aspa
+					if end+1 < len(script) && script[end+1] == c {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			current.WriteString(script[i:min(end+1, len(script))])
+			i = end
+
+		case c == '$':
+			tag := dollarQuoteTag(script[i:])
+			if tag == "" {
+				current.WriteByte(c)
+				continue
+			}
+			end := strings.Index(script[i+len(tag):], tag)
+			if end < 0 {
+				end = len(script) - i - len(tag)
+			} else {
+				end += len(tag)
+			}
+			current.WriteString(script[i : i+len(tag)+end])
+			i += len(tag) + end - 1
+
+		case c == ';':
+			flush()
+
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	flush()
+	return statements
+}
+
+// dollarQuoteTag retorna o delimitador ($$ ou $tag$) no início de s
+func dollarQuoteTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if c == '$' {
+			return s[:i+1]
+		}
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 1 && c >= '0' && c <= '9') {
+			return ""
+		}
+	}
+	return ""
+}
+
+func onlyComments(statement string) bool {
+	for _, line := range strings.Split(statement, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "--") {
+			return false
+		}
+	}
+	return true
+}
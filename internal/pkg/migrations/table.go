@@ -0,0 +1,189 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// legacyVersionPattern reconhece os registros do formato anterior, em que a
+// coluna version guardava o nome do arquivo (ex.: 001-creates_contacts_table.sql)
+var legacyVersionPattern = regexp.MustCompile(`^(\d+)-(.+?)(?:\.up)?\.sql$`)
+
+// Formatos possíveis da tabela schema_migrations
+const (
+	tableMissing = iota
+	tableLegacy
+	tableCurrent
+)
+
+type appliedRecord struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	Dirty     bool
+	AppliedAt time.Time
+	AppliedBy string
+	Duration  time.Duration
+}
+
+func (m *Migrator) tableFormat(ctx context.Context, conn dbConn) (int, error) {
+	var dataType string
+	err := conn.QueryRowContext(ctx, m.dialect.versionTypeQuery).Scan(&dataType)
+	if err == sql.ErrNoRows {
+		return tableMissing, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("erro ao inspecionar tabela de controle de migrations: %v", err)
+	}
+
+	if dataType == "character varying" {
+		return tableLegacy, nil
+	}
+	return tableCurrent, nil
+}
+
+// ensureTable cria a tabela de controle, converte a do formato anterior ou
+// adiciona as colunas que ainda não existem
+func (m *Migrator) ensureTable(ctx context.Context, conn dbConn) error {
+	format, err := m.tableFormat(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case tableCurrent:
+		if m.dialect.upgradeTableQuery == "" {
+			return nil
+		}
+		if _, err := conn.ExecContext(ctx, m.dialect.upgradeTableQuery); err != nil {
+			return fmt.Errorf("erro ao atualizar tabela de controle de migrations: %v", err)
+		}
+		return nil
+	case tableLegacy:
+		return m.convertLegacyTable(ctx, conn)
+	}
+
+	if _, err := conn.ExecContext(ctx, m.dialect.createTableQuery); err != nil {
+		return fmt.Errorf("erro ao criar tabela de controle de migrations: %v", err)
+	}
+	return nil
+}
+
+// convertLegacyTable recria a tabela de controle no formato atual preservando
+// as versões já aplicadas e a data de aplicação
+func (m *Migrator) convertLegacyTable(ctx context.Context, conn dbConn) error {
+	records, err := m.legacyRecords(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DROP TABLE schema_migrations"); err != nil {
+		return fmt.Errorf("erro ao converter tabela de controle de migrations: %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, m.dialect.createTableQuery); err != nil {
+		return fmt.Errorf("erro ao converter tabela de controle de migrations: %v", err)
+	}
+
+	for _, record := range records {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)",
+			record.Version, record.Name, record.AppliedAt)
+		if err != nil {
+			return fmt.Errorf("erro ao converter tabela de controle de migrations: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao finalizar transação: %v", err)
+	}
+
+	slog.Info("Tabela de controle de migrations convertida para o formato atual", "versions", len(records))
+	return nil
+}
+
+// applied retorna as versões registradas no banco, em qualquer dos formatos
+// da tabela de controle
+func (m *Migrator) applied(ctx context.Context, conn dbConn) (map[int64]appliedRecord, error) {
+	format, err := m.tableFormat(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]appliedRecord)
+
+	switch format {
+	case tableMissing:
+		return applied, nil
+	case tableLegacy:
+		records, err := m.legacyRecords(ctx, conn)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			applied[record.Version] = record
+		}
+		return applied, nil
+	}
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT version, name, COALESCE(checksum, ''), dirty, applied_at, COALESCE(applied_by, ''), COALESCE(duration_ms, 0)
+		FROM schema_migrations
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter migrations aplicadas: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record appliedRecord
+		var durationMs int64
+		if err := rows.Scan(&record.Version, &record.Name, &record.Checksum, &record.Dirty, &record.AppliedAt, &record.AppliedBy, &durationMs); err != nil {
+			return nil, err
+		}
+		record.Duration = time.Duration(durationMs) * time.Millisecond
+		applied[record.Version] = record
+	}
+
+	return applied, rows.Err()
+}
+
+func (m *Migrator) legacyRecords(ctx context.Context, conn dbConn) ([]appliedRecord, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, created_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter migrations aplicadas: %v", err)
+	}
+	defer rows.Close()
+
+	var records []appliedRecord
+	for rows.Next() {
+		var fileName string
+		var appliedAt time.Time
+		if err := rows.Scan(&fileName, &appliedAt); err != nil {
+			return nil, err
+		}
+
+		match := legacyVersionPattern.FindStringSubmatch(fileName)
+		if match == nil {
+			return nil, fmt.Errorf("registro de migration não reconhecido: %s", fileName)
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("registro de migration não reconhecido: %s", fileName)
+		}
+
+		records = append(records, appliedRecord{Version: version, Name: match[2], AppliedAt: appliedAt})
+	}
+
+	return records, rows.Err()
+}
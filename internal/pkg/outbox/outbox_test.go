@@ -0,0 +1,147 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/dbtest"
+)
+
+func TestRelayDeliversInOrderPerAggregate(t *testing.T) {
+	store := newTestStore(t)
+	enqueue(t, store, "a", "b", "a", "b", "a")
+
+	var delivered []string
+	sink := SinkFunc{SinkName: "test", Fn: func(ctx context.Context, message Message) error {
+		delivered = append(delivered, message.AggregateID+":"+message.EventID)
+		return nil
+	}}
+
+	relay := NewRelay(store, []Sink{sink}, RelayOptions{BatchSize: 10, Lease: time.Minute, Backoff: time.Second, MaxBackoff: time.Minute})
+	if err := relay.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := []string{"a:0", "b:1", "a:2", "b:3", "a:4"}
+	if !reflect.DeepEqual(delivered, want) {
+		t.Fatalf("entregas = %v, esperado %v", delivered, want)
+	}
+}
+
+func TestRelayRetriesWithBackoff(t *testing.T) {
+	store := newTestStore(t)
+	enqueue(t, store, "a", "a", "b")
+
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	failing := true
+	var delivered []string
+	sink := SinkFunc{SinkName: "test", Fn: func(ctx context.Context, message Message) error {
+		if failing && message.AggregateID == "a" {
+			return errors.New("destino indisponível")
+		}
+		delivered = append(delivered, message.EventID)
+		return nil
+	}}
+
+	relay := NewRelay(store, []Sink{sink}, RelayOptions{BatchSize: 10, Lease: time.Minute, Backoff: time.Second, MaxBackoff: time.Minute})
+	relay.now = store.now
+
+	// A falha de "a" segura o segundo evento de "a", mas não o de "b"
+	if err := relay.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !reflect.DeepEqual(delivered, []string{"2"}) {
+		t.Fatalf("entregas = %v, esperado [2]", delivered)
+	}
+
+	// Antes do fim da espera nada é entregue
+	failing = false
+	now = now.Add(500 * time.Millisecond)
+	if err := relay.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(delivered) != 1 {
+		t.Fatalf("entregas antes do backoff = %v", delivered)
+	}
+
+	now = now.Add(time.Second)
+	if err := relay.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !reflect.DeepEqual(delivered, []string{"2", "0", "1"}) {
+		t.Fatalf("entregas = %v, esperado [2 0 1]", delivered)
+	}
+}
+
+func TestClaimSkipsLeasedMessages(t *testing.T) {
+	store := newTestStore(t)
+	enqueue(t, store, "a", "b")
+	ctx := context.Background()
+
+	first, err := store.Claim(ctx, 10, time.Minute)
+	if err != nil || len(first) != 2 {
+		t.Fatalf("Claim = %v, %v", first, err)
+	}
+
+	second, err := store.Claim(ctx, 10, time.Minute)
+	if err != nil || len(second) != 0 {
+		t.Fatalf("Claim com lease ativo = %v, %v", second, err)
+	}
+
+	// Um lease expirado (ex.: o processo caiu durante a entrega) libera a mensagem
+	store.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	third, err := store.Claim(ctx, 10, time.Minute)
+	if err != nil || len(third) != 2 {
+		t.Fatalf("Claim após o lease = %v, %v", third, err)
+	}
+}
+
+func TestPurgeRemovesDeliveredMessages(t *testing.T) {
+	store := newTestStore(t)
+	enqueue(t, store, "a", "b")
+	ctx := context.Background()
+
+	messages, err := store.Claim(ctx, 1, time.Minute)
+	if err != nil || len(messages) != 1 {
+		t.Fatalf("Claim = %v, %v", messages, err)
+	}
+	if err := store.MarkDelivered(ctx, messages[0].ID); err != nil {
+		t.Fatalf("MarkDelivered: %v", err)
+	}
+
+	store.now = func() time.Time { return time.Now().Add(time.Hour) }
+	purged, err := store.Purge(ctx, time.Minute)
+	if err != nil || purged != 1 {
+		t.Fatalf("Purge = %d, %v; esperado 1", purged, err)
+	}
+}
+
+func newTestStore(t *testing.T) *SQLStore {
+	t.Helper()
+	return NewStore(dbtest.OpenSQLite(t))
+}
+
+// enqueue grava uma mensagem por agregado, com EventID igual à posição
+func enqueue(t *testing.T, store *SQLStore, aggregates ...string) {
+	t.Helper()
+
+	for i, aggregate := range aggregates {
+		message := Message{
+			EventID:     fmt.Sprint(i),
+			Type:        "test.event",
+			TenantID:    "acme",
+			AggregateID: aggregate,
+			Payload:     []byte(`{}`),
+			CreatedAt:   time.Now(),
+		}
+		if err := Enqueue(context.Background(), store.db, message); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/metrics"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/worker"
+)
+
+// Sink recebe os eventos entregues pelo Relay. A entrega é "pelo menos uma
+// vez": uma mensagem pode chegar de novo após uma falha ou a queda do
+// processo, então o sink deve descartar duplicatas pelo EventID.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, message Message) error
+}
+
+type RelayOptions struct {
+	// BatchSize é o número máximo de mensagens reservadas por consulta
+	BatchSize int
+
+	// Lease é o tempo em que uma mensagem reservada fica indisponível para
+	// outras réplicas; também limita a entrega de cada mensagem
+	Lease time.Duration
+
+	// Backoff é a espera após a primeira falha, dobrada a cada nova falha até MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Relay entrega as mensagens do outbox aos sinks. Uma mensagem só é marcada
+// como entregue depois que todos os sinks a aceitam; se algum falhar, ela é
+// reenviada a todos na próxima tentativa. Enquanto isso, as mensagens
+// seguintes da mesma entidade aguardam, preservando a ordem.
+type Relay struct {
+	store Store
+	sinks []Sink
+	opts  RelayOptions
+	now   func() time.Time
+}
+
+func NewRelay(store Store, sinks []Sink, opts RelayOptions) *Relay {
+	return &Relay{store: store, sinks: sinks, opts: opts, now: time.Now}
+}
+
+// Run entrega lotes de mensagens até não restarem mensagens prontas; cada
+// entrega libera a mensagem seguinte da mesma entidade para o próximo lote.
+// Deve ser executado periodicamente (ex.: por um worker.Task).
+func (r *Relay) Run(ctx context.Context) error {
+	for {
+		messages, err := r.store.Claim(ctx, r.opts.BatchSize, r.opts.Lease)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			if err := r.deliver(ctx, message); err != nil {
+				return err
+			}
+		}
+
+		if len(messages) == 0 || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// deliver publica a mensagem e registra o resultado. Retorna erro apenas
+// quando o resultado não pôde ser gravado; a mensagem volta a ser entregue
+// quando o lease expirar.
+func (r *Relay) deliver(ctx context.Context, message Message) error {
+	publishCtx, cancel := context.WithTimeout(ctx, r.opts.Lease)
+	defer cancel()
+
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Publish(publishCtx, message); err != nil {
+			metrics.OutboxDeliveriesTotal.WithLabelValues(sink.Name(), metrics.OutcomeError).Inc()
+			errs = append(errs, fmt.Errorf("%s: %v", sink.Name(), err))
+			continue
+		}
+		metrics.OutboxDeliveriesTotal.WithLabelValues(sink.Name(), metrics.OutcomeOK).Inc()
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		delay := worker.Backoff(message.Attempts, r.opts.Backoff, r.opts.MaxBackoff)
+		slog.WarnContext(ctx, "Falha ao entregar evento do outbox",
+			"event_id", message.EventID, "event_type", message.Type, "attempt", message.Attempts+1, "retry_in", delay, "error", err)
+		return r.store.MarkFailed(ctx, message.ID, r.now().Add(delay), err.Error())
+	}
+
+	return r.store.MarkDelivered(ctx, message.ID)
+}
+
+// SinkFunc adapta uma função ao Sink
+type SinkFunc struct {
+	SinkName string
+	Fn       func(ctx context.Context, message Message) error
+}
+
+func (s SinkFunc) Name() string {
+	return s.SinkName
+}
+
+func (s SinkFunc) Publish(ctx context.Context, message Message) error {
+	return s.Fn(ctx, message)
+}
+
+type logSink struct{}
+
+// NewLogSink cria um sink que apenas registra cada evento no log, útil em
+// desenvolvimento e para auditoria. O payload não é registrado, já que contém
+// dados pessoais que escapariam da redação de email e phone do logger.
+func NewLogSink() Sink {
+	return logSink{}
+}
+
+func (logSink) Name() string {
+	return "log"
+}
+
+func (logSink) Publish(ctx context.Context, message Message) error {
+	slog.InfoContext(ctx, "Evento publicado",
+		"event_id", message.EventID, "event_type", message.Type, "tenant_id", message.TenantID,
+		"aggregate_id", message.AggregateID)
+	return nil
+}
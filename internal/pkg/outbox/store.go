@@ -0,0 +1,157 @@
+// Package outbox implementa o padrão transactional outbox: os eventos são
+// gravados na tabela outbox_events na mesma transação da alteração que os
+// origina e entregues depois, pelo Relay, aos sinks configurados
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Message é um evento gravado no outbox. AggregateID identifica a entidade
+// alterada; os eventos de uma mesma entidade são entregues na ordem em que
+// foram gravados.
+type Message struct {
+	ID          int64
+	EventID     string
+	Type        string
+	TenantID    string
+	AggregateID string
+	Payload     []byte
+	CreatedAt   time.Time
+
+	// Attempts é o número de entregas que já falharam
+	Attempts int
+}
+
+// Executor abstrai *sql.DB e *sql.Tx. Enqueue deve receber a transação da
+// alteração para que o evento seja gravado junto com ela.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Enqueue grava a mensagem no outbox. A consulta é a mesma no PostgreSQL e no
+// SQLite. Para manter a ordem por entidade, a mensagem deve ser gravada depois
+// da alteração, que bloqueia a linha até o fim da transação.
+func Enqueue(ctx context.Context, exec Executor, message Message) error {
+	query := `
+		INSERT INTO outbox_events (event_id, event_type, tenant_id, aggregate_id, payload, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+	`
+
+	// O payload vai como texto: o lib/pq enviaria []byte como bytea para a coluna JSONB
+	_, err := exec.ExecContext(ctx, query, message.EventID, message.Type, message.TenantID, message.AggregateID, string(message.Payload), message.CreatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("erro ao gravar evento no outbox: %v", err)
+	}
+
+	return nil
+}
+
+// Store dá acesso às mensagens pendentes do outbox
+type Store interface {
+	// Claim reserva por lease até limit mensagens prontas para entrega. De cada
+	// entidade, apenas a mensagem pendente mais antiga pode ser reservada.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]Message, error)
+
+	// MarkDelivered registra a entrega da mensagem
+	MarkDelivered(ctx context.Context, id int64) error
+
+	// MarkFailed registra a falha e libera a mensagem para nova tentativa em nextAttempt
+	MarkFailed(ctx context.Context, id int64, nextAttempt time.Time, cause string) error
+}
+
+// SQLStore guarda as mensagens na tabela outbox_events. As consultas são
+// compatíveis com o PostgreSQL e o SQLite; os horários são sempre gravados em
+// UTC para que o SQLite, que os guarda como texto, os compare corretamente.
+type SQLStore struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+func NewStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db, now: time.Now}
+}
+
+func (s *SQLStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]Message, error) {
+	// A condição sobre locked_until se repete fora da subconsulta para que,
+	// no PostgreSQL, uma réplica que esperou pelo bloqueio da linha não
+	// reserve a mensagem que outra acabou de reservar
+	query := `
+		UPDATE outbox_events SET locked_until = $1
+		WHERE id IN (
+			SELECT o.id FROM outbox_events o
+			WHERE o.delivered_at IS NULL
+				AND o.next_attempt_at <= $2
+				AND (o.locked_until IS NULL OR o.locked_until < $2)
+				AND NOT EXISTS (
+					SELECT 1 FROM outbox_events p
+					WHERE p.aggregate_id = o.aggregate_id AND p.delivered_at IS NULL AND p.id < o.id
+				)
+			ORDER BY o.id
+			LIMIT $3
+		)
+		AND (locked_until IS NULL OR locked_until < $2)
+		RETURNING id, event_id, event_type, tenant_id, aggregate_id, payload, created_at, attempts
+	`
+
+	now := s.now().UTC()
+	rows, err := s.db.QueryContext(ctx, query, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao reservar eventos do outbox: %v", err)
+	}
+	defer rows.Close()
+
+	messages := []Message{}
+	for rows.Next() {
+		var message Message
+		if err := rows.Scan(&message.ID, &message.EventID, &message.Type, &message.TenantID, &message.AggregateID, &message.Payload, &message.CreatedAt, &message.Attempts); err != nil {
+			return nil, fmt.Errorf("erro ao ler evento do outbox: %v", err)
+		}
+		messages = append(messages, message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao reservar eventos do outbox: %v", err)
+	}
+
+	// RETURNING não garante a ordem das linhas
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	return messages, nil
+}
+
+func (s *SQLStore) MarkDelivered(ctx context.Context, id int64) error {
+	query := `
+		UPDATE outbox_events SET delivered_at = $1, locked_until = NULL, last_error = NULL
+		WHERE id = $2
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, s.now().UTC(), id); err != nil {
+		return fmt.Errorf("erro ao registrar entrega do evento %d: %v", id, err)
+	}
+	return nil
+}
+
+func (s *SQLStore) MarkFailed(ctx context.Context, id int64, nextAttempt time.Time, cause string) error {
+	query := `
+		UPDATE outbox_events SET attempts = attempts + 1, next_attempt_at = $1, locked_until = NULL, last_error = $2
+		WHERE id = $3
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, nextAttempt.UTC(), cause, id); err != nil {
+		return fmt.Errorf("erro ao registrar falha do evento %d: %v", id, err)
+	}
+	return nil
+}
+
+// Purge remove as mensagens entregues há mais de olderThan
+func (s *SQLStore) Purge(ctx context.Context, olderThan time.Duration) (int64, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM outbox_events WHERE delivered_at < $1", s.now().Add(-olderThan).UTC())
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover eventos entregues do outbox: %v", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval define de quanto em quanto tempo buckets cheios são descartados
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// MemoryStore mantém os buckets na memória do processo. O estado não é
+// compartilhado entre réplicas.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, cost float64, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now, limit)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: limit.Burst, updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	b.tokens = math.Min(limit.Burst, b.tokens+elapsed*limit.Rate)
+	b.updatedAt = now
+
+	allowed := b.tokens >= cost
+	if allowed {
+		b.tokens -= cost
+	}
+
+	return newResult(allowed, b.tokens, cost, limit), nil
+}
+
+// sweep remove buckets que já teriam sido totalmente repostos, pois são
+// equivalentes a um bucket novo
+func (s *MemoryStore) sweep(now time.Time, limit Limit) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if b.tokens+now.Sub(b.updatedAt).Seconds()*limit.Rate >= limit.Burst {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/apierror"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/metrics"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+// Config define o limite aplicado a cada cliente e o custo de cada rota
+type Config struct {
+	Limit Limit
+
+	// Costs associa "MÉTODO /rota" (ex.: "GET /contacts") ao número de tokens
+	// consumidos. Rotas ausentes consomem DefaultCost.
+	Costs       map[string]float64
+	DefaultCost float64
+
+	// KeyFunc identifica o cliente da requisição. Se nil, usa ClientKey.
+	KeyFunc func(c *gin.Context) string
+}
+
+// DefaultConfig permite rajadas de 60 tokens com reposição de 10 por segundo.
+// A listagem retorna a tabela inteira e por isso custa mais que as demais rotas.
+func DefaultConfig() Config {
+	return Config{
+		Limit: Limit{Rate: 10, Burst: 60},
+		Costs: map[string]float64{
+			"GET /contacts":        5,
+			"GET /contacts/:id":    1,
+			"POST /contacts":       2,
+			"PUT /contacts/:id":    2,
+			"DELETE /contacts/:id": 2,
+		},
+		DefaultCost: 1,
+	}
+}
+
+// ClientKey identifica o cliente pelo principal autenticado ou, na ausência
+// dele, pelo IP de origem. Cabeçalhos que a API não verifica, como
+// X-Tenant-ID, não servem de chave: bastaria variá-los a cada requisição
+// para receber um bucket novo.
+func ClientKey(c *gin.Context) string {
+	if id := c.GetString(tenant.PrincipalKey); id != "" {
+		return "tenant:" + id
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+// Middleware aplica o token bucket de cada cliente, adiciona os cabeçalhos
+// RateLimit-* às respostas e rejeita com 429 quando não há tokens suficientes.
+// Falhas no store não bloqueiam a requisição.
+func Middleware(store Store, cfg Config) gin.HandlerFunc {
+	keyFunc := cfg.KeyFunc
+	if keyFunc == nil {
+		keyFunc = ClientKey
+	}
+
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+
+		cost, ok := cfg.Costs[route]
+		if !ok {
+			cost = cfg.DefaultCost
+		}
+
+		result, err := store.Take(c.Request.Context(), keyFunc(c), cost, cfg.Limit)
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "Erro no rate limit, requisição liberada", "error", err)
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Set("RateLimit-Limit", strconv.FormatFloat(cfg.Limit.Burst, 'f', -1, 64))
+		header.Set("RateLimit-Remaining", strconv.FormatFloat(math.Floor(result.Remaining), 'f', -1, 64))
+		header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			header.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			metrics.RateLimitRejectionsTotal.WithLabelValues(c.Request.Method, c.FullPath()).Inc()
+			apierror.Abort(c, http.StatusTooManyRequests, "Limite de requisições excedido")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
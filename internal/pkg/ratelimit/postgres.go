@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// PostgresStore compartilha os buckets entre réplicas usando a tabela
+// rate_limit_buckets. Cada consumo é um único upsert, atômico por chave.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, cost float64, limit Limit) (Result, error) {
+	// $2 = burst, $3 = custo, $4 = taxa de reposição por segundo. Os tokens
+	// repostos desde a última atualização são calculados no próprio banco para
+	// que o consumo seja atômico mesmo com várias réplicas.
+	query := `
+		INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+		VALUES (
+			$1,
+			CASE WHEN $2::float8 >= $3::float8 THEN $2::float8 - $3::float8 ELSE $2::float8 END,
+			$2::float8 >= $3::float8,
+			now()
+		)
+		ON CONFLICT (key) DO UPDATE SET
+			tokens = CASE
+				WHEN LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $4::float8) >= $3::float8
+				THEN LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $4::float8) - $3::float8
+				ELSE LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $4::float8)
+			END,
+			allowed = LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $4::float8) >= $3::float8,
+			updated_at = now()
+		RETURNING tokens, allowed
+	`
+
+	var (
+		tokens  float64
+		allowed bool
+	)
+	err := s.db.QueryRowContext(ctx, query, key, limit.Burst, cost, limit.Rate).Scan(&tokens, &allowed)
+	if err != nil {
+		return Result{}, fmt.Errorf("erro ao consumir tokens do rate limit: %v", err)
+	}
+
+	return newResult(allowed, tokens, cost, limit), nil
+}
+
+// Purge remove buckets sem uso há mais de idle
+func (s *PostgresStore) Purge(ctx context.Context, idle time.Duration) (int64, error) {
+	result, err := s.db.ExecContext(ctx,
+		"DELETE FROM rate_limit_buckets WHERE updated_at < now() - make_interval(secs => $1::float8)",
+		idle.Seconds(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover buckets de rate limit: %v", err)
+	}
+
+	return result.RowsAffected()
+}
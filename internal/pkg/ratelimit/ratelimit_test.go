@@ -0,0 +1,165 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+// newClockStore cria um MemoryStore com o relógio controlado pelo teste
+func newClockStore() (*MemoryStore, *time.Time) {
+	now := time.Now()
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	ctx := context.Background()
+	limit := Limit{Rate: 2, Burst: 4}
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		cost    float64
+		allowed bool
+		// remaining são os tokens após a tentativa
+		remaining float64
+	}{
+		{name: "bucket novo começa cheio", cost: 3, allowed: true, remaining: 1},
+		{name: "custo acima do saldo é rejeitado", cost: 2, allowed: false, remaining: 1},
+		{name: "reposição proporcional ao tempo", elapsed: 500 * time.Millisecond, cost: 2, allowed: true, remaining: 0},
+		{name: "reposição limitada ao burst", elapsed: time.Hour, cost: 4, allowed: true, remaining: 0},
+	}
+
+	store, now := newClockStore()
+	for _, tt := range tests {
+		*now = now.Add(tt.elapsed)
+
+		result, err := store.Take(ctx, "cliente", tt.cost, limit)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if result.Allowed != tt.allowed || result.Remaining != tt.remaining {
+			t.Fatalf("%s: allowed %v, remaining %v; esperado %v, %v", tt.name, result.Allowed, result.Remaining, tt.allowed, tt.remaining)
+		}
+	}
+}
+
+func TestMemoryStoreRetryAfter(t *testing.T) {
+	store, _ := newClockStore()
+	limit := Limit{Rate: 2, Burst: 2}
+
+	store.Take(context.Background(), "cliente", 2, limit)
+	result, _ := store.Take(context.Background(), "cliente", 1, limit)
+
+	if result.Allowed || result.RetryAfter != 500*time.Millisecond || result.ResetAfter != time.Second {
+		t.Fatalf("result = %+v, esperado RetryAfter 500ms e ResetAfter 1s", result)
+	}
+}
+
+func newRouter(store Store, limit Limit) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		// Simula a camada de autenticação
+		if principal := c.GetHeader("X-Test-Principal"); principal != "" {
+			c.Set(tenant.PrincipalKey, principal)
+		}
+	})
+	router.Use(Middleware(store, Config{Limit: limit, DefaultCost: 1}))
+	router.GET("/contacts", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func get(router http.Handler, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/contacts", nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddlewareRejectsWithRetryAfter(t *testing.T) {
+	store, now := newClockStore()
+	router := newRouter(store, Limit{Rate: 1, Burst: 2})
+
+	for i := range 2 {
+		rec := get(router, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("requisição %d = %d, esperado 200", i, rec.Code)
+		}
+		if remaining := rec.Header().Get("RateLimit-Remaining"); remaining != strconv.Itoa(1-i) {
+			t.Fatalf("RateLimit-Remaining = %q, esperado %d", remaining, 1-i)
+		}
+	}
+
+	rec := get(router, nil)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" {
+		t.Fatalf("excedente = %d, Retry-After %q; esperado 429 e 1", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	*now = now.Add(time.Second)
+	if rec := get(router, nil); rec.Code != http.StatusOK {
+		t.Fatalf("após a reposição = %d, esperado 200", rec.Code)
+	}
+}
+
+func TestMiddlewareKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		// first esgota o bucket; second indica se a requisição seguinte,
+		// com outros cabeçalhos, usa o mesmo bucket
+		first, second map[string]string
+		sameBucket    bool
+	}{
+		{
+			name:       "cabeçalhos não verificados não criam bucket novo",
+			first:      map[string]string{"X-API-Key": "a", tenant.HeaderName: "acme"},
+			second:     map[string]string{"X-API-Key": "b", tenant.HeaderName: "globex"},
+			sameBucket: true,
+		},
+		{
+			name:       "principais diferentes têm buckets próprios",
+			first:      map[string]string{"X-Test-Principal": "acme"},
+			second:     map[string]string{"X-Test-Principal": "globex"},
+			sameBucket: false,
+		},
+		{
+			name:       "principal não divide o bucket do IP",
+			first:      nil,
+			second:     map[string]string{"X-Test-Principal": "acme"},
+			sameBucket: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, _ := newClockStore()
+			router := newRouter(store, Limit{Rate: 1, Burst: 1})
+
+			if rec := get(router, tt.first); rec.Code != http.StatusOK {
+				t.Fatalf("primeira requisição = %d", rec.Code)
+			}
+
+			want := http.StatusOK
+			if tt.sameBucket {
+				want = http.StatusTooManyRequests
+			}
+			if rec := get(router, tt.second); rec.Code != want {
+				t.Fatalf("segunda requisição = %d, esperado %d", rec.Code, want)
+			}
+		})
+	}
+}
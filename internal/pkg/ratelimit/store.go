@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit define um token bucket: Rate tokens são repostos por segundo até o
+// máximo de Burst tokens
+type Limit struct {
+	Rate  float64
+	Burst float64
+}
+
+// Result é o estado do bucket após uma tentativa de consumo
+type Result struct {
+	Allowed    bool
+	Remaining  float64
+	RetryAfter time.Duration // tempo até haver tokens suficientes (apenas se !Allowed)
+	ResetAfter time.Duration // tempo até o bucket voltar a ficar cheio
+}
+
+// Store guarda o estado dos buckets. Implementações devem consumir os tokens
+// de forma atômica para a chave.
+type Store interface {
+	Take(ctx context.Context, key string, cost float64, limit Limit) (Result, error)
+}
+
+// newResult calcula os tempos de espera a partir dos tokens restantes
+func newResult(allowed bool, tokens, cost float64, limit Limit) Result {
+	result := Result{
+		Allowed:    allowed,
+		Remaining:  math.Max(0, tokens),
+		ResetAfter: secondsToDuration((limit.Burst - tokens) / limit.Rate),
+	}
+
+	if !allowed {
+		result.RetryAfter = secondsToDuration((cost - tokens) / limit.Rate)
+	}
+
+	return result
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
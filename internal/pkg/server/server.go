@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/config"
+)
+
+// ShutdownHook é executado após o servidor parar de aceitar conexões e
+// concluir as requisições em andamento, ainda dentro do prazo de desligamento
+type ShutdownHook func(ctx context.Context) error
+
+// Hooks são as etapas do desligamento gracioso
+type Hooks struct {
+	// Draining é chamado assim que o sinal de término chega, antes de
+	// ShutdownDelay, para que a readiness passe a falhar enquanto o servidor
+	// ainda atende requisições
+	Draining []func()
+
+	// Stopped é executado após o servidor concluir as requisições em andamento
+	Stopped []ShutdownHook
+}
+
+// New cria o servidor HTTP com os timeouts da configuração
+func New(cfg config.ServerConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+}
+
+// Run atende requisições em listener até ctx ser cancelado (ex.: SIGTERM).
+// Então sinaliza os hooks Draining, aguarda cfg.ShutdownDelay para que os
+// balanceadores deixem de enviar tráfego, para de aceitar conexões, aguarda as
+// requisições em andamento e executa os hooks Stopped em ordem, estas últimas
+// etapas dentro de cfg.ShutdownTimeout.
+func Run(ctx context.Context, srv *http.Server, listener net.Listener, cfg config.ServerConfig, hooks Hooks) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("erro ao atender requisições: %v", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("Sinal de término recebido, finalizando requisições em andamento")
+
+	for _, draining := range hooks.Draining {
+		draining()
+	}
+
+	if cfg.ShutdownDelay > 0 {
+		select {
+		case <-time.After(cfg.ShutdownDelay):
+		case err := <-serveErr:
+			return fmt.Errorf("erro ao atender requisições: %v", err)
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cfg.ShutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		errs = append(errs, fmt.Errorf("erro ao finalizar servidor HTTP: %v", err))
+	}
+
+	for _, hook := range hooks.Stopped {
+		if err := hook(shutdownCtx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	slog.Info("Servidor finalizado")
+	return nil
+}
+
+// ListenAndRun abre o listener em cfg.Addr e chama Run
+func ListenAndRun(ctx context.Context, srv *http.Server, cfg config.ServerConfig, hooks Hooks) error {
+	listener, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir porta %s: %v", cfg.Addr, err)
+	}
+
+	slog.Info("Servidor iniciado", "addr", listener.Addr().String())
+	return Run(ctx, srv, listener, cfg, hooks)
+}
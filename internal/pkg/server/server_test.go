@@ -0,0 +1,162 @@
+package server_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/config"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/server"
+)
+
+func TestMain(m *testing.M) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	m.Run()
+}
+
+func TestRunGracefulShutdown(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.ShutdownDelay = 300 * time.Millisecond
+	cfg.ShutdownTimeout = 5 * time.Second
+
+	var draining atomic.Bool
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		if draining.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("erro ao abrir porta: %v", err)
+	}
+	addr := listener.Addr().String()
+
+	var mu sync.Mutex
+	var steps []string
+	step := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		steps = append(steps, name)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hooks := server.Hooks{
+		Draining: []func(){func() {
+			draining.Store(true)
+			step("draining")
+		}},
+		Stopped: []server.ShutdownHook{func(context.Context) error {
+			step("stopped")
+			return nil
+		}},
+	}
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- server.Run(ctx, server.New(cfg, mux), listener, cfg, hooks)
+	}()
+
+	// Conexões novas a cada requisição, para observar o listener
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}, Timeout: 5 * time.Second}
+
+	slowStatus := make(chan int, 1)
+	go func() {
+		resp, err := client.Get("http://" + addr + "/slow")
+		if err != nil {
+			t.Errorf("requisição em andamento falhou: %v", err)
+			slowStatus <- 0
+			return
+		}
+		resp.Body.Close()
+		step("slow")
+		slowStatus <- resp.StatusCode
+	}()
+	<-started
+
+	if resp, err := client.Get("http://" + addr + "/ready"); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("readiness antes do sinal = %v, %v", resp, err)
+	}
+
+	cancel()
+
+	// Durante ShutdownDelay o listener continua aberto e a readiness falha
+	deadline := time.Now().Add(cfg.ShutdownDelay / 2)
+	for !draining.Load() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	resp, err := client.Get("http://" + addr + "/ready")
+	if err != nil {
+		t.Fatalf("listener fechado antes de ShutdownDelay: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("readiness durante o desligamento = %d, esperado 503", resp.StatusCode)
+	}
+
+	// Após ShutdownDelay novas conexões são recusadas
+	deadline = time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+		if err != nil {
+			break
+		}
+		conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("listener continuou aceitando conexões")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case err := <-runErr:
+		t.Fatalf("Run retornou com requisição em andamento: %v", err)
+	default:
+	}
+
+	close(release)
+	if status := <-slowStatus; status != http.StatusOK {
+		t.Fatalf("requisição em andamento = %d, esperado 200", status)
+	}
+	if err := <-runErr; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(steps) != 3 || steps[0] != "draining" || steps[2] != "stopped" {
+		t.Fatalf("etapas = %v, esperado draining, slow, stopped", steps)
+	}
+}
+
+func TestRunServeError(t *testing.T) {
+	cfg := config.Default().Server
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("erro ao abrir porta: %v", err)
+	}
+	listener.Close()
+
+	if err := server.Run(context.Background(), server.New(cfg, http.NotFoundHandler()), listener, cfg, server.Hooks{}); err == nil {
+		t.Fatal("Run com listener fechado não retornou erro")
+	}
+}
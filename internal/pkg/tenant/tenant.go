@@ -0,0 +1,67 @@
+package tenant
+
+import (
+	"log/slog"
+	"net/http"
+	"regexp"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/apierror"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// HeaderName é o cabeçalho HTTP usado para informar o tenant da requisição
+	HeaderName = "X-Tenant-ID"
+
+	// DefaultID é o tenant atribuído aos contatos criados antes do suporte a multi-tenancy
+	DefaultID = "default"
+
+	// PrincipalKey é a chave do contexto do Gin onde a camada de autenticação
+	// deve gravar o tenant do principal autenticado. Quando presente, tem
+	// precedência sobre o cabeçalho.
+	PrincipalKey = "principal_tenant_id"
+
+	contextKey = "tenant_id"
+)
+
+var validID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// IsValid indica se o identificador de tenant tem um formato aceito
+func IsValid(id string) bool {
+	return validID.MatchString(id)
+}
+
+// Middleware resolve o tenant da requisição a partir do principal autenticado
+// ou do cabeçalho X-Tenant-ID. Se nenhum for informado, usa defaultID; quando
+// defaultID é vazio a requisição é rejeitada.
+func Middleware(defaultID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetString(PrincipalKey)
+		if id == "" {
+			id = c.GetHeader(HeaderName)
+		}
+		if id == "" {
+			id = defaultID
+		}
+
+		if id == "" {
+			apierror.Abort(c, http.StatusBadRequest, "Cabeçalho "+HeaderName+" é obrigatório")
+			return
+		}
+
+		if !IsValid(id) {
+			apierror.Abort(c, http.StatusBadRequest, "Tenant inválido")
+			return
+		}
+
+		c.Set(contextKey, id)
+		c.Request = c.Request.WithContext(logger.WithAttrs(c.Request.Context(), slog.String("tenant_id", id)))
+		c.Next()
+	}
+}
+
+// FromContext retorna o tenant resolvido pelo Middleware
+func FromContext(c *gin.Context) string {
+	return c.GetString(contextKey)
+}
@@ -0,0 +1,78 @@
+package tenant_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		defaultID  string
+		principal  string
+		header     string
+		wantStatus int
+		wantTenant string
+	}{
+		{name: "sem cabeçalho usa o padrão", defaultID: tenant.DefaultID, wantStatus: http.StatusOK, wantTenant: tenant.DefaultID},
+		{name: "cabeçalho", defaultID: tenant.DefaultID, header: "acme", wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "principal tem precedência sobre o cabeçalho", defaultID: tenant.DefaultID, principal: "globex", header: "acme", wantStatus: http.StatusOK, wantTenant: "globex"},
+		{name: "sem padrão o cabeçalho é obrigatório", wantStatus: http.StatusBadRequest},
+		{name: "tenant com caracteres inválidos", defaultID: tenant.DefaultID, header: "acme corp", wantStatus: http.StatusBadRequest},
+		{name: "tenant longo demais", defaultID: tenant.DefaultID, header: strings.Repeat("a", 65), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.principal != "" {
+					c.Set(tenant.PrincipalKey, tt.principal)
+				}
+			})
+			router.Use(tenant.Middleware(tt.defaultID))
+			router.GET("/", func(c *gin.Context) {
+				c.String(http.StatusOK, tenant.FromContext(c))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(tenant.HeaderName, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, esperado %d (%s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && rec.Body.String() != tt.wantTenant {
+				t.Fatalf("tenant = %q, esperado %q", rec.Body.String(), tt.wantTenant)
+			}
+		})
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	tests := map[string]bool{
+		"default":               true,
+		"acme_01-prod":          true,
+		strings.Repeat("a", 64): true,
+		"":                      false,
+		"acme/../globex":        false,
+		"ação":                  false,
+		strings.Repeat("a", 65): false,
+	}
+
+	for id, want := range tests {
+		if got := tenant.IsValid(id); got != want {
+			t.Errorf("IsValid(%q) = %v, esperado %v", id, got, want)
+		}
+	}
+}
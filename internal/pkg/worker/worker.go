@@ -0,0 +1,113 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Task é uma tarefa executada periodicamente em segundo plano
+type Task struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Group executa um conjunto de tarefas periódicas e permite pará-las
+// aguardando a execução em andamento terminar
+type Group struct {
+	tasks   []Task
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Add registra uma tarefa. Deve ser chamado antes de Start.
+func (g *Group) Add(task Task) {
+	g.tasks = append(g.tasks, task)
+}
+
+// Start inicia uma goroutine por tarefa
+func (g *Group) Start(ctx context.Context) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.running {
+		return
+	}
+
+	ctx, g.cancel = context.WithCancel(ctx)
+	g.running = true
+
+	for _, task := range g.tasks {
+		g.wg.Add(1)
+		go g.loop(ctx, task)
+	}
+}
+
+// Stop cancela as tarefas e aguarda que terminem ou que ctx expire
+func (g *Group) Stop(ctx context.Context) error {
+	g.mu.Lock()
+	if !g.running {
+		g.mu.Unlock()
+		return nil
+	}
+	g.running = false
+	g.cancel()
+	g.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		slog.Info("Workers finalizados")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Running indica se o grupo foi iniciado e ainda não foi parado
+func (g *Group) Running() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.running
+}
+
+func (g *Group) loop(ctx context.Context, task Task) {
+	defer g.wg.Done()
+
+	ticker := time.NewTicker(task.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := task.Run(ctx); err != nil && ctx.Err() == nil {
+				slog.Error("Erro no worker", "worker", task.Name, "error", err)
+			}
+		}
+	}
+}
+
+// Backoff retorna a espera após a falha da tentativa de número attempt+1:
+// initial dobrado a cada falha anterior, limitado a max
+func Backoff(attempt int, initial, max time.Duration) time.Duration {
+	delay := initial
+	for i := 0; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	return min(delay, max)
+}
@@ -0,0 +1,136 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/worker"
+)
+
+func TestMain(m *testing.M) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	m.Run()
+}
+
+func TestGroupRunsTasksPeriodically(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "sucesso"},
+		{name: "erro não interrompe a tarefa", err: errors.New("falha")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runs atomic.Int32
+			group := worker.NewGroup()
+			group.Add(worker.Task{Name: "teste", Interval: 5 * time.Millisecond, Run: func(context.Context) error {
+				runs.Add(1)
+				return tt.err
+			}})
+
+			group.Start(context.Background())
+			deadline := time.Now().Add(2 * time.Second)
+			for runs.Load() < 3 && time.Now().Before(deadline) {
+				time.Sleep(5 * time.Millisecond)
+			}
+			if err := group.Stop(context.Background()); err != nil {
+				t.Fatalf("Stop: %v", err)
+			}
+
+			if runs.Load() < 3 {
+				t.Fatalf("tarefa executada %d vezes, esperado pelo menos 3", runs.Load())
+			}
+			if group.Running() {
+				t.Fatal("grupo continua em execução após Stop")
+			}
+
+			after := runs.Load()
+			time.Sleep(20 * time.Millisecond)
+			if runs.Load() != after {
+				t.Fatal("tarefa executada após Stop")
+			}
+		})
+	}
+}
+
+func TestGroupStopWaitsForRunningTask(t *testing.T) {
+	started := make(chan struct{})
+	var finished atomic.Bool
+
+	group := worker.NewGroup()
+	group.Add(worker.Task{Name: "lenta", Interval: time.Millisecond, Run: func(ctx context.Context) error {
+		if finished.Load() {
+			return nil
+		}
+		close(started)
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond)
+		finished.Store(true)
+		return ctx.Err()
+	}})
+
+	group.Start(context.Background())
+	<-started
+
+	if err := group.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if !finished.Load() {
+		t.Fatal("Stop retornou antes da execução em andamento terminar")
+	}
+}
+
+func TestGroupStopTimeout(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	group := worker.NewGroup()
+	group.Add(worker.Task{Name: "presa", Interval: time.Millisecond, Run: func(context.Context) error {
+		select {
+		case <-started:
+		default:
+			close(started)
+		}
+		<-release
+		return nil
+	}})
+
+	group.Start(context.Background())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := group.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Stop = %v, esperado context.DeadlineExceeded", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt      int
+		initial, max time.Duration
+		want         time.Duration
+	}{
+		{0, time.Second, time.Minute, time.Second},
+		{1, time.Second, time.Minute, 2 * time.Second},
+		{3, time.Second, time.Minute, 8 * time.Second},
+		{10, time.Second, time.Minute, time.Minute},
+		{4, 500 * time.Millisecond, 5 * time.Second, 5 * time.Second},
+		{50, 500 * time.Millisecond, 5 * time.Second, 5 * time.Second},
+		{0, 10 * time.Second, 5 * time.Second, 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := worker.Backoff(tt.attempt, tt.initial, tt.max); got != tt.want {
+			t.Errorf("Backoff(%d, %v, %v) = %v, esperado %v", tt.attempt, tt.initial, tt.max, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package stream
+
+import (
+	"sync"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/metrics"
+)
+
+// recentEvents é quantos eventos o broker lembra para descartar publicações
+// repetidas (ex.: um evento que o relay entregou de novo após a falha de outro sink)
+const recentEvents = 1024
+
+// Broker distribui os eventos publicados nesta réplica aos clientes
+// conectados. Cada inscrição tem um buffer próprio; o cliente que não o
+// esvazia a tempo é desconectado em vez de atrasar os demais, e pode retomar
+// o stream pelo Last-Event-ID.
+type Broker struct {
+	mu          sync.Mutex
+	bufferSize  int
+	subscribers map[*Subscription]struct{}
+	closed      bool
+
+	recent     map[int64]struct{}
+	recentRing []int64
+	recentNext int
+}
+
+// Subscription é a inscrição de um cliente nos eventos de um tenant
+type Subscription struct {
+	broker   *Broker
+	tenantID string
+	filter   Filter
+	events   chan Event
+	dropped  bool
+}
+
+func NewBroker(bufferSize int) *Broker {
+	return &Broker{
+		bufferSize:  bufferSize,
+		subscribers: map[*Subscription]struct{}{},
+		recent:      map[int64]struct{}{},
+		recentRing:  make([]int64, 0, recentEvents),
+	}
+}
+
+// Subscribe inscreve um cliente nos eventos do tenant que passam pelo filtro.
+// Com o broker fechado, a inscrição já nasce encerrada.
+func (b *Broker) Subscribe(tenantID string, filter Filter) *Subscription {
+	subscription := &Subscription{
+		broker:   b,
+		tenantID: tenantID,
+		filter:   filter,
+		events:   make(chan Event, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(subscription.events)
+		return subscription
+	}
+
+	b.subscribers[subscription] = struct{}{}
+	metrics.StreamSubscribers.Inc()
+	return subscription
+}
+
+// Publish entrega o evento às inscrições do tenant sem bloquear. Eventos já
+// publicados recentemente são ignorados.
+func (b *Broker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed || !b.remember(event.Seq) {
+		return
+	}
+
+	for subscription := range b.subscribers {
+		if subscription.tenantID != event.TenantID || !subscription.filter.Matches(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			subscription.dropped = true
+			b.removeLocked(subscription)
+			metrics.StreamSlowConsumersTotal.Inc()
+		}
+	}
+}
+
+// Close encerra todas as inscrições; usado no desligamento para que as
+// conexões abertas não segurem o servidor
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for subscription := range b.subscribers {
+		b.removeLocked(subscription)
+	}
+}
+
+// remember registra seq entre os eventos recentes e indica se ele é novo
+func (b *Broker) remember(seq int64) bool {
+	if _, ok := b.recent[seq]; ok {
+		return false
+	}
+
+	if len(b.recentRing) < recentEvents {
+		b.recentRing = append(b.recentRing, seq)
+	} else {
+		delete(b.recent, b.recentRing[b.recentNext])
+		b.recentRing[b.recentNext] = seq
+		b.recentNext = (b.recentNext + 1) % recentEvents
+	}
+	b.recent[seq] = struct{}{}
+	return true
+}
+
+func (b *Broker) removeLocked(subscription *Subscription) {
+	if _, ok := b.subscribers[subscription]; !ok {
+		return
+	}
+	delete(b.subscribers, subscription)
+	close(subscription.events)
+	metrics.StreamSubscribers.Dec()
+}
+
+// Events recebe os eventos da inscrição e é fechado quando ela termina
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped indica se a inscrição foi encerrada porque o buffer encheu
+func (s *Subscription) Dropped() bool {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.dropped
+}
+
+// Close cancela a inscrição
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.removeLocked(s)
+}
@@ -0,0 +1,56 @@
+package stream
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// Event é um evento de contato do outbox enviado aos clientes do stream. Seq
+// é o ID do evento no outbox, crescente, e identifica o evento no
+// Last-Event-ID; Data é o payload JSON gravado pela alteração.
+type Event struct {
+	Seq      int64
+	Type     string
+	TenantID string
+	Data     []byte
+
+	// categories guarda a categoria atual e a anterior do contato, usadas nos filtros
+	categories []string
+}
+
+func newEvent(seq int64, eventType, tenantID string, data []byte) Event {
+	var payload struct {
+		CategoryID         string `json:"category_id"`
+		PreviousCategoryID string `json:"previous_category_id"`
+	}
+
+	// Um payload que não pode ser lido apenas não passa pelos filtros de categoria
+	json.Unmarshal(data, &payload)
+
+	event := Event{Seq: seq, Type: eventType, TenantID: tenantID, Data: data}
+	for _, category := range []string{payload.CategoryID, payload.PreviousCategoryID} {
+		if category != "" {
+			event.categories = append(event.categories, category)
+		}
+	}
+	return event
+}
+
+// Filter restringe os eventos enviados a um cliente. Categories vazio aceita
+// todos os eventos; caso contrário, o evento passa se a categoria atual ou a
+// anterior do contato estiver na lista.
+type Filter struct {
+	Categories []string
+}
+
+func (f Filter) Matches(event Event) bool {
+	if len(f.Categories) == 0 {
+		return true
+	}
+	for _, category := range event.categories {
+		if slices.Contains(f.Categories, category) {
+			return true
+		}
+	}
+	return false
+}
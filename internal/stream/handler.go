@@ -0,0 +1,251 @@
+package stream
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/apierror"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// replayBatchSize é o tamanho de cada página lida do log na retomada
+	replayBatchSize = 500
+
+	// retryDelay é a espera sugerida aos clientes antes de reconectar
+	retryDelay = 3 * time.Second
+
+	// replayMargin recua o início da retomada em relação à gravação do
+	// Last-Event-ID para cobrir a diferença entre os relógios das réplicas
+	replayMargin = time.Minute
+
+	// EventReset avisa o cliente de que o Last-Event-ID informado não está
+	// mais no log: os eventos seguintes são entregues, mas o estado deve ser
+	// recarregado por GET /contacts
+	EventReset = "reset"
+)
+
+type HandlerOptions struct {
+	// HeartbeatInterval é o intervalo entre os comentários enviados para
+	// manter a conexão aberta em proxies e balanceadores
+	HeartbeatInterval time.Duration
+
+	// WriteTimeout limita cada escrita no cliente; substitui o WriteTimeout
+	// do servidor, que encerraria o stream
+	WriteTimeout time.Duration
+}
+
+type Handler struct {
+	broker *Broker
+	log    Log
+	opts   HandlerOptions
+}
+
+func NewHandler(broker *Broker, log Log, opts HandlerOptions) *Handler {
+	return &Handler{broker: broker, log: log, opts: opts}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/contacts/events", h.Stream)
+}
+
+// @Summary     Stream de alterações de contatos
+// @Description Server-Sent Events com os eventos contact.created, contact.updated e contact.deleted do tenant. O campo id de cada evento pode ser enviado em Last-Event-ID para retomar o stream sem perdas enquanto o evento estiver retido; a retomada pode repetir eventos já recebidos, que o cliente descarta pelo id; fora da retenção, o stream começa com o evento reset e o cliente deve recarregar os contatos. Comentários de heartbeat são enviados periodicamente, e o cliente que não acompanha os eventos é desconectado e deve reconectar com Last-Event-ID.
+// @Tags        contacts
+// @Produce     text/event-stream
+// @Param       X-Tenant-ID header string false "Tenant dos contatos (padrão: default)"
+// @Param       Last-Event-ID header string false "ID do último evento recebido"
+// @Param       last_event_id query string false "Alternativa ao cabeçalho Last-Event-ID na primeira conexão"
+// @Param       category_id query string false "IDs de categoria, separados por vírgula; o evento passa se a categoria atual ou a anterior do contato estiver na lista"
+// @Success     200 {string} string "Stream de eventos"
+// @Failure     400 {object} contacts.ErrorResponse "Filtro ou Last-Event-ID inválido"
+// @Failure     429 {object} contacts.ErrorResponse "Limite de requisições excedido"
+// @Failure     500 {object} contacts.ErrorResponse "Erro interno do servidor"
+// @Router      /contacts/events [get]
+func (h *Handler) Stream(c *gin.Context) {
+	ctx := c.Request.Context()
+	tenantID := tenant.FromContext(c)
+
+	filter, err := parseFilter(c.Query("category_id"))
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lastEventID, err := parseLastEventID(c)
+	if err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// A inscrição antecede a leitura do log para que nenhum evento publicado
+	// durante a retomada se perca
+	subscription := h.broker.Subscribe(tenantID, filter)
+	defer subscription.Close()
+
+	var (
+		resume    bool
+		createdAt time.Time
+	)
+	if lastEventID > 0 {
+		createdAt, resume, err = h.log.CreatedAt(ctx, tenantID, lastEventID)
+		if err != nil {
+			apierror.Respond(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := &eventWriter{w: c.Writer, rc: http.NewResponseController(c.Writer), timeout: h.opts.WriteTimeout}
+	if err := w.write(fmt.Sprintf("retry: %d\n\n", retryDelay.Milliseconds())); err != nil {
+		return
+	}
+
+	if lastEventID > 0 && !resume {
+		if err := w.write(fmt.Sprintf("event: %s\ndata: {}\n\n", EventReset)); err != nil {
+			return
+		}
+	}
+
+	// Os eventos reenviados do log também podem chegar pela inscrição
+	replayed := map[int64]struct{}{}
+	if resume {
+		if err := h.replay(c, w, tenantID, filter, lastEventID, createdAt.Add(-replayMargin), replayed); err != nil {
+			slog.ErrorContext(ctx, "Erro ao retomar stream de eventos", "error", err)
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.opts.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-subscription.Events():
+			if !ok {
+				if subscription.Dropped() {
+					slog.WarnContext(ctx, "Cliente do stream desconectado por lentidão", "tenant_id", tenantID)
+				}
+				return
+			}
+			if _, ok := replayed[event.Seq]; ok {
+				delete(replayed, event.Seq)
+				continue
+			}
+			if err := w.event(event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := w.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// replay envia os eventos retidos que o cliente pode não ter recebido e os
+// registra em replayed. O seq segue a ordem de gravação no outbox, e não a de
+// commit nem a de entrega: um evento de seq menor que lastEventID pode ter sido
+// confirmado ou entregue depois dele. Por isso, além dos eventos de seq maior,
+// são reenviados os ainda não entregues e os entregues desde a gravação de
+// lastEventID (since); o cliente descarta os que já recebeu pelo id.
+func (h *Handler) replay(c *gin.Context, w *eventWriter, tenantID string, filter Filter, lastEventID int64, since time.Time, replayed map[int64]struct{}) error {
+	var after int64
+	for {
+		events, err := h.log.Since(c.Request.Context(), tenantID, lastEventID, since, after, replayBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			after = event.Seq
+			if !filter.Matches(event) {
+				continue
+			}
+			if err := w.event(event); err != nil {
+				return err
+			}
+			replayed[event.Seq] = struct{}{}
+		}
+
+		if len(events) < replayBatchSize {
+			return nil
+		}
+	}
+}
+
+// eventWriter escreve no formato text/event-stream, enviando cada bloco de imediato
+type eventWriter struct {
+	w       io.Writer
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+func (w *eventWriter) event(event Event) error {
+	var frame bytes.Buffer
+	fmt.Fprintf(&frame, "id: %d\nevent: %s\n", event.Seq, event.Type)
+	for _, line := range bytes.Split(event.Data, []byte("\n")) {
+		fmt.Fprintf(&frame, "data: %s\n", line)
+	}
+	frame.WriteString("\n")
+	return w.write(frame.String())
+}
+
+func (w *eventWriter) write(frame string) error {
+	// Nem todo ResponseWriter aceita prazos (ex.: nos testes); a escrita segue sem ele
+	w.rc.SetWriteDeadline(time.Now().Add(w.timeout))
+
+	if _, err := io.WriteString(w.w, frame); err != nil {
+		return err
+	}
+	return w.rc.Flush()
+}
+
+// parseFilter lê a lista de categorias separadas por vírgula
+func parseFilter(value string) (Filter, error) {
+	var filter Filter
+	for _, category := range strings.Split(value, ",") {
+		category = strings.TrimSpace(category)
+		if category == "" {
+			continue
+		}
+		if uuid.Validate(category) != nil {
+			return Filter{}, fmt.Errorf("category_id inválido: %q", category)
+		}
+		filter.Categories = append(filter.Categories, category)
+	}
+	return filter, nil
+}
+
+// parseLastEventID lê o cabeçalho Last-Event-ID, enviado pelo EventSource ao
+// reconectar, ou o parâmetro last_event_id; zero indica que não há retomada
+func parseLastEventID(c *gin.Context) (int64, error) {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	seq, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, fmt.Errorf("Last-Event-ID inválido: %q", value)
+	}
+	return seq, nil
+}
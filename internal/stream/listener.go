@@ -0,0 +1,109 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// catchUpMargin recua a releitura após uma reconexão para cobrir a
+	// diferença entre os relógios das réplicas
+	catchUpMargin = time.Minute
+
+	catchUpBatchSize = 500
+)
+
+// Listener recebe por LISTEN os eventos entregues pelo relay em qualquer
+// réplica e os publica no broker local. As notificações enviadas enquanto a
+// conexão estava caída se perdem; por isso, ao reconectar, os eventos
+// entregues desde a última notificação são relidos do log.
+type Listener struct {
+	dsn    string
+	log    Log
+	broker *Broker
+	now    func() time.Time
+}
+
+func NewListener(dsn string, log Log, broker *Broker) *Listener {
+	return &Listener{dsn: dsn, log: log, broker: broker, now: time.Now}
+}
+
+// Run escuta o canal até ctx ser cancelado. Deve ser executado por um único
+// worker.Task por réplica; um erro ao escutar é retornado para que o worker
+// tente de novo.
+func (l *Listener) Run(ctx context.Context) error {
+	listener := pq.NewListener(l.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected:
+			slog.Warn("Conexão do stream de eventos perdida", "error", err)
+		case pq.ListenerEventReconnected:
+			slog.Info("Conexão do stream de eventos restabelecida")
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(NotifyChannel); err != nil {
+		return fmt.Errorf("erro ao escutar o canal %s: %v", NotifyChannel, err)
+	}
+
+	lastNotification := l.now()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			// O driver envia nil após reconectar
+			if notification == nil {
+				if err := l.catchUp(ctx, lastNotification.Add(-catchUpMargin)); err != nil {
+					slog.Error("Erro ao reler eventos após reconexão", "error", err)
+				}
+				continue
+			}
+
+			lastNotification = l.now()
+			l.publish(ctx, notification.Extra)
+		}
+	}
+}
+
+func (l *Listener) publish(ctx context.Context, payload string) {
+	seq, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		slog.Warn("Notificação de evento inválida", "payload", payload)
+		return
+	}
+
+	event, err := l.log.Get(ctx, seq)
+	if err != nil {
+		slog.Error("Erro ao carregar evento notificado", "seq", seq, "error", err)
+		return
+	}
+
+	l.broker.Publish(event)
+}
+
+// catchUp publica os eventos entregues a partir de since; os que já tinham
+// sido publicados são descartados pelo broker
+func (l *Listener) catchUp(ctx context.Context, since time.Time) error {
+	var after int64
+	for {
+		events, err := l.log.DeliveredSince(ctx, since, after, catchUpBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			l.broker.Publish(event)
+			after = event.Seq
+		}
+
+		if len(events) < catchUpBatchSize {
+			return nil
+		}
+	}
+}
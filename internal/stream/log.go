@@ -0,0 +1,119 @@
+package stream
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Log lê os eventos retidos no outbox, de onde o stream é retomado
+type Log interface {
+	// CreatedAt retorna quando o evento seq do tenant foi gravado; ok é falso
+	// se ele não está mais retido. Um Last-Event-ID que não está mais no log
+	// não permite retomar o stream.
+	CreatedAt(ctx context.Context, tenantID string, seq int64) (createdAt time.Time, ok bool, err error)
+
+	// Since retorna, em ordem, até limit eventos do tenant com seq maior que
+	// after que o cliente que recebeu lastEventID pode não ter recebido: os de
+	// seq maior, os ainda não entregues e os entregues a partir de since. O
+	// próprio lastEventID não é retornado.
+	Since(ctx context.Context, tenantID string, lastEventID int64, since time.Time, after int64, limit int) ([]Event, error)
+
+	// Get retorna o evento seq
+	Get(ctx context.Context, seq int64) (Event, error)
+
+	// DeliveredSince retorna, em ordem, até limit eventos de todos os tenants
+	// entregues a partir de since e com seq maior que after
+	DeliveredSince(ctx context.Context, since time.Time, after int64, limit int) ([]Event, error)
+}
+
+// SQLLog lê a tabela outbox_events do PostgreSQL ou do SQLite
+type SQLLog struct {
+	db *sql.DB
+}
+
+func NewSQLLog(db *sql.DB) *SQLLog {
+	return &SQLLog{db: db}
+}
+
+func (l *SQLLog) CreatedAt(ctx context.Context, tenantID string, seq int64) (time.Time, bool, error) {
+	var createdAt time.Time
+	err := l.db.QueryRowContext(ctx, "SELECT created_at FROM outbox_events WHERE tenant_id = $1 AND id = $2", tenantID, seq).Scan(&createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("erro ao consultar log de eventos: %v", err)
+	}
+	return createdAt, true, nil
+}
+
+func (l *SQLLog) Since(ctx context.Context, tenantID string, lastEventID int64, since time.Time, after int64, limit int) ([]Event, error) {
+	query := `
+		SELECT id, event_type, tenant_id, payload
+		FROM outbox_events
+		WHERE tenant_id = $1 AND id > $2 AND id <> $3
+			AND (id > $3 OR delivered_at IS NULL OR delivered_at >= $4)
+		ORDER BY id
+		LIMIT $5
+	`
+
+	return l.query(ctx, query, tenantID, after, lastEventID, since.UTC(), limit)
+}
+
+func (l *SQLLog) Get(ctx context.Context, seq int64) (Event, error) {
+	query := `
+		SELECT id, event_type, tenant_id, payload
+		FROM outbox_events
+		WHERE id = $1
+	`
+
+	events, err := l.query(ctx, query, seq)
+	if err != nil {
+		return Event{}, err
+	}
+	if len(events) == 0 {
+		return Event{}, fmt.Errorf("evento %d não encontrado no log", seq)
+	}
+	return events[0], nil
+}
+
+func (l *SQLLog) DeliveredSince(ctx context.Context, since time.Time, after int64, limit int) ([]Event, error) {
+	query := `
+		SELECT id, event_type, tenant_id, payload
+		FROM outbox_events
+		WHERE delivered_at >= $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+
+	return l.query(ctx, query, since.UTC(), after, limit)
+}
+
+func (l *SQLLog) query(ctx context.Context, query string, args ...any) ([]Event, error) {
+	rows, err := l.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler log de eventos: %v", err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var (
+			seq                 int64
+			eventType, tenantID string
+			payload             []byte
+		)
+		if err := rows.Scan(&seq, &eventType, &tenantID, &payload); err != nil {
+			return nil, fmt.Errorf("erro ao ler log de eventos: %v", err)
+		}
+		events = append(events, newEvent(seq, eventType, tenantID, payload))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler log de eventos: %v", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,56 @@
+package stream
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/outbox"
+)
+
+// NotifyChannel é o canal do PostgreSQL em que as réplicas são avisadas dos
+// eventos entregues pelo relay
+const NotifyChannel = "contact_events"
+
+type localSink struct {
+	broker *Broker
+}
+
+// NewLocalSink cria o sink do outbox que publica direto no broker do
+// processo. Serve apenas a uma réplica, como no SQLite; com várias réplicas
+// use NewNotifySink e um Listener em cada uma.
+func NewLocalSink(broker *Broker) outbox.Sink {
+	return &localSink{broker: broker}
+}
+
+func (s *localSink) Name() string {
+	return "stream"
+}
+
+func (s *localSink) Publish(ctx context.Context, message outbox.Message) error {
+	s.broker.Publish(newEvent(message.ID, message.Type, message.TenantID, message.Payload))
+	return nil
+}
+
+type notifySink struct {
+	db *sql.DB
+}
+
+// NewNotifySink cria o sink do outbox que avisa todas as réplicas, por
+// NOTIFY, do ID do evento entregue. O payload não vai na notificação, que é
+// limitada a 8000 bytes; cada Listener o lê do outbox.
+func NewNotifySink(db *sql.DB) outbox.Sink {
+	return &notifySink{db: db}
+}
+
+func (s *notifySink) Name() string {
+	return "stream"
+}
+
+func (s *notifySink) Publish(ctx context.Context, message outbox.Message) error {
+	if _, err := s.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", NotifyChannel, strconv.FormatInt(message.ID, 10)); err != nil {
+		return fmt.Errorf("erro ao notificar evento %d: %v", message.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+package stream_test
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/dbtest"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/outbox"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/Felipe8297/go-contacts-api/internal/stream"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	clients   = "0b8f7f5e-6f1a-4c1e-9a57-1d1f0f4c2a01"
+	suppliers = "0b8f7f5e-6f1a-4c1e-9a57-1d1f0f4c2a02"
+)
+
+func TestBroker(t *testing.T) {
+	broker := stream.NewBroker(2)
+
+	all := broker.Subscribe("acme", stream.Filter{})
+	filtered := broker.Subscribe("acme", stream.Filter{Categories: []string{clients}})
+	other := broker.Subscribe("globex", stream.Filter{})
+
+	publish := func(seq int64, payload string) {
+		broker.Publish(publishedEvent(t, seq, "acme", payload))
+	}
+
+	publish(1, `{"category_id":"`+suppliers+`","previous_category_id":"`+clients+`"}`)
+	publish(1, `{"category_id":"`+suppliers+`"}`)
+	publish(2, `{"category_id":"`+suppliers+`"}`)
+
+	// O evento repetido é descartado e o contato que saiu da categoria
+	// filtrada ainda é visto pela inscrição filtrada
+	if got := receive(all, 2); got[0].Seq != 1 || got[1].Seq != 2 {
+		t.Fatalf("inscrição sem filtro recebeu %v", got)
+	}
+	if got := receive(filtered, 1); got[0].Seq != 1 {
+		t.Fatalf("inscrição filtrada recebeu %v", got)
+	}
+	if len(other.Events()) != 0 {
+		t.Fatal("inscrição de outro tenant recebeu eventos")
+	}
+
+	// O buffer de all está vazio e o de filtered não recebe estes eventos
+	publish(3, `{}`)
+	publish(4, `{}`)
+	publish(5, `{}`)
+	if _, ok := <-drain(all); ok {
+		t.Fatal("inscrição lenta não foi encerrada")
+	}
+	if !all.Dropped() {
+		t.Fatal("Dropped = false para a inscrição lenta")
+	}
+
+	broker.Close()
+	if _, ok := <-drain(filtered); ok || filtered.Dropped() {
+		t.Fatal("Close não encerrou as inscrições")
+	}
+	if _, ok := <-broker.Subscribe("acme", stream.Filter{}).Events(); ok {
+		t.Fatal("inscrição após Close não nasceu encerrada")
+	}
+}
+
+func TestStreamEndToEnd(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	live := env.connect(t, "/contacts/events", "")
+	filtered := env.connect(t, "/contacts/events?category_id="+suppliers, "")
+	other := env.connect(t, "/contacts/events", "globex")
+
+	ana, err := env.contacts.CreateNewContact(ctx, tenant.DefaultID, "Ana", "ana@example.com", "", clients)
+	if err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+	if _, err := env.contacts.UpdateContact(ctx, tenant.DefaultID, ana.ID, "Ana", "ana@example.com", "", suppliers); err != nil {
+		t.Fatalf("UpdateContact: %v", err)
+	}
+	env.relay(t)
+
+	created := live.next(t)
+	updated := live.next(t)
+	if created.event != contacts.EventContactCreated || updated.event != contacts.EventContactUpdated {
+		t.Fatalf("eventos = %s, %s", created.event, updated.event)
+	}
+
+	var payload contacts.Event
+	if err := json.Unmarshal([]byte(updated.data), &payload); err != nil || payload.ContactID != ana.ID {
+		t.Fatalf("data = %s, %v", updated.data, err)
+	}
+
+	if got := filtered.next(t); got.id != updated.id {
+		t.Fatalf("stream filtrado recebeu %s, esperado apenas a atualização %s", got.id, updated.id)
+	}
+
+	if _, err := env.contacts.CreateNewContact(ctx, "globex", "Bia", "bia@example.com", "", clients); err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+	env.relay(t)
+	if got := other.next(t); got.event != contacts.EventContactCreated {
+		t.Fatalf("stream de globex recebeu %+v", got)
+	}
+
+	// Alterações feitas com o cliente desconectado são reenviadas a partir do Last-Event-ID
+	if err := env.contacts.DeleteContact(ctx, tenant.DefaultID, ana.ID); err != nil {
+		t.Fatalf("DeleteContact: %v", err)
+	}
+	env.relay(t)
+
+	resumed := env.connect(t, "/contacts/events", "", "Last-Event-ID", created.id)
+	if got := resumed.next(t); got.id != updated.id {
+		t.Fatalf("primeiro evento retomado = %s, esperado %s", got.id, updated.id)
+	}
+	if got := resumed.next(t); got.event != contacts.EventContactDeleted {
+		t.Fatalf("segundo evento retomado = %+v", got)
+	}
+
+	// Um ID que não está no log, ou é de outro tenant, exige recarregar o estado
+	expired := env.connect(t, "/contacts/events", "globex", "Last-Event-ID", created.id)
+	if got := expired.next(t); got.event != stream.EventReset {
+		t.Fatalf("evento = %+v, esperado %s", got, stream.EventReset)
+	}
+}
+
+func TestStreamResumesEventsCommittedOutOfOrder(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	if _, err := env.contacts.CreateNewContact(ctx, tenant.DefaultID, "Ana", "ana@example.com", "", ""); err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+	// Os IDs 2 e 3 ficam com transações ainda não confirmadas, como as que
+	// obtêm o ID do BIGSERIAL no PostgreSQL e confirmam depois
+	if _, err := env.db.Exec("UPDATE sqlite_sequence SET seq = seq + 2 WHERE name = 'outbox_events'"); err != nil {
+		t.Fatalf("erro ao reservar IDs: %v", err)
+	}
+	if _, err := env.contacts.CreateNewContact(ctx, tenant.DefaultID, "Bia", "bia@example.com", "", ""); err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+	env.relay(t)
+
+	// O cliente recebeu o evento 4 e desconectou; o evento 2 é confirmado e
+	// entregue, e o 3 é confirmado, mas ainda não foi entregue
+	env.enqueue(t, 2)
+	env.relay(t)
+	env.enqueue(t, 3)
+
+	resumed := env.connect(t, "/contacts/events", "", "Last-Event-ID", "4")
+	env.relay(t)
+	if _, err := env.contacts.CreateNewContact(ctx, tenant.DefaultID, "Caio", "caio@example.com", "", ""); err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+	env.relay(t)
+
+	// Eventos já recebidos podem ser reenviados, mas cada um chega uma vez
+	// por conexão e o último recebido não é repetido
+	received := map[string]bool{}
+	for {
+		got := resumed.next(t)
+		if received[got.id] || got.id == "4" {
+			t.Fatalf("evento %s repetido", got.id)
+		}
+		received[got.id] = true
+		if got.id == "5" {
+			break
+		}
+	}
+	if !received["2"] || !received["3"] {
+		t.Fatalf("eventos retomados = %v, esperado 2 e 3", received)
+	}
+}
+
+func TestStreamRejectsInvalidParameters(t *testing.T) {
+	env := newTestEnv(t)
+
+	for _, target := range []string{"/contacts/events?category_id=abc", "/contacts/events?last_event_id=-1"} {
+		rec := httptest.NewRecorder()
+		env.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("GET %s = %d, esperado 400", target, rec.Code)
+		}
+	}
+}
+
+// testEnv liga o repositório de contatos com outbox, o relay com o sink
+// local e o handler do stream sobre o mesmo banco SQLite
+type testEnv struct {
+	db       *sql.DB
+	contacts contacts.Service
+	router   *gin.Engine
+	server   *httptest.Server
+	outbox   *outbox.Relay
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	db := dbtest.OpenSQLite(t)
+	broker := stream.NewBroker(16)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("", tenant.Middleware(tenant.DefaultID))
+	stream.NewHandler(broker, stream.NewSQLLog(db), stream.HandlerOptions{
+		HeartbeatInterval: time.Minute,
+		WriteTimeout:      5 * time.Second,
+	}).RegisterRoutes(api)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(func() {
+		broker.Close()
+		server.Close()
+	})
+
+	return &testEnv{
+		db:       db,
+		contacts: contacts.NewService(contacts.NewSQLiteRepositoryWithOptions(db, contacts.SQLiteOptions{Outbox: true})),
+		router:   router,
+		server:   server,
+		outbox: outbox.NewRelay(outbox.NewStore(db), []outbox.Sink{stream.NewLocalSink(broker)}, outbox.RelayOptions{
+			BatchSize: 10, Lease: time.Minute, Backoff: time.Second, MaxBackoff: time.Minute,
+		}),
+	}
+}
+
+// enqueue grava no outbox, com o ID informado, um evento de atualização de
+// contato do tenant padrão
+func (e *testEnv) enqueue(t *testing.T, id int64) {
+	t.Helper()
+
+	query := `
+		INSERT INTO outbox_events (id, event_id, event_type, tenant_id, aggregate_id, payload, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+	`
+	if _, err := e.db.Exec(query, id, uuid.NewString(), contacts.EventContactUpdated, tenant.DefaultID, uuid.NewString(), "{}", time.Now().UTC()); err != nil {
+		t.Fatalf("erro ao gravar evento %d: %v", id, err)
+	}
+}
+
+func (e *testEnv) relay(t *testing.T) {
+	t.Helper()
+	if err := e.outbox.Run(context.Background()); err != nil {
+		t.Fatalf("relay: %v", err)
+	}
+}
+
+// frame é um evento recebido do stream
+type frame struct {
+	id, event, data string
+}
+
+type client struct {
+	frames chan frame
+}
+
+// connect abre o stream e aguarda a resposta; headers são pares nome, valor
+func (e *testEnv) connect(t *testing.T, target, tenantID string, headers ...string) *client {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, e.server.URL+target, nil)
+	if tenantID != "" {
+		req.Header.Set(tenant.HeaderName, tenantID)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", target, err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("GET %s = %d %s", target, resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	c := &client{frames: make(chan frame, 16)}
+	go func() {
+		defer resp.Body.Close()
+		defer close(c.frames)
+
+		var current frame
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if current.event != "" {
+					c.frames <- current
+				}
+				current = frame{}
+			case strings.HasPrefix(line, "id: "):
+				current.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				current.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				current.data += strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+	return c
+}
+
+func (c *client) next(t *testing.T) frame {
+	t.Helper()
+
+	select {
+	case f, ok := <-c.frames:
+		if !ok {
+			t.Fatal("stream encerrado")
+		}
+		return f
+	case <-time.After(5 * time.Second):
+		t.Fatal("nenhum evento recebido")
+	}
+	return frame{}
+}
+
+func publishedEvent(t *testing.T, seq int64, tenantID, payload string) stream.Event {
+	t.Helper()
+
+	// O evento é montado pelo sink local, como no relay
+	broker := stream.NewBroker(1)
+	subscription := broker.Subscribe(tenantID, stream.Filter{})
+	if err := stream.NewLocalSink(broker).Publish(context.Background(), outbox.Message{ID: seq, Type: contacts.EventContactUpdated, TenantID: tenantID, Payload: []byte(payload)}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	return <-subscription.Events()
+}
+
+func receive(subscription *stream.Subscription, n int) []stream.Event {
+	var events []stream.Event
+	for range n {
+		events = append(events, <-subscription.Events())
+	}
+	return events
+}
+
+// drain descarta os eventos pendentes e retorna o canal para verificar se foi fechado
+func drain(subscription *stream.Subscription) <-chan stream.Event {
+	for len(subscription.Events()) > 0 {
+		<-subscription.Events()
+	}
+	return subscription.Events()
+}
@@ -0,0 +1,196 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/metrics"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/worker"
+)
+
+// Cabeçalhos enviados em cada entrega. A assinatura é o HMAC-SHA256, em
+// hexadecimal e prefixado por "sha256=", de "<timestamp>.<corpo>" com o
+// segredo da assinatura; o receptor deve recusar timestamps antigos para
+// evitar a reutilização de uma entrega capturada.
+const (
+	HeaderID        = "X-Webhook-ID"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Resultados possíveis de uma tentativa de entrega, usados na métrica
+// webhook_deliveries_total
+const (
+	outcomeDelivered = "delivered"
+	outcomeFailed    = "failed"
+	outcomeDead      = "dead"
+)
+
+// Sign calcula a assinatura enviada em X-Webhook-Signature
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify confere a assinatura de uma entrega recebida e recusa timestamps que
+// diferem de now em mais de tolerance. Pode ser usado pelos receptores em Go.
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("timestamp inválido: %q", timestamp)
+	}
+
+	if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return errors.New("timestamp fora da tolerância")
+	}
+
+	if !hmac.Equal([]byte(Sign(secret, seconds, body)), []byte(signature)) {
+		return errors.New("assinatura inválida")
+	}
+	return nil
+}
+
+type DispatcherOptions struct {
+	// BatchSize é o número máximo de entregas reservadas e enviadas em paralelo
+	BatchSize int
+
+	// Lease é o tempo em que uma entrega reservada fica indisponível para
+	// outras réplicas; deve ser maior que Timeout
+	Lease time.Duration
+
+	// Timeout limita cada requisição ao receptor
+	Timeout time.Duration
+
+	// MaxAttempts é o número de falhas após o qual a entrega passa a dead
+	MaxAttempts int
+
+	// Backoff é a espera após a primeira falha, dobrada a cada nova falha até MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Dispatcher envia as entregas pendentes às URLs das assinaturas. Respostas
+// 2xx concluem a entrega; qualquer outro status ou erro de rede agenda uma
+// nova tentativa, até MaxAttempts. As entregas de um mesmo contato para uma
+// assinatura são enviadas uma de cada vez, na ordem dos eventos.
+type Dispatcher struct {
+	repo   Repository
+	client *http.Client
+	opts   DispatcherOptions
+	now    func() time.Time
+}
+
+func NewDispatcher(repo Repository, client *http.Client, opts DispatcherOptions) *Dispatcher {
+	return &Dispatcher{repo: repo, client: client, opts: opts, now: time.Now}
+}
+
+// Run envia lotes de entregas até não restarem entregas prontas. Deve ser
+// executado periodicamente (ex.: por um worker.Task).
+func (d *Dispatcher) Run(ctx context.Context) error {
+	for {
+		dispatches, err := d.repo.ClaimDeliveries(ctx, d.opts.BatchSize, d.opts.Lease)
+		if err != nil {
+			return err
+		}
+		if len(dispatches) == 0 {
+			return nil
+		}
+
+		errs := make([]error, len(dispatches))
+		var wg sync.WaitGroup
+		for i, dispatch := range dispatches {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = d.dispatch(ctx, dispatch)
+			}()
+		}
+		wg.Wait()
+
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// dispatch envia a entrega e registra o resultado. Retorna erro apenas quando
+// o resultado não pôde ser gravado; a entrega volta a ser enviada quando o
+// lease expirar.
+func (d *Dispatcher) dispatch(ctx context.Context, dispatch *Dispatch) error {
+	statusCode, err := d.send(ctx, dispatch)
+	if err == nil {
+		metrics.WebhookDeliveriesTotal.WithLabelValues(outcomeDelivered).Inc()
+		return d.repo.MarkDelivered(ctx, dispatch.ID, statusCode)
+	}
+
+	// No desligamento a tentativa não conta; a entrega volta quando o lease expirar
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	attempt := dispatch.Attempts + 1
+	dead := attempt >= d.opts.MaxAttempts
+	delay := worker.Backoff(dispatch.Attempts, d.opts.Backoff, d.opts.MaxBackoff)
+
+	if dead {
+		metrics.WebhookDeliveriesTotal.WithLabelValues(outcomeDead).Inc()
+		slog.ErrorContext(ctx, "Entrega de webhook esgotou as tentativas",
+			"delivery_id", dispatch.ID, "subscription_id", dispatch.SubscriptionID, "event_type", dispatch.EventType, "attempts", attempt, "error", err)
+	} else {
+		metrics.WebhookDeliveriesTotal.WithLabelValues(outcomeFailed).Inc()
+		slog.WarnContext(ctx, "Falha ao entregar webhook",
+			"delivery_id", dispatch.ID, "subscription_id", dispatch.SubscriptionID, "event_type", dispatch.EventType, "attempt", attempt, "retry_in", delay, "error", err)
+	}
+
+	return d.repo.MarkFailed(ctx, dispatch.ID, statusCode, err.Error(), d.now().Add(delay), dead)
+}
+
+// send faz a requisição assinada e retorna o status da resposta, se houver
+func (d *Dispatcher) send(ctx context.Context, dispatch *Dispatch) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dispatch.URL, bytes.NewReader(dispatch.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, dispatch.ID)
+	req.Header.Set(HeaderEvent, dispatch.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(dispatch.Secret, timestamp, dispatch.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Descarta um trecho do corpo para que a conexão possa ser reaproveitada
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,14 @@
+package webhooks
+
+import "errors"
+
+var (
+	// ErrNotFound indica que a assinatura não existe para o tenant informado
+	ErrNotFound = errors.New("assinatura não encontrada")
+
+	// ErrDeliveryNotFound indica que a entrega não existe na assinatura informada
+	ErrDeliveryNotFound = errors.New("entrega não encontrada")
+
+	// ErrInvalidSubscription indica uma URL ou um tipo de evento inválido
+	ErrInvalidSubscription = errors.New("assinatura inválida")
+)
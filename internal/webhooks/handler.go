@@ -0,0 +1,186 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/apierror"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+// @Description Dados para criação de uma assinatura de webhook
+type CreateSubscriptionRequest struct {
+	URL        string   `json:"url" binding:"required" example:"https://crm.example.com/hooks/contacts"`  // URL que recebe os eventos
+	EventTypes []string `json:"event_types" binding:"required" example:"contact.created,contact.updated"` // contact.created, contact.updated e/ou contact.deleted
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	webhooks := router.Group("/webhooks")
+	{
+		webhooks.POST("", h.CreateSubscription)
+		webhooks.GET("", h.GetSubscriptions)
+		webhooks.GET("/:id", h.GetSubscriptionByID)
+		webhooks.DELETE("/:id", h.DeleteSubscription)
+		webhooks.GET("/:id/deliveries", h.GetDeliveries)
+		webhooks.POST("/:id/deliveries/:deliveryId/replay", h.ReplayDelivery)
+	}
+}
+
+// @Summary     Criar assinatura de webhook
+// @Description Registra uma URL para receber os eventos de contatos dos tipos informados. O segredo usado na assinatura HMAC-SHA256 das entregas é retornado apenas nesta resposta.
+// @Tags        webhooks
+// @Accept      json
+// @Produce     json
+// @Param       Authorization header string true "Bearer <chave de API>; o tenant da assinatura é o da chave"
+// @Param       request body CreateSubscriptionRequest true "Dados da assinatura"
+// @Success     201 {object} Subscription
+// @Failure     400 {object} contacts.ErrorResponse "URL ou tipo de evento inválido"
+// @Failure     401 {object} contacts.ErrorResponse "Credenciais ausentes ou inválidas"
+// @Failure     429 {object} contacts.ErrorResponse "Limite de requisições excedido"
+// @Failure     500 {object} contacts.ErrorResponse "Erro interno do servidor"
+// @Router      /webhooks [post]
+func (h *Handler) CreateSubscription(c *gin.Context) {
+	var req CreateSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	subscription, err := h.service.CreateSubscription(c.Request.Context(), tenant.FromContext(c), req.URL, req.EventTypes)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+// @Summary     Listar assinaturas de webhook
+// @Description Retorna as assinaturas do tenant, sem os segredos
+// @Tags        webhooks
+// @Produce     json
+// @Param       Authorization header string true "Bearer <chave de API>; o tenant da assinatura é o da chave"
+// @Success     200 {array} Subscription
+// @Failure     401 {object} contacts.ErrorResponse "Credenciais ausentes ou inválidas"
+// @Failure     429 {object} contacts.ErrorResponse "Limite de requisições excedido"
+// @Failure     500 {object} contacts.ErrorResponse "Erro interno do servidor"
+// @Router      /webhooks [get]
+func (h *Handler) GetSubscriptions(c *gin.Context) {
+	subscriptions, err := h.service.GetSubscriptions(c.Request.Context(), tenant.FromContext(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+// @Summary     Buscar assinatura de webhook
+// @Description Retorna uma assinatura do tenant, sem o segredo
+// @Tags        webhooks
+// @Produce     json
+// @Param       Authorization header string true "Bearer <chave de API>; o tenant da assinatura é o da chave"
+// @Param       id path string true "ID da assinatura"
+// @Success     200 {object} Subscription
+// @Failure     401 {object} contacts.ErrorResponse "Credenciais ausentes ou inválidas"
+// @Failure     404 {object} contacts.ErrorResponse "Assinatura não encontrada"
+// @Failure     429 {object} contacts.ErrorResponse "Limite de requisições excedido"
+// @Router      /webhooks/{id} [get]
+func (h *Handler) GetSubscriptionByID(c *gin.Context) {
+	subscription, err := h.service.GetSubscriptionByID(c.Request.Context(), tenant.FromContext(c), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, subscription)
+}
+
+// @Summary     Excluir assinatura de webhook
+// @Description Remove a assinatura e o seu log de entregas
+// @Tags        webhooks
+// @Produce     json
+// @Param       Authorization header string true "Bearer <chave de API>; o tenant da assinatura é o da chave"
+// @Param       id path string true "ID da assinatura"
+// @Success     204 "Assinatura removida com sucesso"
+// @Failure     401 {object} contacts.ErrorResponse "Credenciais ausentes ou inválidas"
+// @Failure     404 {object} contacts.ErrorResponse "Assinatura não encontrada"
+// @Failure     429 {object} contacts.ErrorResponse "Limite de requisições excedido"
+// @Failure     500 {object} contacts.ErrorResponse "Erro interno do servidor"
+// @Router      /webhooks/{id} [delete]
+func (h *Handler) DeleteSubscription(c *gin.Context) {
+	if err := h.service.DeleteSubscription(c.Request.Context(), tenant.FromContext(c), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary     Log de entregas
+// @Description Retorna as 100 entregas mais recentes da assinatura, com o estado, as tentativas e o último erro
+// @Tags        webhooks
+// @Produce     json
+// @Param       Authorization header string true "Bearer <chave de API>; o tenant da assinatura é o da chave"
+// @Param       id path string true "ID da assinatura"
+// @Param       status query string false "Filtra pelo estado" Enums(pending, delivered, dead)
+// @Success     200 {array} Delivery
+// @Failure     400 {object} contacts.ErrorResponse "Estado inválido"
+// @Failure     401 {object} contacts.ErrorResponse "Credenciais ausentes ou inválidas"
+// @Failure     404 {object} contacts.ErrorResponse "Assinatura não encontrada"
+// @Failure     429 {object} contacts.ErrorResponse "Limite de requisições excedido"
+// @Failure     500 {object} contacts.ErrorResponse "Erro interno do servidor"
+// @Router      /webhooks/{id}/deliveries [get]
+func (h *Handler) GetDeliveries(c *gin.Context) {
+	deliveries, err := h.service.GetDeliveries(c.Request.Context(), tenant.FromContext(c), c.Param("id"), c.Query("status"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// @Summary     Reenviar entrega
+// @Description Volta a entrega para pendente, com as tentativas zeradas, para que seja reenviada assim que possível
+// @Tags        webhooks
+// @Produce     json
+// @Param       Authorization header string true "Bearer <chave de API>; o tenant da assinatura é o da chave"
+// @Param       id path string true "ID da assinatura"
+// @Param       deliveryId path string true "ID da entrega"
+// @Success     202 {object} Delivery
+// @Failure     401 {object} contacts.ErrorResponse "Credenciais ausentes ou inválidas"
+// @Failure     404 {object} contacts.ErrorResponse "Assinatura ou entrega não encontrada"
+// @Failure     429 {object} contacts.ErrorResponse "Limite de requisições excedido"
+// @Failure     500 {object} contacts.ErrorResponse "Erro interno do servidor"
+// @Router      /webhooks/{id}/deliveries/{deliveryId}/replay [post]
+func (h *Handler) ReplayDelivery(c *gin.Context) {
+	delivery, err := h.service.ReplayDelivery(c.Request.Context(), tenant.FromContext(c), c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// respondError converte erros de domínio no status HTTP correspondente
+func respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		apierror.Respond(c, http.StatusNotFound, "Assinatura não encontrada")
+	case errors.Is(err, ErrDeliveryNotFound):
+		apierror.Respond(c, http.StatusNotFound, "Entrega não encontrada")
+	case errors.Is(err, ErrInvalidSubscription):
+		apierror.Respond(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		apierror.Respond(c, http.StatusGatewayTimeout, "Tempo limite da operação excedido")
+	default:
+		apierror.Respond(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Estados de uma entrega. Uma entrega pendente é reenviada com espera
+// exponencial até ser aceita (delivered) ou esgotar as tentativas (dead).
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusDead      = "dead"
+)
+
+// @Description Assinatura de webhook
+type Subscription struct {
+	ID         string    `json:"id" example:"7d9f2c1e-5b7a-4c1e-9f3a-2b8d6e4c1a90"`                 // ID da assinatura
+	TenantID   string    `json:"tenant_id" example:"acme"`                                          // Tenant dono da assinatura
+	URL        string    `json:"url" example:"https://crm.example.com/hooks/contacts"`              // URL que recebe os eventos
+	EventTypes []string  `json:"event_types" example:"contact.created,contact.updated"`             // Tipos de evento entregues
+	Secret     string    `json:"secret,omitempty" example:"whsec_3f1c9a0b7e2d4c6f8a1b3d5e7f9a2c4e"` // Segredo da assinatura HMAC, exibido apenas na criação
+	CreatedAt  time.Time `json:"created_at" example:"2023-01-01T12:00:00Z"`                         // Data de criação
+}
+
+// Accepts informa se a assinatura recebe eventos do tipo informado
+func (s *Subscription) Accepts(eventType string) bool {
+	for _, accepted := range s.EventTypes {
+		if accepted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// @Description Entrega de um evento a uma assinatura
+type Delivery struct {
+	ID             string          `json:"id" example:"0b6e7f7a-3c2d-4e1f-8a9b-5c6d7e8f9a0b"`              // ID da entrega, enviado em X-Webhook-ID
+	SubscriptionID string          `json:"subscription_id" example:"7d9f2c1e-5b7a-4c1e-9f3a-2b8d6e4c1a90"` // ID da assinatura
+	TenantID       string          `json:"tenant_id" example:"acme"`                                       // Tenant dono da assinatura
+	EventID        string          `json:"event_id" example:"979dd9c2-d8a1-4009-a794-72dfdc476a23"`        // ID do evento
+	EventType      string          `json:"event_type" example:"contact.created"`                           // Tipo do evento
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`                                   // Corpo enviado
+	Status         string          `json:"status" example:"pending"`                                       // pending, delivered ou dead
+	Attempts       int             `json:"attempts" example:"1"`                                           // Tentativas que falharam
+	NextAttemptAt  time.Time       `json:"next_attempt_at" example:"2023-01-01T12:00:30Z"`                 // Próxima tentativa, se pendente
+	LastStatusCode int             `json:"last_status_code,omitempty" example:"503"`                       // Status HTTP da última resposta
+	LastError      string          `json:"last_error,omitempty" example:"status 503"`                      // Erro da última tentativa
+	CreatedAt      time.Time       `json:"created_at" example:"2023-01-01T12:00:00Z"`                      // Data de criação
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" example:"2023-01-01T12:00:01Z"`          // Data da entrega
+}
+
+// Dispatch é uma entrega reservada para envio, com o destino e o segredo da assinatura
+type Dispatch struct {
+	Delivery
+	URL    string
+	Secret string
+}
@@ -0,0 +1,85 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// LookupFunc resolve um host nos seus endereços IP
+type LookupFunc func(ctx context.Context, host string) ([]netip.Addr, error)
+
+// lookupHost resolve host com o resolvedor do sistema
+func lookupHost(ctx context.Context, host string) ([]netip.Addr, error) {
+	return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+}
+
+// isPublicAddr informa se addr pode receber entregas: endereços de loopback,
+// de redes privadas, link-local, não especificados ou multicast levariam as
+// requisições da API à sua própria rede interna (SSRF)
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified()
+}
+
+// checkHost resolve host e rejeita a URL se algum dos endereços não for público
+func checkHost(ctx context.Context, lookup LookupFunc, host string) error {
+	addrs := []netip.Addr{}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = append(addrs, addr)
+	} else {
+		if addrs, err = lookup(ctx, host); err != nil || len(addrs) == 0 {
+			return fmt.Errorf("%w: não foi possível resolver o host %s", ErrInvalidSubscription, host)
+		}
+	}
+
+	for _, addr := range addrs {
+		if !isPublicAddr(addr) {
+			return fmt.Errorf("%w: o host %s aponta para um endereço interno", ErrInvalidSubscription, host)
+		}
+	}
+	return nil
+}
+
+// NewHTTPClient cria o cliente usado pelo Dispatcher. Sem allowPrivate, a
+// conexão é recusada no momento da discagem quando o endereço resolvido não é
+// público, o que também cobre assinaturas antigas e DNS rebinding (o host
+// resolvia para um endereço público no cadastro e passou a resolver para um
+// interno). Redirecionamentos não são seguidos: a resposta 3xx conta como
+// falha da entrega.
+func NewHTTPClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !isPublicAddr(addrPort.Addr()) {
+				return fmt.Errorf("conexão recusada para o endereço interno %s", address)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// Um proxy faria a discagem ir para ele, e não para o receptor
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
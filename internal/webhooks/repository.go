@@ -0,0 +1,369 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/outbox"
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	CreateSubscription(ctx context.Context, subscription *Subscription) error
+	FindSubscriptions(ctx context.Context, tenantID string) ([]*Subscription, error)
+	FindSubscriptionByID(ctx context.Context, tenantID, id string) (*Subscription, error)
+	DeleteSubscription(ctx context.Context, tenantID, id string) error
+
+	// EnqueueDeliveries cria uma entrega para cada assinatura do tenant que
+	// aceita o tipo do evento. Um evento entregue de novo pelo outbox não
+	// gera entregas duplicadas. As entregas de um mesmo contato são enviadas
+	// na ordem dos eventos no outbox.
+	EnqueueDeliveries(ctx context.Context, message outbox.Message) error
+
+	// FindDeliveries retorna as entregas mais recentes da assinatura,
+	// opcionalmente filtradas pelo estado
+	FindDeliveries(ctx context.Context, tenantID, subscriptionID, status string, limit int) ([]*Delivery, error)
+
+	// ReplayDelivery volta a entrega para pendente, com as tentativas zeradas
+	ReplayDelivery(ctx context.Context, tenantID, subscriptionID, id string) (*Delivery, error)
+
+	// ClaimDeliveries reserva por lease até limit entregas pendentes prontas para envio
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*Dispatch, error)
+	MarkDelivered(ctx context.Context, id string, statusCode int) error
+
+	// MarkFailed registra a falha; com dead a entrega não é mais tentada
+	MarkFailed(ctx context.Context, id string, statusCode int, cause string, nextAttempt time.Time, dead bool) error
+
+	// Purge remove as entregas concluídas (delivered ou dead) criadas há mais de olderThan
+	Purge(ctx context.Context, olderThan time.Duration) (int64, error)
+}
+
+// SQLRepository guarda as assinaturas e entregas no PostgreSQL ou no SQLite.
+// As consultas são compatíveis com os dois bancos: os IDs são gerados pela
+// aplicação e os horários gravados em UTC.
+type SQLRepository struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db, now: time.Now}
+}
+
+const deliveryColumns = `id, subscription_id, tenant_id, event_id, event_type, payload, status, attempts,
+	next_attempt_at, last_status_code, last_error, created_at, delivered_at`
+
+func (r *SQLRepository) CreateSubscription(ctx context.Context, subscription *Subscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (id, tenant_id, url, event_types, secret, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	id := uuid.NewString()
+	_, err := r.db.ExecContext(ctx, query, id, subscription.TenantID, subscription.URL, strings.Join(subscription.EventTypes, ","), subscription.Secret, subscription.CreatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("erro ao criar assinatura: %v", err)
+	}
+
+	subscription.ID = id
+	return nil
+}
+
+func (r *SQLRepository) FindSubscriptions(ctx context.Context, tenantID string) ([]*Subscription, error) {
+	query := `
+		SELECT id, tenant_id, url, event_types, created_at
+		FROM webhook_subscriptions
+		WHERE tenant_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar assinaturas: %v", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []*Subscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler assinatura: %v", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao listar assinaturas: %v", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *SQLRepository) FindSubscriptionByID(ctx context.Context, tenantID, id string) (*Subscription, error) {
+	query := `
+		SELECT id, tenant_id, url, event_types, created_at
+		FROM webhook_subscriptions
+		WHERE tenant_id = $1 AND id = $2
+	`
+
+	subscription, err := scanSubscription(r.db.QueryRowContext(ctx, query, tenantID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar assinatura: %v", err)
+	}
+
+	return subscription, nil
+}
+
+func (r *SQLRepository) DeleteSubscription(ctx context.Context, tenantID, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao remover assinatura: %v", err)
+	}
+	defer tx.Rollback()
+
+	// As entregas são removidas explicitamente: o SQLite só aplica o ON DELETE
+	// CASCADE quando as foreign keys estão habilitadas na conexão
+	if _, err := tx.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE tenant_id = $1 AND subscription_id = $2", tenantID, id); err != nil {
+		return fmt.Errorf("erro ao remover entregas da assinatura: %v", err)
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE tenant_id = $1 AND id = $2", tenantID, id)
+	if err != nil {
+		return fmt.Errorf("erro ao remover assinatura: %v", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("erro ao remover assinatura: %v", err)
+	} else if affected == 0 {
+		return ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao remover assinatura: %v", err)
+	}
+	return nil
+}
+
+func (r *SQLRepository) EnqueueDeliveries(ctx context.Context, message outbox.Message) error {
+	subscriptions, err := r.FindSubscriptions(ctx, message.TenantID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (id, subscription_id, tenant_id, event_id, aggregate_id, event_seq, event_type, payload, status, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+
+	now := r.now().UTC()
+	for _, subscription := range subscriptions {
+		if !subscription.Accepts(message.Type) {
+			continue
+		}
+
+		_, err := r.db.ExecContext(ctx, query, uuid.NewString(), subscription.ID, message.TenantID, message.EventID, message.AggregateID, message.ID, message.Type, string(message.Payload), StatusPending, now)
+		if err != nil {
+			return fmt.Errorf("erro ao criar entrega para a assinatura %s: %v", subscription.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *SQLRepository) FindDeliveries(ctx context.Context, tenantID, subscriptionID, status string, limit int) ([]*Delivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE tenant_id = $1 AND subscription_id = $2 AND (CAST($3 AS TEXT) = '' OR status = $3)
+		ORDER BY created_at DESC, id
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, subscriptionID, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar entregas: %v", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*Delivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler entrega: %v", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao listar entregas: %v", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *SQLRepository) ReplayDelivery(ctx context.Context, tenantID, subscriptionID, id string) (*Delivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = 0, next_attempt_at = $2, locked_until = NULL, delivered_at = NULL
+		WHERE tenant_id = $3 AND subscription_id = $4 AND id = $5
+		RETURNING ` + deliveryColumns
+
+	delivery, err := scanDelivery(r.db.QueryRowContext(ctx, query, StatusPending, r.now().UTC(), tenantID, subscriptionID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao reenviar entrega: %v", err)
+	}
+
+	return delivery, nil
+}
+
+func (r *SQLRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*Dispatch, error) {
+	// Como no outbox, apenas a entrega pendente mais antiga de cada contato
+	// por assinatura pode ser reservada, e a condição sobre locked_until se
+	// repete fora da subconsulta para que duas réplicas não reservem a mesma
+	// entrega. Entregas mortas não seguram as seguintes.
+	claimQuery := `
+		UPDATE webhook_deliveries SET locked_until = $1
+		WHERE id IN (
+			SELECT d.id FROM webhook_deliveries d
+			WHERE d.status = $2 AND d.next_attempt_at <= $3 AND (d.locked_until IS NULL OR d.locked_until < $3)
+				AND NOT EXISTS (
+					SELECT 1 FROM webhook_deliveries p
+					WHERE p.subscription_id = d.subscription_id AND p.aggregate_id = d.aggregate_id
+						AND p.status = $2 AND p.event_seq < d.event_seq
+				)
+			ORDER BY d.next_attempt_at
+			LIMIT $4
+		)
+		AND status = $2 AND (locked_until IS NULL OR locked_until < $3)
+		RETURNING ` + deliveryColumns
+
+	now := r.now().UTC()
+	rows, err := r.db.QueryContext(ctx, claimQuery, now.Add(lease), StatusPending, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao reservar entregas: %v", err)
+	}
+	defer rows.Close()
+
+	dispatches := []*Dispatch{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler entrega: %v", err)
+		}
+		dispatches = append(dispatches, &Dispatch{Delivery: *delivery})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao reservar entregas: %v", err)
+	}
+
+	// O destino e o segredo são lidos depois, já que o RETURNING não alcança a assinatura
+	for _, dispatch := range dispatches {
+		err := r.db.QueryRowContext(ctx, "SELECT url, secret FROM webhook_subscriptions WHERE id = $1", dispatch.SubscriptionID).Scan(&dispatch.URL, &dispatch.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao buscar assinatura da entrega %s: %v", dispatch.ID, err)
+		}
+	}
+
+	return dispatches, nil
+}
+
+func (r *SQLRepository) MarkDelivered(ctx context.Context, id string, statusCode int) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, delivered_at = $2, last_status_code = $3, last_error = NULL, locked_until = NULL
+		WHERE id = $4
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, StatusDelivered, r.now().UTC(), statusCode, id); err != nil {
+		return fmt.Errorf("erro ao registrar entrega %s: %v", id, err)
+	}
+	return nil
+}
+
+func (r *SQLRepository) MarkFailed(ctx context.Context, id string, statusCode int, cause string, nextAttempt time.Time, dead bool) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = attempts + 1, next_attempt_at = $2, last_status_code = $3, last_error = $4, locked_until = NULL
+		WHERE id = $5
+	`
+
+	status := StatusPending
+	if dead {
+		status = StatusDead
+	}
+
+	// Sem resposta HTTP, o status fica nulo
+	var code sql.NullInt64
+	if statusCode != 0 {
+		code = sql.NullInt64{Int64: int64(statusCode), Valid: true}
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, status, nextAttempt.UTC(), code, cause, id); err != nil {
+		return fmt.Errorf("erro ao registrar falha da entrega %s: %v", id, err)
+	}
+	return nil
+}
+
+func (r *SQLRepository) Purge(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query := `
+		DELETE FROM webhook_deliveries
+		WHERE status <> $1 AND created_at < $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, StatusPending, r.now().Add(-olderThan).UTC())
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover entregas concluídas: %v", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// scanner abstrai *sql.Row e *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSubscription(row scanner) (*Subscription, error) {
+	subscription := &Subscription{}
+	var eventTypes string
+	if err := row.Scan(&subscription.ID, &subscription.TenantID, &subscription.URL, &eventTypes, &subscription.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	subscription.EventTypes = strings.Split(eventTypes, ",")
+	return subscription, nil
+}
+
+func scanDelivery(row scanner) (*Delivery, error) {
+	delivery := &Delivery{}
+	var (
+		payload     []byte
+		statusCode  sql.NullInt64
+		lastError   sql.NullString
+		deliveredAt sql.NullTime
+	)
+
+	err := row.Scan(&delivery.ID, &delivery.SubscriptionID, &delivery.TenantID, &delivery.EventID, &delivery.EventType, &payload,
+		&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &statusCode, &lastError, &delivery.CreatedAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Payload = payload
+	delivery.LastStatusCode = int(statusCode.Int64)
+	delivery.LastError = lastError.String
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+	return delivery, nil
+}
@@ -0,0 +1,148 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/google/uuid"
+)
+
+// EventTypes lista os tipos de evento que podem ser assinados
+var EventTypes = []string{contacts.EventContactCreated, contacts.EventContactUpdated, contacts.EventContactDeleted}
+
+// deliveryLogLimit é o número máximo de entregas retornadas pelo log
+const deliveryLogLimit = 100
+
+type Service interface {
+	CreateSubscription(ctx context.Context, tenantID, callbackURL string, eventTypes []string) (*Subscription, error)
+	GetSubscriptions(ctx context.Context, tenantID string) ([]*Subscription, error)
+	GetSubscriptionByID(ctx context.Context, tenantID, id string) (*Subscription, error)
+	DeleteSubscription(ctx context.Context, tenantID, id string) error
+	GetDeliveries(ctx context.Context, tenantID, subscriptionID, status string) ([]*Delivery, error)
+	ReplayDelivery(ctx context.Context, tenantID, subscriptionID, id string) (*Delivery, error)
+}
+
+type ServiceOptions struct {
+	// AllowPrivateNetworks aceita URLs que apontam para loopback e redes
+	// privadas, para receptores na mesma rede em desenvolvimento
+	AllowPrivateNetworks bool
+
+	// Lookup resolve o host das URLs; nil usa o resolvedor do sistema
+	Lookup LookupFunc
+}
+
+type service struct {
+	repo Repository
+	opts ServiceOptions
+}
+
+// NewService cria o serviço que rejeita URLs de endereços internos
+func NewService(repo Repository) Service {
+	return NewServiceWithOptions(repo, ServiceOptions{})
+}
+
+func NewServiceWithOptions(repo Repository, opts ServiceOptions) Service {
+	if opts.Lookup == nil {
+		opts.Lookup = lookupHost
+	}
+	return &service{repo: repo, opts: opts}
+}
+
+func (s *service) CreateSubscription(ctx context.Context, tenantID, callbackURL string, eventTypes []string) (*Subscription, error) {
+	parsed, err := url.Parse(callbackURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return nil, fmt.Errorf("%w: a url deve ser absoluta, com http ou https", ErrInvalidSubscription)
+	}
+	if !s.opts.AllowPrivateNetworks {
+		if err := checkHost(ctx, s.opts.Lookup, parsed.Hostname()); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("%w: informe ao menos um tipo de evento", ErrInvalidSubscription)
+	}
+	for _, eventType := range eventTypes {
+		if !slices.Contains(EventTypes, eventType) {
+			return nil, fmt.Errorf("%w: tipo de evento desconhecido %q", ErrInvalidSubscription, eventType)
+		}
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &Subscription{
+		TenantID:   tenantID,
+		URL:        callbackURL,
+		EventTypes: slices.Compact(slices.Sorted(slices.Values(eventTypes))),
+		Secret:     secret,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (s *service) GetSubscriptions(ctx context.Context, tenantID string) ([]*Subscription, error) {
+	return s.repo.FindSubscriptions(ctx, tenantID)
+}
+
+func (s *service) GetSubscriptionByID(ctx context.Context, tenantID, id string) (*Subscription, error) {
+	// No PostgreSQL um ID que não é UUID gera erro de sintaxe em vez de não encontrado
+	if uuid.Validate(id) != nil {
+		return nil, ErrNotFound
+	}
+	return s.repo.FindSubscriptionByID(ctx, tenantID, id)
+}
+
+func (s *service) DeleteSubscription(ctx context.Context, tenantID, id string) error {
+	if uuid.Validate(id) != nil {
+		return ErrNotFound
+	}
+	return s.repo.DeleteSubscription(ctx, tenantID, id)
+}
+
+func (s *service) GetDeliveries(ctx context.Context, tenantID, subscriptionID, status string) ([]*Delivery, error) {
+	switch status {
+	case "", StatusPending, StatusDelivered, StatusDead:
+	default:
+		return nil, fmt.Errorf("%w: status deve ser pending, delivered ou dead", ErrInvalidSubscription)
+	}
+
+	if _, err := s.GetSubscriptionByID(ctx, tenantID, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindDeliveries(ctx, tenantID, subscriptionID, status, deliveryLogLimit)
+}
+
+func (s *service) ReplayDelivery(ctx context.Context, tenantID, subscriptionID, id string) (*Delivery, error) {
+	if _, err := s.GetSubscriptionByID(ctx, tenantID, subscriptionID); err != nil {
+		return nil, err
+	}
+	if uuid.Validate(id) != nil {
+		return nil, ErrDeliveryNotFound
+	}
+
+	return s.repo.ReplayDelivery(ctx, tenantID, subscriptionID, id)
+}
+
+// newSecret gera o segredo usado para assinar as entregas
+func newSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("erro ao gerar segredo da assinatura: %v", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,26 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/outbox"
+)
+
+type sink struct {
+	repo Repository
+}
+
+// NewSink cria o sink do outbox que transforma cada evento em entregas para
+// as assinaturas interessadas. O envio fica a cargo do Dispatcher, para que
+// um receptor lento ou fora do ar não atrase os demais sinks.
+func NewSink(repo Repository) outbox.Sink {
+	return &sink{repo: repo}
+}
+
+func (s *sink) Name() string {
+	return "webhook"
+}
+
+func (s *sink) Publish(ctx context.Context, message outbox.Message) error {
+	return s.repo.EnqueueDeliveries(ctx, message)
+}
@@ -0,0 +1,417 @@
+package webhooks_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Felipe8297/go-contacts-api/internal/contacts"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/dbtest"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/middleware"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/outbox"
+	"github.com/Felipe8297/go-contacts-api/internal/pkg/tenant"
+	"github.com/Felipe8297/go-contacts-api/internal/webhooks"
+	"github.com/gin-gonic/gin"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"type":"contact.created"}`)
+	now := time.Unix(1700000000, 0)
+	signature := webhooks.Sign("segredo", now.Unix(), body)
+
+	if err := webhooks.Verify("segredo", "1700000000", signature, body, time.Minute, now); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := webhooks.Verify("outro", "1700000000", signature, body, time.Minute, now); err == nil {
+		t.Fatal("Verify aceitou outro segredo")
+	}
+	if err := webhooks.Verify("segredo", "1700000000", signature, []byte(`{}`), time.Minute, now); err == nil {
+		t.Fatal("Verify aceitou outro corpo")
+	}
+	if err := webhooks.Verify("segredo", "1700000000", signature, body, time.Minute, now.Add(2*time.Minute)); err == nil {
+		t.Fatal("Verify aceitou timestamp fora da tolerância")
+	}
+}
+
+// receiver é um receptor de webhooks que confere a assinatura de cada entrega
+type receiver struct {
+	t      *testing.T
+	server *httptest.Server
+	secret atomic.Value
+	status atomic.Int32
+
+	mu     sync.Mutex
+	events []contacts.Event
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{t: t}
+	r.status.Store(http.StatusOK)
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		secret, _ := r.secret.Load().(string)
+		if err := webhooks.Verify(secret, req.Header.Get(webhooks.HeaderTimestamp), req.Header.Get(webhooks.HeaderSignature), body, time.Minute, time.Now()); err != nil {
+			r.t.Errorf("entrega com assinatura inválida: %v", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var event contacts.Event
+		if err := json.Unmarshal(body, &event); err != nil {
+			r.t.Errorf("payload inválido: %v", err)
+		}
+		if req.Header.Get(webhooks.HeaderEvent) != event.Type || req.Header.Get(webhooks.HeaderID) == "" {
+			r.t.Errorf("cabeçalhos da entrega = %v", req.Header)
+		}
+
+		status := int(r.status.Load())
+		if status == http.StatusOK {
+			r.mu.Lock()
+			r.events = append(r.events, event)
+			r.mu.Unlock()
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *receiver) received() []contacts.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]contacts.Event(nil), r.events...)
+}
+
+// testEnv liga o repositório de contatos com outbox, o relay com o sink de
+// webhooks, o dispatcher e a API de webhooks sobre o mesmo banco SQLite
+type testEnv struct {
+	contacts   contacts.Service
+	router     *gin.Engine
+	relay      *outbox.Relay
+	dispatcher *webhooks.Dispatcher
+}
+
+func newTestEnv(t *testing.T, maxAttempts int) *testEnv {
+	t.Helper()
+
+	db := dbtest.OpenSQLite(t)
+	repo := webhooks.NewSQLRepository(db)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RequestIDMiddleware())
+	api := router.Group("", tenant.Middleware(tenant.DefaultID))
+	// O receptor de testes escuta em 127.0.0.1
+	webhooks.NewHandler(webhooks.NewServiceWithOptions(repo, webhooks.ServiceOptions{AllowPrivateNetworks: true})).RegisterRoutes(api)
+
+	return &testEnv{
+		contacts: contacts.NewService(contacts.NewSQLiteRepositoryWithOptions(db, contacts.SQLiteOptions{Outbox: true})),
+		router:   router,
+		relay: outbox.NewRelay(outbox.NewStore(db), []outbox.Sink{webhooks.NewSink(repo)}, outbox.RelayOptions{
+			BatchSize: 10, Lease: time.Minute, Backoff: time.Second, MaxBackoff: time.Minute,
+		}),
+		dispatcher: webhooks.NewDispatcher(repo, webhooks.NewHTTPClient(5*time.Second, true), webhooks.DispatcherOptions{
+			BatchSize: 10, Lease: time.Minute, Timeout: 5 * time.Second, MaxAttempts: maxAttempts,
+			Backoff: time.Millisecond, MaxBackoff: time.Millisecond,
+		}),
+	}
+}
+
+// deliver publica os eventos pendentes do outbox e envia as entregas prontas
+func (e *testEnv) deliver(t *testing.T) {
+	t.Helper()
+
+	if err := e.relay.Run(context.Background()); err != nil {
+		t.Fatalf("relay: %v", err)
+	}
+	if err := e.dispatcher.Run(context.Background()); err != nil {
+		t.Fatalf("dispatcher: %v", err)
+	}
+}
+
+func (e *testEnv) do(t *testing.T, method, path, tenantID string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatalf("erro ao codificar corpo: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(tenant.HeaderName, tenantID)
+
+	rec := httptest.NewRecorder()
+	e.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func (e *testEnv) subscribe(t *testing.T, tenantID, url string, eventTypes ...string) webhooks.Subscription {
+	t.Helper()
+
+	rec := e.do(t, http.MethodPost, "/webhooks", tenantID, webhooks.CreateSubscriptionRequest{URL: url, EventTypes: eventTypes})
+	assertStatus(t, rec, http.StatusCreated)
+	subscription := decode[webhooks.Subscription](t, rec)
+	if subscription.Secret == "" {
+		t.Fatal("assinatura criada sem segredo")
+	}
+	return subscription
+}
+
+func TestWebhooksEndToEnd(t *testing.T) {
+	env := newTestEnv(t, 3)
+	ctx := context.Background()
+	recv := newReceiver(t)
+
+	subscription := env.subscribe(t, "acme", recv.server.URL, contacts.EventContactCreated, contacts.EventContactDeleted)
+	recv.secret.Store(subscription.Secret)
+
+	// Eventos de outro tenant não chegam à assinatura
+	if _, err := env.contacts.CreateNewContact(ctx, "globex", "Bia", "bia@example.com", "", ""); err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+
+	contact, err := env.contacts.CreateNewContact(ctx, "acme", "Ana", "ana@example.com", "", "")
+	if err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+	if _, err := env.contacts.UpdateContact(ctx, "acme", contact.ID, "Ana Souza", "ana@example.com", "", ""); err != nil {
+		t.Fatalf("UpdateContact: %v", err)
+	}
+	if err := env.contacts.DeleteContact(ctx, "acme", contact.ID); err != nil {
+		t.Fatalf("DeleteContact: %v", err)
+	}
+
+	env.deliver(t)
+
+	// contact.updated não foi assinado
+	events := recv.received()
+	if len(events) != 2 || events[0].Type != contacts.EventContactCreated || events[1].Type != contacts.EventContactDeleted {
+		t.Fatalf("eventos recebidos = %+v", events)
+	}
+	if events[0].ContactID != contact.ID || events[0].Contact == nil || events[0].Contact.Name != "Ana" {
+		t.Fatalf("evento de criação = %+v", events[0])
+	}
+
+	rec := env.do(t, http.MethodGet, "/webhooks/"+subscription.ID+"/deliveries?status=delivered", "acme", nil)
+	assertStatus(t, rec, http.StatusOK)
+	deliveries := decode[[]webhooks.Delivery](t, rec)
+	if len(deliveries) != 2 {
+		t.Fatalf("log de entregas = %+v", deliveries)
+	}
+	for _, delivery := range deliveries {
+		if delivery.LastStatusCode != http.StatusOK || delivery.DeliveredAt == nil || delivery.Attempts != 0 {
+			t.Fatalf("entrega = %+v", delivery)
+		}
+	}
+
+	// Uma nova execução não reenvia o que já foi entregue
+	env.deliver(t)
+	if got := len(recv.received()); got != 2 {
+		t.Fatalf("%d eventos recebidos após nova execução, esperado 2", got)
+	}
+}
+
+func TestWebhooksRetryUntilDeadAndReplay(t *testing.T) {
+	env := newTestEnv(t, 3)
+	ctx := context.Background()
+	recv := newReceiver(t)
+	recv.status.Store(http.StatusServiceUnavailable)
+
+	subscription := env.subscribe(t, "acme", recv.server.URL, contacts.EventContactCreated)
+	recv.secret.Store(subscription.Secret)
+
+	if _, err := env.contacts.CreateNewContact(ctx, "acme", "Ana", "ana@example.com", "", ""); err != nil {
+		t.Fatalf("CreateNewContact: %v", err)
+	}
+
+	var delivery webhooks.Delivery
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		env.deliver(t)
+
+		rec := env.do(t, http.MethodGet, "/webhooks/"+subscription.ID+"/deliveries", "acme", nil)
+		assertStatus(t, rec, http.StatusOK)
+		deliveries := decode[[]webhooks.Delivery](t, rec)
+		if len(deliveries) != 1 {
+			t.Fatalf("log de entregas = %+v", deliveries)
+		}
+		delivery = deliveries[0]
+
+		if delivery.Status == webhooks.StatusDead {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("entrega não chegou a dead: %+v", delivery)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if delivery.Attempts != 3 || delivery.LastStatusCode != http.StatusServiceUnavailable || delivery.LastError == "" {
+		t.Fatalf("entrega morta = %+v", delivery)
+	}
+
+	// Uma entrega morta não é mais tentada
+	env.deliver(t)
+	if len(recv.received()) != 0 {
+		t.Fatal("entrega morta foi reenviada")
+	}
+
+	recv.status.Store(http.StatusOK)
+	rec := env.do(t, http.MethodPost, "/webhooks/"+subscription.ID+"/deliveries/"+delivery.ID+"/replay", "acme", nil)
+	assertStatus(t, rec, http.StatusAccepted)
+	if replayed := decode[webhooks.Delivery](t, rec); replayed.Status != webhooks.StatusPending || replayed.Attempts != 0 {
+		t.Fatalf("entrega após replay = %+v", replayed)
+	}
+
+	env.deliver(t)
+	if events := recv.received(); len(events) != 1 || events[0].Type != contacts.EventContactCreated {
+		t.Fatalf("eventos recebidos após replay = %+v", events)
+	}
+}
+
+func TestCreateSubscriptionRejectsInternalAddresses(t *testing.T) {
+	// lookup simula o DNS sem depender da rede
+	lookup := func(_ context.Context, host string) ([]netip.Addr, error) {
+		switch host {
+		case "hooks.example.com":
+			return []netip.Addr{netip.MustParseAddr("93.184.216.34")}, nil
+		case "interno.example.com":
+			return []netip.Addr{netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr("10.0.0.5")}, nil
+		case "localhost":
+			return []netip.Addr{netip.MustParseAddr("127.0.0.1"), netip.MustParseAddr("::1")}, nil
+		}
+		return nil, errors.New("host desconhecido")
+	}
+
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{url: "https://hooks.example.com/contacts", allowed: true},
+		{url: "https://93.184.216.34:8443/contacts", allowed: true},
+		{url: "http://localhost:8080/hooks"},
+		{url: "http://127.0.0.1/hooks"},
+		{url: "http://[::1]/hooks"},
+		{url: "http://[::ffff:127.0.0.1]/hooks"},
+		{url: "http://10.1.2.3/hooks"},
+		{url: "http://172.16.0.1/hooks"},
+		{url: "http://192.168.0.10/hooks"},
+		{url: "http://169.254.169.254/latest/meta-data"},
+		{url: "http://[fe80::1]/hooks"},
+		{url: "http://0.0.0.0/hooks"},
+		{url: "http://interno.example.com/hooks"},
+		{url: "http://inexistente.example.com/hooks"},
+	}
+
+	service := webhooks.NewServiceWithOptions(webhooks.NewSQLRepository(dbtest.OpenSQLite(t)), webhooks.ServiceOptions{Lookup: lookup})
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			_, err := service.CreateSubscription(context.Background(), "acme", tt.url, []string{contacts.EventContactCreated})
+			if tt.allowed && err != nil {
+				t.Fatalf("URL pública rejeitada: %v", err)
+			}
+			if !tt.allowed && !errors.Is(err, webhooks.ErrInvalidSubscription) {
+				t.Fatalf("erro = %v, esperado ErrInvalidSubscription", err)
+			}
+		})
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	var redirected atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/destino" {
+			redirected.Store(true)
+		}
+		http.Redirect(w, req, "/destino", http.StatusFound)
+	}))
+	defer server.Close()
+
+	// localhost resolve para loopback na discagem, como um host que passou a
+	// resolver para um endereço interno depois do cadastro (DNS rebinding)
+	localhostURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+	for _, url := range []string{server.URL, localhostURL} {
+		if _, err := webhooks.NewHTTPClient(time.Second, false).Get(url); err == nil || !strings.Contains(err.Error(), "endereço interno") {
+			t.Fatalf("GET %s = %v, esperado conexão recusada", url, err)
+		}
+	}
+
+	resp, err := webhooks.NewHTTPClient(time.Second, true).Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET com redes privadas permitidas: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound || redirected.Load() {
+		t.Fatalf("status = %d, redirecionamento seguido = %v; esperado 302 sem seguir", resp.StatusCode, redirected.Load())
+	}
+}
+
+func TestWebhooksHandler(t *testing.T) {
+	env := newTestEnv(t, 3)
+
+	for name, req := range map[string]webhooks.CreateSubscriptionRequest{
+		"URLRelativa":        {URL: "/hooks", EventTypes: []string{contacts.EventContactCreated}},
+		"EsquemaInvalido":    {URL: "ftp://example.com/hooks", EventTypes: []string{contacts.EventContactCreated}},
+		"EventoDesconhecido": {URL: "https://example.com/hooks", EventTypes: []string{"contact.merged"}},
+		"SemEventos":         {URL: "https://example.com/hooks", EventTypes: []string{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assertStatus(t, env.do(t, http.MethodPost, "/webhooks", "acme", req), http.StatusBadRequest)
+		})
+	}
+
+	subscription := env.subscribe(t, "acme", "https://example.com/hooks", contacts.EventContactUpdated, contacts.EventContactCreated, contacts.EventContactCreated)
+	if len(subscription.EventTypes) != 2 {
+		t.Fatalf("tipos de evento = %v, esperado sem duplicatas", subscription.EventTypes)
+	}
+
+	rec := env.do(t, http.MethodGet, "/webhooks/"+subscription.ID, "acme", nil)
+	assertStatus(t, rec, http.StatusOK)
+	if found := decode[webhooks.Subscription](t, rec); found.Secret != "" || found.URL != subscription.URL {
+		t.Fatalf("assinatura = %+v, esperado sem segredo", found)
+	}
+
+	// Assinaturas são isoladas por tenant
+	assertStatus(t, env.do(t, http.MethodGet, "/webhooks/"+subscription.ID, "globex", nil), http.StatusNotFound)
+	if list := decode[[]webhooks.Subscription](t, env.do(t, http.MethodGet, "/webhooks", "globex", nil)); len(list) != 0 {
+		t.Fatalf("assinaturas de outro tenant = %+v", list)
+	}
+
+	assertStatus(t, env.do(t, http.MethodGet, "/webhooks/nao-existe", "acme", nil), http.StatusNotFound)
+	assertStatus(t, env.do(t, http.MethodGet, "/webhooks/"+subscription.ID+"/deliveries?status=lost", "acme", nil), http.StatusBadRequest)
+	assertStatus(t, env.do(t, http.MethodPost, "/webhooks/"+subscription.ID+"/deliveries/nao-existe/replay", "acme", nil), http.StatusNotFound)
+
+	assertStatus(t, env.do(t, http.MethodDelete, "/webhooks/"+subscription.ID, "acme", nil), http.StatusNoContent)
+	assertStatus(t, env.do(t, http.MethodDelete, "/webhooks/"+subscription.ID, "acme", nil), http.StatusNotFound)
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var value T
+	if err := json.Unmarshal(rec.Body.Bytes(), &value); err != nil {
+		t.Fatalf("erro ao decodificar resposta %q: %v", rec.Body.String(), err)
+	}
+	return value
+}
+
+func assertStatus(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status = %d, esperado %d (corpo: %s)", rec.Code, want, rec.Body.String())
+	}
+}